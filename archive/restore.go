@@ -24,7 +24,7 @@ type blockchainInterface interface {
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	GetHashByNumber(uint64) types.Hash
 	WriteBlock(*types.Block, string) error
-	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
+	VerifyFinalizedBlock(*types.Block, string) (*types.FullBlock, error)
 }
 
 // RestoreChain reads blocks from the archive and write to the chain
@@ -83,7 +83,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	nextBlock := firstBlock
 
 	for {
-		if _, err := chain.VerifyFinalizedBlock(nextBlock); err != nil {
+		if _, err := chain.VerifyFinalizedBlock(nextBlock, restore); err != nil {
 			return err
 		}
 
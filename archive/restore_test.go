@@ -55,7 +55,7 @@ func (m *mockChain) WriteBlock(block *types.Block, _ string) error {
 	return nil
 }
 
-func (m *mockChain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
+func (m *mockChain) VerifyFinalizedBlock(block *types.Block, source string) (*types.FullBlock, error) {
 	return &types.FullBlock{Block: block}, nil
 }
 
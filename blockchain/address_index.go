@@ -0,0 +1,487 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/addresstracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// addressIndexLogInterval is the number of the blocks between the progress logs of the backfill
+const addressIndexLogInterval = 1000
+
+// addressIndex keeps the state of the index of the canonical transactions by the addresses
+// they touched, the index is updated together with the chain under the write lock. The addresses
+// are collected by the execution of the verified blocks, the other blocks are traced by the backfill
+type addressIndex struct {
+	enabled atomic.Bool
+
+	// pending holds the changes of the batch being written
+	pending *addressIndexBatch
+
+	notifyCh chan struct{}
+	closeCh  chan struct{}
+	doneCh   chan struct{}
+}
+
+// addressIndexBatch collects the changes of the address index written in a single batch,
+// the transactions of the addresses are removed from the tail before the new ones are appended
+type addressIndexBatch struct {
+	db          storage.Storage
+	batchWriter *storage.BatchWriter
+
+	// head is the number of the last indexed block, exists is false if nothing is indexed yet
+	head   uint64
+	exists bool
+
+	counts map[types.Address]uint64
+}
+
+func newAddressIndexBatch(db storage.Storage, batchWriter *storage.BatchWriter) *addressIndexBatch {
+	head, exists := db.ReadAddressIndexHead()
+
+	return &addressIndexBatch{
+		db:          db,
+		batchWriter: batchWriter,
+		head:        head,
+		exists:      exists,
+		counts:      map[types.Address]uint64{},
+	}
+}
+
+func (a *addressIndexBatch) count(addr types.Address) uint64 {
+	count, ok := a.counts[addr]
+	if !ok {
+		count, _ = a.db.ReadAddressTxsCount(addr)
+		a.counts[addr] = count
+	}
+
+	return count
+}
+
+func (a *addressIndexBatch) setCount(addr types.Address, count uint64) {
+	a.counts[addr] = count
+
+	if count == 0 {
+		a.batchWriter.DeleteAddressTxsCount(addr)
+	} else {
+		a.batchWriter.PutAddressTxsCount(addr, count)
+	}
+}
+
+func (a *addressIndexBatch) setHead(number uint64) {
+	a.head, a.exists = number, true
+	a.batchWriter.PutAddressIndexHead(number)
+}
+
+// add appends the block to the index, the block has to follow the head of the index
+func (a *addressIndexBatch) add(block *types.Block, txAddresses storage.TxAddresses) {
+	for idx, addresses := range txAddresses {
+		addressTx := &storage.AddressTx{
+			BlockNumber: block.Number(),
+			TxHash:      block.Transactions[idx].Hash,
+		}
+
+		for _, addr := range addresses {
+			count := a.count(addr)
+
+			a.batchWriter.PutAddressTx(addr, count, addressTx)
+			a.setCount(addr, count+1)
+		}
+	}
+
+	if len(txAddresses) > 0 {
+		a.batchWriter.PutTxAddresses(block.Hash(), txAddresses)
+	}
+
+	a.setHead(block.Number())
+}
+
+// truncate removes the transactions of the given blocks above the given number from the index,
+// the addresses touched by the blocks are read from the storage
+func (a *addressIndexBatch) truncate(hashes []types.Hash, number uint64) error {
+	if !a.exists || a.head <= number {
+		return nil
+	}
+
+	for _, hash := range hashes {
+		txAddresses, err := a.db.ReadTxAddresses(hash)
+		if err != nil {
+			// the blocks without transactions are not recorded
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		for _, addresses := range txAddresses {
+			for _, addr := range addresses {
+				if err := a.truncateAddress(addr, number); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	a.setHead(number)
+
+	return nil
+}
+
+// truncateAddress removes the transactions of the address above the given number,
+// they are at the tail as the transactions are ordered by the blocks
+func (a *addressIndexBatch) truncateAddress(addr types.Address, number uint64) error {
+	count := a.count(addr)
+
+	for ; count > 0; count-- {
+		addressTx, err := a.db.ReadAddressTx(addr, count-1)
+		if err != nil {
+			return fmt.Errorf("failed to read the transaction %d of %s: %w", count-1, addr, err)
+		}
+
+		if addressTx.BlockNumber <= number {
+			break
+		}
+
+		a.batchWriter.DeleteAddressTx(addr, count-1)
+	}
+
+	if count != a.counts[addr] {
+		a.setCount(addr, count)
+	}
+
+	return nil
+}
+
+// EnableAddressIndex enables the index of the canonical transactions by the addresses they touched,
+// including the internal calls. The blocks written while the index was disabled are indexed in the background
+func (b *Blockchain) EnableAddressIndex() {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if b.addressIndex.enabled.Load() {
+		return
+	}
+
+	b.addressIndex.notifyCh = make(chan struct{}, 1)
+	b.addressIndex.closeCh = make(chan struct{})
+	b.addressIndex.doneCh = make(chan struct{})
+	b.addressIndex.enabled.Store(true)
+
+	go b.runAddressIndexBackfill()
+
+	b.notifyAddressIndex()
+}
+
+// AddressIndexHead returns the number of the last block in the address index,
+// it returns false if the index is disabled
+func (b *Blockchain) AddressIndexHead() (uint64, bool) {
+	if !b.addressIndex.enabled.Load() {
+		return 0, false
+	}
+
+	head, _ := b.db.ReadAddressIndexHead()
+
+	return head, true
+}
+
+// GetAddressTxsCount returns the number of the transactions in the address index which touched the address
+func (b *Blockchain) GetAddressTxsCount(addr types.Address) uint64 {
+	count, _ := b.db.ReadAddressTxsCount(addr)
+
+	return count
+}
+
+// GetAddressTx returns the transaction which touched the address at the given position in the address index,
+// the transactions of the address are ordered by the blocks and by the positions in the blocks
+func (b *Blockchain) GetAddressTx(addr types.Address, index uint64) (*storage.AddressTx, error) {
+	return b.db.ReadAddressTx(addr, index)
+}
+
+// addressIndexBatch returns the changes of the address index written in the given batch
+func (b *Blockchain) addressIndexBatch(batchWriter *storage.BatchWriter) *addressIndexBatch {
+	if pending := b.addressIndex.pending; pending != nil && pending.batchWriter == batchWriter {
+		return pending
+	}
+
+	b.addressIndex.pending = newAddressIndexBatch(b.db, batchWriter)
+
+	return b.addressIndex.pending
+}
+
+// reorgAddressIndex removes the blocks of the old chain from the address index and adds the blocks of the new one,
+// except for its head which is added once it's written. The index is truncated even if it's disabled
+func (b *Blockchain) reorgAddressIndex(
+	batchWriter *storage.BatchWriter,
+	oldChain []*types.Header,
+	ancestor *types.Header,
+	newChainHead *types.Header,
+) error {
+	batch := b.addressIndexBatch(batchWriter)
+
+	caughtUp := batch.exists && batch.head >= ancestor.Number
+
+	hashes := make([]types.Hash, 0, len(oldChain))
+	for _, header := range oldChain {
+		hashes = append(hashes, header.Hash)
+	}
+
+	if err := batch.truncate(hashes, ancestor.Number); err != nil {
+		return err
+	}
+
+	// the blocks are added by the backfill if the index isn't caught up with the old chain
+	if !b.addressIndex.enabled.Load() || !caughtUp {
+		return nil
+	}
+
+	newChain := []*types.Header{}
+
+	for header := newChainHead; header.ParentHash != ancestor.Hash; {
+		parent, ok := b.readHeader(header.ParentHash)
+		if !ok {
+			return fmt.Errorf("header '%s' not found", header.ParentHash)
+		}
+
+		newChain = append(newChain, parent)
+		header = parent
+	}
+
+	for i := len(newChain) - 1; i >= 0; i-- {
+		block, ok := b.GetBlockByHash(newChain[i].Hash, true)
+		if !ok {
+			return fmt.Errorf("block '%s' not found", newChain[i].Hash)
+		}
+
+		// the blocks aren't traced on the write path, the rest of the new chain is indexed by the backfill
+		txAddresses, stored := b.readStoredTxAddresses(block)
+		if !stored {
+			b.notifyAddressIndex()
+
+			break
+		}
+
+		batch.add(block, txAddresses)
+	}
+
+	return nil
+}
+
+// updateAddressIndex adds the new canonical block to the address index if the index is caught up
+// with the chain and the addresses were collected by the execution of the block,
+// otherwise the block is left to the backfill, so the blocks are never traced on the write path
+func (b *Blockchain) updateAddressIndex(
+	batchWriter *storage.BatchWriter,
+	block *types.Block,
+	txAddresses storage.TxAddresses,
+) {
+	if !b.addressIndex.enabled.Load() {
+		return
+	}
+
+	batch := b.addressIndexBatch(batchWriter)
+
+	if block.Number() == 0 || batch.head+1 != block.Number() || len(txAddresses) != len(block.Transactions) {
+		b.notifyAddressIndex()
+
+		return
+	}
+
+	batch.add(block, txAddresses)
+}
+
+// indexBlockAddresses adds the block following the head of the index to the index
+func (b *Blockchain) indexBlockAddresses(batch *addressIndexBatch, block *types.Block) error {
+	txAddresses, err := b.readTxAddresses(block)
+	if err != nil {
+		return err
+	}
+
+	batch.add(block, txAddresses)
+
+	return nil
+}
+
+// readStoredTxAddresses returns the stored addresses touched by the transactions of the block,
+// e.g. if the block is canonical again after a reorg. It returns false if they are not stored
+func (b *Blockchain) readStoredTxAddresses(block *types.Block) (storage.TxAddresses, bool) {
+	if len(block.Transactions) == 0 {
+		return nil, true
+	}
+
+	txAddresses, err := b.db.ReadTxAddresses(block.Hash())
+	if err != nil || len(txAddresses) != len(block.Transactions) {
+		return nil, false
+	}
+
+	return txAddresses, true
+}
+
+// readTxAddresses returns the addresses touched by the transactions of the block,
+// the block is traced unless the addresses are stored
+func (b *Blockchain) readTxAddresses(block *types.Block) (storage.TxAddresses, error) {
+	if txAddresses, ok := b.readStoredTxAddresses(block); ok {
+		return txAddresses, nil
+	}
+
+	parent, ok := b.readHeader(block.ParentHash())
+	if !ok {
+		return nil, ErrParentNotFound
+	}
+
+	blockCreator, err := b.consensus.GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	_, txAddresses, err := b.traceTxAddresses(parent.StateRoot, block, blockCreator)
+
+	return txAddresses, err
+}
+
+// processBlock executes the transactions of the block, the addresses they touched
+// are collected by the address tracer if the address index is enabled
+func (b *Blockchain) processBlock(
+	parentRoot types.Hash,
+	block *types.Block,
+	blockCreator types.Address,
+) (*state.Transition, storage.TxAddresses, error) {
+	if !b.addressIndex.enabled.Load() || len(block.Transactions) == 0 {
+		txn, err := b.executor.ProcessBlock(parentRoot, block, blockCreator)
+
+		return txn, nil, err
+	}
+
+	return b.traceTxAddresses(parentRoot, block, blockCreator)
+}
+
+// traceTxAddresses executes the transactions of the block the same way as Executor.ProcessBlock
+// and returns the addresses touched by each of them
+func (b *Blockchain) traceTxAddresses(
+	parentRoot types.Hash,
+	block *types.Block,
+	blockCreator types.Address,
+) (*state.Transition, storage.TxAddresses, error) {
+	txn, err := b.executor.BeginTxn(parentRoot, block.Header, blockCreator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tracer := addresstracer.NewAddressTracer()
+	txn.SetTracer(tracer)
+
+	txAddresses := make(storage.TxAddresses, len(block.Transactions))
+
+	for idx, tx := range block.Transactions {
+		tracer.Clear()
+
+		// the transactions are skipped by the execution of the block as well
+		if tx.Gas > block.Header.GasLimit {
+			continue
+		}
+
+		if err := txn.Write(tx); err != nil {
+			return nil, nil, fmt.Errorf("failed to execute the transaction %s: %w", tx.Hash, err)
+		}
+
+		txAddresses[idx] = tracer.Addresses()
+	}
+
+	// the changes made after the transactions, e.g. by the consensus, are not traced
+	txn.SetTracer(nil)
+
+	return txn, txAddresses, nil
+}
+
+func (b *Blockchain) notifyAddressIndex() {
+	select {
+	case b.addressIndex.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// runAddressIndexBackfill indexes the blocks which are not indexed yet, e.g. the blocks written
+// before the index was enabled, one by one so the new blocks can be written in between
+func (b *Blockchain) runAddressIndexBackfill() {
+	defer close(b.addressIndex.doneCh)
+
+	for {
+		select {
+		case <-b.addressIndex.notifyCh:
+		case <-b.addressIndex.closeCh:
+			return
+		}
+
+		for {
+			number, indexed, err := b.backfillAddressIndex()
+			if err != nil {
+				b.logger.Error("failed to backfill the address index", "number", number, "err", err)
+
+				break
+			}
+
+			if !indexed {
+				break
+			}
+
+			if number%addressIndexLogInterval == 0 {
+				b.logger.Info("address index backfilled", "number", number, "head", b.Header().Number)
+			}
+
+			select {
+			case <-b.addressIndex.closeCh:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// backfillAddressIndex indexes the canonical block following the head of the index,
+// it returns false if the index is caught up with the chain
+func (b *Blockchain) backfillAddressIndex() (uint64, bool, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	batchWriter := storage.NewBatchWriter(b.db)
+	batch := b.addressIndexBatch(batchWriter)
+
+	defer func() {
+		b.addressIndex.pending = nil
+	}()
+
+	number := batch.head + 1
+	if number > b.Header().Number {
+		return number, false, nil
+	}
+
+	block, ok := b.GetBlockByNumber(number, true)
+	if !ok {
+		return number, false, fmt.Errorf("block %d not found", number)
+	}
+
+	if err := b.indexBlockAddresses(batch, block); err != nil {
+		return number, false, err
+	}
+
+	if err := batchWriter.WriteBatch(); err != nil {
+		return number, false, err
+	}
+
+	return number, true, nil
+}
+
+// closeAddressIndex stops the backfill of the address index
+func (b *Blockchain) closeAddressIndex() {
+	if !b.addressIndex.enabled.Load() {
+		return
+	}
+
+	close(b.addressIndex.closeCh)
+	<-b.addressIndex.doneCh
+}
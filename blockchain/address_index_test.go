@@ -0,0 +1,270 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addressIndexSender   = types.StringToAddress("0x1000")
+	addressIndexContract = types.StringToAddress("0x2000")
+	addressIndexSink     = types.StringToAddress("0x3000")
+	addressIndexOther    = types.StringToAddress("0x4000")
+)
+
+// addressIndexTestChain is a blockchain with the real executor,
+// the contract forwards the value of the call to the sink
+type addressIndexTestChain struct {
+	t      *testing.T
+	b      *Blockchain
+	signer *mockSigner
+}
+
+func newAddressIndexTestChain(t *testing.T) *addressIndexTestChain {
+	t.Helper()
+
+	params := &chain.Params{
+		Forks: &chain.Forks{
+			chain.EIP155:    chain.NewFork(0),
+			chain.Homestead: chain.NewFork(0),
+			chain.EIP150:    chain.NewFork(0),
+			chain.EIP158:    chain.NewFork(0),
+		},
+		ChainID: 100,
+	}
+
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	// CALL(gas, sink, callvalue, 0, 0, 0, 0)
+	code := hex.MustDecodeHex("0x60006000600060003473" + hex.EncodeToString(addressIndexSink.Bytes()) + "5af100")
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		addressIndexSender:   {Balance: big.NewInt(1_000_000_000)},
+		addressIndexContract: {Code: code},
+	}
+
+	root, err := executor.WriteGenesis(alloc, types.EmptyRootHash)
+	require.NoError(t, err)
+
+	config := &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit:  10_000_000,
+			StateRoot: root,
+		},
+		Params: params,
+	}
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	signer := &mockSigner{txFromByTxHash: map[types.Hash]types.Address{}}
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), db, config, &MockVerifier{}, executor, signer)
+	require.NoError(t, err)
+
+	executor.GetHash = b.GetHashHelper
+
+	require.NoError(t, b.ComputeGenesis())
+
+	t.Cleanup(func() {
+		require.NoError(t, b.Close())
+	})
+
+	return &addressIndexTestChain{t: t, b: b, signer: signer}
+}
+
+// newTx returns the transaction of the sender with the given nonce
+func (c *addressIndexTestChain) newTx(nonce uint64, to types.Address, number uint64) *types.Transaction {
+	tx := &types.Transaction{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    big.NewInt(10),
+		Gas:      100_000,
+		GasPrice: big.NewInt(1),
+		From:     addressIndexSender,
+		V:        big.NewInt(1),
+	}
+	tx.ComputeHash(number)
+
+	c.signer.txFromByTxHash[tx.Hash] = addressIndexSender
+
+	return tx
+}
+
+// newBlock executes the transactions on top of the parent and returns the block with the resulting state root
+func (c *addressIndexTestChain) newBlock(parent *types.Header, txs ...*types.Transaction) *types.Block {
+	c.t.Helper()
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number:     parent.Number + 1,
+			ParentHash: parent.Hash,
+			Difficulty: 1,
+			GasLimit:   10_000_000,
+			Timestamp:  parent.Timestamp + 1,
+			Sha3Uncles: types.EmptyUncleHash,
+		},
+		Transactions: txs,
+	}
+	block.Header.ComputeHash()
+
+	res, err := c.b.executeBlockTransactions(block)
+	require.NoError(c.t, err)
+
+	block.Header.StateRoot = res.Root
+	block.Header.GasUsed = res.TotalGas
+	block.Header.ComputeHash()
+
+	return block
+}
+
+// writeBlock executes the transactions on top of the parent and writes the block
+func (c *addressIndexTestChain) writeBlock(parent *types.Header, txs ...*types.Transaction) *types.Block {
+	c.t.Helper()
+
+	block := c.newBlock(parent, txs...)
+	require.NoError(c.t, c.b.WriteBlock(block, "test"))
+
+	return block
+}
+
+// writeFork writes the block which isn't on top of the head, the blocks are written only by their headers then
+func (c *addressIndexTestChain) writeFork(parent *types.Header, txs ...*types.Transaction) *types.Block {
+	c.t.Helper()
+
+	block := c.newBlock(parent, txs...)
+
+	batchWriter := storage.NewBatchWriter(c.b.db)
+	batchWriter.PutBody(block.Hash(), block.Body())
+	require.NoError(c.t, batchWriter.WriteBatch())
+
+	require.NoError(c.t, c.b.WriteHeadersWithBodies([]*types.Header{block.Header}))
+
+	return block
+}
+
+// addressTxs returns the hashes of the transactions of the address in the index
+func (c *addressIndexTestChain) addressTxs(addr types.Address) []types.Hash {
+	c.t.Helper()
+
+	count := c.b.GetAddressTxsCount(addr)
+	hashes := make([]types.Hash, 0, count)
+
+	for i := uint64(0); i < count; i++ {
+		addressTx, err := c.b.GetAddressTx(addr, i)
+		require.NoError(c.t, err)
+
+		hashes = append(hashes, addressTx.TxHash)
+	}
+
+	return hashes
+}
+
+func (c *addressIndexTestChain) waitAddressIndex(number uint64) {
+	c.t.Helper()
+
+	require.Eventually(c.t, func() bool {
+		head, ok := c.b.AddressIndexHead()
+
+		return ok && head == number
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBlockchain_AddressIndex(t *testing.T) {
+	t.Parallel()
+
+	c := newAddressIndexTestChain(t)
+
+	_, ok := c.b.AddressIndexHead()
+	assert.False(t, ok)
+
+	// the blocks written before the index is enabled are backfilled
+	tx1 := c.newTx(0, addressIndexContract, 1)
+	block1 := c.writeBlock(c.b.Header(), tx1)
+
+	tx2 := c.newTx(1, addressIndexOther, 2)
+	block2 := c.writeBlock(block1.Header, tx2)
+
+	assert.Equal(t, uint64(0), c.b.GetAddressTxsCount(addressIndexSender))
+
+	c.b.EnableAddressIndex()
+	c.waitAddressIndex(2)
+
+	assert.Equal(t, []types.Hash{tx1.Hash, tx2.Hash}, c.addressTxs(addressIndexSender))
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexContract))
+	assert.Equal(t, []types.Hash{tx2.Hash}, c.addressTxs(addressIndexOther))
+
+	// the internal call is indexed as well
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexSink))
+
+	// the new blocks are indexed with the addresses collected by their verification
+	tx3 := c.newTx(2, addressIndexContract, 3)
+	block3 := c.writeBlock(block2.Header, tx3)
+
+	head, ok := c.b.AddressIndexHead()
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), head)
+	assert.Equal(t, []types.Hash{tx1.Hash, tx3.Hash}, c.addressTxs(addressIndexSink))
+
+	addressTx, err := c.b.GetAddressTx(addressIndexSink, 1)
+	require.NoError(t, err)
+	assert.Equal(t, block3.Number(), addressTx.BlockNumber)
+
+	// the blocks of the old chain are removed by the reorg
+	forkTx3 := c.newTx(2, addressIndexOther, 3)
+	forkBlock3 := c.writeFork(block2.Header, forkTx3)
+
+	// the fork with the same difficulty isn't canonical
+	head, _ = c.b.AddressIndexHead()
+	assert.Equal(t, uint64(3), head)
+	assert.Equal(t, []types.Hash{tx2.Hash}, c.addressTxs(addressIndexOther))
+
+	c.writeBlock(forkBlock3.Header)
+	assert.Equal(t, forkBlock3.Hash(), c.b.Header().ParentHash)
+
+	// the fork block wasn't executed when it was written, so it's indexed by the backfill
+	c.waitAddressIndex(4)
+	assert.Equal(t, []types.Hash{tx1.Hash, tx2.Hash, forkTx3.Hash}, c.addressTxs(addressIndexSender))
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexSink))
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexContract))
+	assert.Equal(t, []types.Hash{tx2.Hash, forkTx3.Hash}, c.addressTxs(addressIndexOther))
+
+	// the blocks above the new head are removed by the rewind
+	_, err = c.b.SetHead(1)
+	require.NoError(t, err)
+
+	head, _ = c.b.AddressIndexHead()
+	assert.Equal(t, uint64(1), head)
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexSender))
+	assert.Empty(t, c.addressTxs(addressIndexOther))
+	assert.Equal(t, []types.Hash{tx1.Hash}, c.addressTxs(addressIndexSink))
+}
+
+func TestBlockchain_AddressIndex_Disabled(t *testing.T) {
+	t.Parallel()
+
+	c := newAddressIndexTestChain(t)
+
+	c.writeBlock(c.b.Header(), c.newTx(0, addressIndexContract, 1))
+
+	_, ok := c.b.AddressIndexHead()
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), c.b.GetAddressTxsCount(addressIndexSender))
+	assert.Equal(t, uint64(0), c.b.GetAddressTxsCount(addressIndexSink))
+
+	_, ok = c.b.db.ReadAddressIndexHead()
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// GetBadBlocks returns the last blocks rejected by the verification, starting from the most recent one
+func (b *Blockchain) GetBadBlocks() ([]*storage.BadBlock, error) {
+	badBlocks, err := b.db.ReadBadBlocks()
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return []*storage.BadBlock{}, nil
+		}
+
+		return nil, err
+	}
+
+	// the senders aren't part of the stored transactions
+	for _, badBlock := range badBlocks {
+		b.recoverFromFieldsInTransactions(badBlock.Block.Transactions)
+	}
+
+	return badBlocks, nil
+}
+
+// writeBadBlock stores the rejected block together with the reason of the rejection,
+// only the last badBlocksLimit blocks are kept. The blocks whose parent isn't known
+// are not stored, as they are rejected before they are actually verified
+func (b *Blockchain) writeBadBlock(block *types.Block, reason error, source string) {
+	if block == nil || block.Header == nil || errors.Is(reason, ErrParentNotFound) {
+		return
+	}
+
+	b.badBlocksLock.Lock()
+	defer b.badBlocksLock.Unlock()
+
+	stored, err := b.db.ReadBadBlocks()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		b.logger.Error("failed to read the bad blocks", "err", err)
+
+		return
+	}
+
+	badBlocks := make([]*storage.BadBlock, 0, badBlocksLimit)
+	badBlocks = append(badBlocks, &storage.BadBlock{
+		Block:  block,
+		Reason: reason.Error(),
+		Source: source,
+		Time:   uint64(time.Now().Unix()),
+	})
+
+	// the block rejected again replaces its previous record
+	for _, badBlock := range stored {
+		if len(badBlocks) == badBlocksLimit {
+			break
+		}
+
+		if badBlock.Block.Hash() != block.Hash() {
+			badBlocks = append(badBlocks, badBlock)
+		}
+	}
+
+	batch := storage.NewBatchWriter(b.db)
+	batch.PutBadBlocks(badBlocks)
+
+	if err := batch.WriteBatch(); err != nil {
+		b.logger.Error("failed to write the bad block", "number", block.Number(), "err", err)
+
+		return
+	}
+
+	b.logger.Warn("bad block stored",
+		"number", block.Number(), "hash", block.Hash(), "source", source, "reason", reason)
+}
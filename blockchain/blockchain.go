@@ -24,6 +24,9 @@ const (
 
 	// defaultCacheSize is the default size for Blockchain LRU cache structures
 	defaultCacheSize int = 100
+
+	// badBlocksLimit is the number of the last rejected blocks kept in the storage
+	badBlocksLimit = 10
 )
 
 var (
@@ -74,6 +77,10 @@ type Blockchain struct {
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
 	writeLock sync.Mutex
+
+	badBlocksLock sync.Mutex
+
+	addressIndex addressIndex // The index of the transactions by the addresses, updated under the write lock
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -93,6 +100,7 @@ type Verifier interface {
 
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
+	BeginTxn(parentRoot types.Hash, header *types.Header, coinbaseReceiver types.Address) (*state.Transition, error)
 }
 
 type TxSigner interface {
@@ -104,6 +112,9 @@ type BlockResult struct {
 	Root     types.Hash
 	Receipts []*types.Receipt
 	TotalGas uint64
+
+	// TxAddresses are the addresses touched by the transactions, collected if the address index is enabled
+	TxAddresses storage.TxAddresses
 }
 
 // updateGasPriceAvg updates the rolling average value of the gas price
@@ -403,7 +414,7 @@ func (b *Blockchain) writeGenesisImpl(header *types.Header) error {
 
 	batchWriter.PutCanonicalHeader(header, newTD)
 
-	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, true); err != nil {
+	if err := b.writeBatchAndUpdate(batchWriter, &types.Block{Header: header}, nil, newTD, true); err != nil {
 		return err
 	}
 
@@ -437,8 +448,13 @@ func (b *Blockchain) GetTD(hash types.Hash) (*big.Int, bool) {
 	return b.readTotalDifficulty(hash)
 }
 
-// GetReceiptsByHash returns the receipts by their hash
+// GetReceiptsByHash returns the receipts by their hash,
+// the receipts of the recently processed blocks are served from the cache
 func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	if receipts, err := b.GetCachedReceipts(hash); err == nil {
+		return receipts, nil
+	}
+
 	return b.db.ReadReceipts(hash)
 }
 
@@ -579,7 +595,14 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 			return err
 		}
 
-		if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+		// the bodies are written beforehand, the transactions are needed by the address index
+		block := &types.Block{Header: header}
+		if body, err := b.db.ReadBody(header.Hash); err == nil {
+			b.recoverFromFieldsInTransactions(body.Transactions)
+			block.Transactions = body.Transactions
+		}
+
+		if err := b.writeBatchAndUpdate(batchWriter, block, nil, newTD, isCanonical); err != nil {
 			return err
 		}
 
@@ -592,7 +615,8 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 
 // VerifyPotentialBlock does the minimal block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
-// outside the method call
+// outside the method call. The rejected proposal is not stored as a bad block,
+// as its header isn't verified by the consensus
 func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 	// Do just the initial block verification
 	_, err := b.verifyBlock(block)
@@ -601,25 +625,33 @@ func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 }
 
 // VerifyFinalizedBlock verifies that the block is valid by performing a series of checks.
-// It is assumed that the block status is sealed (committed)
-func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
+// It is assumed that the block status is sealed (committed). The block rejected after its header
+// is verified by the consensus is stored as a bad block together with the source it was received from,
+// the headers failing the verification are not stored, as anyone can send them
+func (b *Blockchain) VerifyFinalizedBlock(block *types.Block, source string) (*types.FullBlock, error) {
 	// Make sure the consensus layer verifies this block header
 	if err := b.consensus.VerifyHeader(block.Header); err != nil {
 		return nil, fmt.Errorf("failed to verify the header: %w", err)
 	}
 
 	// Do the initial block verification
-	receipts, err := b.verifyBlock(block)
+	blockResult, err := b.verifyBlock(block)
 	if err != nil {
+		b.writeBadBlock(block, err, source)
+
 		return nil, err
 	}
 
-	return &types.FullBlock{Block: block, Receipts: receipts}, nil
+	return &types.FullBlock{
+		Block:       block,
+		Receipts:    blockResult.Receipts,
+		TxAddresses: blockResult.TxAddresses,
+	}, nil
 }
 
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
-func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
+func (b *Blockchain) verifyBlock(block *types.Block) (*BlockResult, error) {
 	// Make sure the block is present
 	if block == nil {
 		return nil, ErrNoBlock
@@ -689,7 +721,7 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The trie roots match up (state, transactions, receipts, uncles)
 // - The receipts match up
 // - The execution result matches up
-func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+func (b *Blockchain) verifyBlockBody(block *types.Block) (*BlockResult, error) {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -723,7 +755,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
 	}
 
-	return blockResult.Receipts, nil
+	return blockResult, nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -768,7 +800,7 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 		return nil, err
 	}
 
-	txn, err := b.executor.ProcessBlock(parent.StateRoot, block, blockCreator)
+	txn, txAddresses, err := b.processBlock(parent.StateRoot, block, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -786,9 +818,10 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 	b.receiptsCache.Add(header.Hash, txn.Receipts())
 
 	return &BlockResult{
-		Root:     root,
-		Receipts: txn.Receipts(),
-		TotalGas: txn.TotalGas(),
+		Root:        root,
+		Receipts:    txn.Receipts(),
+		TotalGas:    txn.TotalGas(),
+		TxAddresses: txAddresses,
 	}, nil
 }
 
@@ -837,7 +870,7 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 	// Update the average gas price
 	b.updateGasPriceAvgWithBlock(block)
 
-	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+	if err := b.writeBatchAndUpdate(batchWriter, block, fblock.TxAddresses, newTD, isCanonical); err != nil {
 		return err
 	}
 
@@ -890,7 +923,7 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	}
 
 	// Fetch the block receipts
-	blockReceipts, receiptsErr := b.extractBlockReceipts(block)
+	blockReceipts, txAddresses, receiptsErr := b.extractBlockReceipts(block)
 	if receiptsErr != nil {
 		return receiptsErr
 	}
@@ -908,7 +941,7 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	// Update the average gas price
 	b.updateGasPriceAvgWithBlock(block)
 
-	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+	if err := b.writeBatchAndUpdate(batchWriter, block, txAddresses, newTD, isCanonical); err != nil {
 		return err
 	}
 
@@ -947,8 +980,9 @@ func (b *Blockchain) GetCachedReceipts(headerHash types.Hash) ([]*types.Receipt,
 	return extractedReceipts, nil
 }
 
-// extractBlockReceipts extracts the receipts from the passed in block
-func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt, error) {
+// extractBlockReceipts extracts the receipts from the passed in block, together with the addresses touched
+// by its transactions if they are executed now and the address index is enabled
+func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt, storage.TxAddresses, error) {
 	// Check the cache for the block receipts
 	receipts, ok := b.receiptsCache.Get(block.Header.Hash)
 	if !ok {
@@ -956,18 +990,18 @@ func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt,
 		// and fetch them
 		blockResult, err := b.executeBlockTransactions(block)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return blockResult.Receipts, nil
+		return blockResult.Receipts, blockResult.TxAddresses, nil
 	}
 
 	extractedReceipts, ok := receipts.([]*types.Receipt)
 	if !ok {
-		return nil, errors.New("invalid type assertion for receipts")
+		return nil, nil, errors.New("invalid type assertion for receipts")
 	}
 
-	return extractedReceipts, nil
+	return extractedReceipts, nil, nil
 }
 
 // updateGasPriceAvgWithBlock extracts the gas price information from the
@@ -1273,6 +1307,12 @@ func (b *Blockchain) handleReorg(
 		return fmt.Errorf("failed to write the old header as fork: %w", err)
 	}
 
+	// remove the transactions of the old chain from the address index
+	removed := append([]*types.Header{oldChainHead}, oldChain[:len(oldChain)-1]...)
+	if err := b.reorgAddressIndex(batchWriter, removed, oldChain[len(oldChain)-1], newChainHead); err != nil {
+		return fmt.Errorf("failed to update the address index: %w", err)
+	}
+
 	batchWriter.PutForks(forks)
 
 	// Update canonical chain numbers
@@ -1348,6 +1388,8 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
+	b.closeAddressIndex()
+
 	return b.db.Close()
 }
 
@@ -1398,9 +1440,21 @@ func (b *Blockchain) calcBaseFeeDelta(gasUsedDelta, parentGasTarget, baseFee uin
 
 func (b *Blockchain) writeBatchAndUpdate(
 	batchWriter *storage.BatchWriter,
-	header *types.Header,
+	block *types.Block,
+	txAddresses storage.TxAddresses,
 	newTD *big.Int,
 	isCanonnical bool) error {
+	header := block.Header
+
+	if isCanonnical {
+		b.updateAddressIndex(batchWriter, block, txAddresses)
+	}
+
+	// the changes of the address index are written by the batch
+	defer func() {
+		b.addressIndex.pending = nil
+	}()
+
 	if err := batchWriter.WriteBatch(); err != nil {
 		return err
 	}
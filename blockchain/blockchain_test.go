@@ -542,7 +542,7 @@ func TestForkUnknownParents(t *testing.T) {
 
 	batchWriter.PutCanonicalHeader(h0[0], td)
 
-	assert.NoError(t, b.writeBatchAndUpdate(batchWriter, h0[0], td, true))
+	assert.NoError(t, b.writeBatchAndUpdate(batchWriter, &types.Block{Header: h0[0]}, nil, td, true))
 
 	// Write 10 headers
 	assert.NoError(t, b.WriteHeadersWithBodies(h0[1:]))
@@ -1521,3 +1521,201 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.CANONICAL, common.EncodeUint64ToBytes(header.Number)))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.RECEIPTS, header.Hash.Bytes()))])
 }
+
+func TestBlockchain_GetReceiptsByHash(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cachedHash     = types.StringToHash("1")
+		storedHash     = types.StringToHash("2")
+		cachedReceipts = []*types.Receipt{{GasUsed: 100}}
+		storedReceipts = []*types.Receipt{{GasUsed: 200}}
+	)
+
+	storageMock := storage.NewMockStorage()
+	storageMock.HookReadReceipts(func(hash types.Hash) ([]*types.Receipt, error) {
+		require.Equal(t, storedHash, hash, "the cached receipts should not be read from the storage")
+
+		return storedReceipts, nil
+	})
+
+	bc := &Blockchain{
+		db: storageMock,
+	}
+	require.NoError(t, bc.initCaches(10))
+
+	bc.receiptsCache.Add(cachedHash, cachedReceipts)
+
+	receipts, err := bc.GetReceiptsByHash(cachedHash)
+	require.NoError(t, err)
+	require.Equal(t, cachedReceipts, receipts)
+
+	receipts, err = bc.GetReceiptsByHash(storedHash)
+	require.NoError(t, err)
+	require.Equal(t, storedReceipts, receipts)
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	// the transactions of the kept and the removed blocks
+	txs := make([]*types.Transaction, 2)
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	for i, number := range []int{3, 7} {
+		txs[i] = &types.Transaction{Nonce: uint64(i), Value: big.NewInt(1), V: big.NewInt(1)}
+		txs[i].ComputeHash(1)
+
+		batchWriter.PutBody(headers[number].Hash, &types.Body{Transactions: []*types.Transaction{txs[i]}})
+		batchWriter.PutTxLookup(txs[i].Hash, headers[number].Hash)
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+
+	sub := b.SubscribeEvents()
+	defer b.UnsubscribeEvents(sub)
+
+	head, err := b.SetHead(5)
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash, head.Hash)
+	assert.Equal(t, headers[5].Hash, b.Header().Hash)
+
+	headNumber, ok := b.db.ReadHeadNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), headNumber)
+
+	headHash, ok := b.db.ReadHeadHash()
+	require.True(t, ok)
+	assert.Equal(t, headers[5].Hash, headHash)
+
+	for _, h := range headers[6:] {
+		_, ok := b.GetHeaderByNumber(h.Number)
+		assert.False(t, ok)
+
+		// the removed blocks are kept in the storage
+		_, ok = b.GetHeaderByHash(h.Hash)
+		assert.True(t, ok)
+	}
+
+	_, ok = b.ReadTxLookup(txs[0].Hash)
+	assert.True(t, ok)
+
+	_, ok = b.ReadTxLookup(txs[1].Hash)
+	assert.False(t, ok)
+
+	// the removed blocks are announced as the old chain of a reorg
+	evnt := sub.GetEvent()
+	require.NotNil(t, evnt)
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Len(t, evnt.OldChain, 4)
+	require.Len(t, evnt.NewChain, 1)
+	assert.Equal(t, headers[5].Hash, evnt.NewChain[0].Hash)
+
+	// the blocks above the head can't be set as the head
+	_, err = b.SetHead(6)
+	require.Error(t, err)
+
+	// the removed blocks can be imported again
+	require.NoError(t, b.WriteHeadersWithBodies(headers[6:]))
+	assert.Equal(t, headers[9].Hash, b.Header().Hash)
+}
+
+func TestBlockchain_BadBlocks(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(3)
+	b := NewTestBlockchain(t, headers)
+
+	newBadBlock := func(seed byte) *types.Block {
+		header := &types.Header{
+			Number:     3,
+			ParentHash: headers[2].Hash,
+			Sha3Uncles: types.ZeroHash,
+			ExtraData:  []byte{seed},
+		}
+		header.ComputeHash()
+
+		return &types.Block{Header: header}
+	}
+
+	badBlocks, err := b.GetBadBlocks()
+	require.NoError(t, err)
+	assert.Empty(t, badBlocks)
+
+	block := newBadBlock(0)
+
+	_, err = b.VerifyFinalizedBlock(block, "peer")
+	require.ErrorIs(t, err, ErrInvalidSha3Uncles)
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, block.Hash(), badBlocks[0].Block.Hash())
+	assert.Equal(t, ErrInvalidSha3Uncles.Error(), badBlocks[0].Reason)
+	assert.Equal(t, "peer", badBlocks[0].Source)
+	assert.NotZero(t, badBlocks[0].Time)
+
+	// the block rejected again replaces its previous record
+	_, err = b.VerifyFinalizedBlock(block, "other peer")
+	require.ErrorIs(t, err, ErrInvalidSha3Uncles)
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, "other peer", badBlocks[0].Source)
+
+	// the rejected proposal is not stored, as its header isn't verified by the consensus
+	proposal := newBadBlock(0xfe)
+	require.ErrorIs(t, b.VerifyPotentialBlock(proposal), ErrInvalidSha3Uncles)
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, block.Hash(), badBlocks[0].Block.Hash())
+
+	// the block with the unknown parent is not stored
+	orphan := newBadBlock(1)
+	orphan.Header.ParentHash = types.StringToHash("unknown")
+
+	_, err = b.VerifyFinalizedBlock(orphan, "peer")
+	require.ErrorIs(t, err, ErrParentNotFound)
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+
+	// the block whose header isn't verified by the consensus is not stored
+	errInvalidSeal := errors.New("invalid seal")
+	unsealed := newBadBlock(0xff)
+
+	b.consensus.(*MockVerifier).HookVerifyHeader(func(header *types.Header) error {
+		if header.Hash == unsealed.Hash() {
+			return errInvalidSeal
+		}
+
+		return nil
+	})
+
+	_, err = b.VerifyFinalizedBlock(unsealed, "peer")
+	require.ErrorIs(t, err, errInvalidSeal)
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, block.Hash(), badBlocks[0].Block.Hash())
+
+	// only the last blocks are kept, starting from the most recent one
+	for i := 1; i <= badBlocksLimit; i++ {
+		_, err = b.VerifyFinalizedBlock(newBadBlock(byte(i)), "peer")
+		require.Error(t, err)
+	}
+
+	badBlocks, err = b.GetBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, badBlocksLimit)
+	assert.Equal(t, newBadBlock(badBlocksLimit).Hash(), badBlocks[0].Block.Hash())
+	assert.Equal(t, newBadBlock(1).Hash(), badBlocks[badBlocksLimit-1].Block.Hash())
+}
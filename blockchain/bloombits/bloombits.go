@@ -0,0 +1,118 @@
+package bloombits
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// SectionSize is the number of the blocks in a section of the index
+	SectionSize = uint64(4096)
+
+	// BloomBitLength is the number of the bits in a bloom
+	BloomBitLength = types.BloomByteLength * 8
+
+	// sectionBytes is the size of the bit-vector of a section
+	sectionBytes = SectionSize / 8
+)
+
+var (
+	ErrSectionFull     = errors.New("section is already full")
+	ErrSectionNotFull  = errors.New("section is not full")
+	ErrInvalidBitIndex = fmt.Errorf("bit index must be lower than %d", BloomBitLength)
+)
+
+// Generator rotates the blooms of a section into the bit-vectors,
+// so the n-th bit of the vector i is the bit i of the n-th bloom in the section
+type Generator struct {
+	vectors [BloomBitLength][]byte
+	next    uint64
+}
+
+func NewGenerator() *Generator {
+	g := &Generator{}
+
+	for i := range g.vectors {
+		g.vectors[i] = make([]byte, sectionBytes)
+	}
+
+	return g
+}
+
+// AddBloom adds the bloom of the next block in the section
+func (g *Generator) AddBloom(bloom types.Bloom) error {
+	if g.next >= SectionSize {
+		return ErrSectionFull
+	}
+
+	byteIndex, bitMask := g.next/8, byte(1)<<(7-g.next%8)
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		if isBitSet(bloom, bit) {
+			g.vectors[bit][byteIndex] |= bitMask
+		}
+	}
+
+	g.next++
+
+	return nil
+}
+
+// Bitset returns the bit-vector of the given bloom bit once the section is full
+func (g *Generator) Bitset(bit uint) ([]byte, error) {
+	if g.next != SectionSize {
+		return nil, ErrSectionNotFull
+	}
+
+	if bit >= BloomBitLength {
+		return nil, ErrInvalidBitIndex
+	}
+
+	return g.vectors[bit], nil
+}
+
+// BloomIndexes returns the indexes of the bloom bits which are set by the given value
+func BloomIndexes(data []byte) [3]uint {
+	buf := keccak.Keccak256(nil, data)
+
+	var indexes [3]uint
+
+	for i := 0; i < 6; i += 2 {
+		indexes[i/2] = (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomBitLength - 1)
+	}
+
+	return indexes
+}
+
+// isBitSet checks if the bit is set in the bloom, the bits are numbered
+// the same way as they are set by the types.Bloom
+func isBitSet(bloom types.Bloom, bit uint) bool {
+	return bloom[types.BloomByteLength-1-bit/8]&(1<<(bit%8)) != 0
+}
+
+// encodeBits returns the stored form of the bit-vector, the vectors
+// without any bit set are stored empty as most of them are on the quiet chains
+func encodeBits(bits []byte) []byte {
+	for _, b := range bits {
+		if b != 0 {
+			return bits
+		}
+	}
+
+	return []byte{}
+}
+
+// decodeBits returns the bit-vector from its stored form
+func decodeBits(data []byte) ([]byte, error) {
+	switch uint64(len(data)) {
+	case 0:
+		return make([]byte, sectionBytes), nil
+	case sectionBytes:
+		return data, nil
+	default:
+		return nil, fmt.Errorf("invalid bit-vector length %d", len(data))
+	}
+}
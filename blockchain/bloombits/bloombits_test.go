@@ -0,0 +1,98 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBloomIndexes(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	bloom := types.CreateBloom([]*types.Receipt{
+		{Logs: []*types.Log{{Address: addr}}},
+	})
+
+	set := 0
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		if isBitSet(bloom, bit) {
+			set++
+		}
+	}
+
+	// the indexes are the bits set by the bloom
+	for _, bit := range BloomIndexes(addr.Bytes()) {
+		assert.True(t, isBitSet(bloom, bit))
+	}
+
+	assert.LessOrEqual(t, set, 3)
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	var bloom types.Bloom
+
+	// bit 0 is the last bit of the last byte, bit 2047 is the first bit of the first byte
+	bloom[types.BloomByteLength-1] = 0x1
+	bloom[0] = 0x80
+
+	gen := NewGenerator()
+
+	_, err := gen.Bitset(0)
+	require.ErrorIs(t, err, ErrSectionNotFull)
+
+	for n := uint64(0); n < SectionSize; n++ {
+		if n == 1 || n == 10 {
+			require.NoError(t, gen.AddBloom(bloom))
+		} else {
+			require.NoError(t, gen.AddBloom(types.Bloom{}))
+		}
+	}
+
+	require.ErrorIs(t, gen.AddBloom(bloom), ErrSectionFull)
+
+	expected := make([]byte, sectionBytes)
+	expected[0] = 0x40
+	expected[1] = 0x20
+
+	for _, bit := range []uint{0, BloomBitLength - 1} {
+		bits, err := gen.Bitset(bit)
+		require.NoError(t, err)
+		assert.Equal(t, expected, bits)
+	}
+
+	bits, err := gen.Bitset(1)
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, sectionBytes), bits)
+
+	_, err = gen.Bitset(BloomBitLength)
+	require.ErrorIs(t, err, ErrInvalidBitIndex)
+}
+
+func TestEncodeBits(t *testing.T) {
+	t.Parallel()
+
+	empty := make([]byte, sectionBytes)
+	assert.Empty(t, encodeBits(empty))
+
+	decoded, err := decodeBits(encodeBits(empty))
+	require.NoError(t, err)
+	assert.Equal(t, empty, decoded)
+
+	bits := make([]byte, sectionBytes)
+	bits[7] = 0x3
+
+	decoded, err = decodeBits(encodeBits(bits))
+	require.NoError(t, err)
+	assert.Equal(t, bits, decoded)
+
+	_, err = decodeBits([]byte{0x1})
+	require.Error(t, err)
+}
@@ -0,0 +1,286 @@
+package bloombits
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// confirmations is the number of the blocks which have to follow a section
+// before it's indexed, so the indexed sections are not affected by the reorgs
+const confirmations = uint64(256)
+
+// blockchainEvents is the part of the blockchain which notifies the indexer about the new blocks
+type blockchainEvents interface {
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(blockchain.Subscription)
+}
+
+// Indexer builds the bloom-bits index of the completed sections in the background,
+// the index is stored in the blockchain storage next to the blocks
+type Indexer struct {
+	logger hclog.Logger
+	db     storage.Storage
+
+	// lock serializes the updates of the index
+	lock     sync.Mutex
+	sections atomic.Uint64
+
+	// reorgFrom is the lowest number of the block removed by a reorg
+	// since the last update, it's zero if there was no reorg
+	reorgLock sync.Mutex
+	reorgFrom uint64
+
+	chain        blockchainEvents
+	subscription blockchain.Subscription
+
+	updateCh chan struct{}
+	closeCh  chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewIndexer(logger hclog.Logger, db storage.Storage) *Indexer {
+	i := &Indexer{
+		logger:   logger.Named("bloombits"),
+		db:       db,
+		updateCh: make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+	}
+
+	if sections, ok := db.ReadBloomBitsSections(); ok {
+		i.sections.Store(sections)
+	}
+
+	return i
+}
+
+// Sections returns the number of the indexed sections
+func (i *Indexer) Sections() uint64 {
+	return i.sections.Load()
+}
+
+// Start indexes the completed sections in the background and keeps
+// the index up to date with the blocks written to the chain
+func (i *Indexer) Start(chain blockchainEvents) {
+	i.chain = chain
+	i.subscription = chain.SubscribeEvents()
+
+	i.wg.Add(2)
+
+	go i.watchEvents()
+	go i.run()
+
+	// index the sections completed while the node was stopped
+	i.notify()
+}
+
+// Close stops the indexer, the index of the section being built is discarded
+func (i *Indexer) Close() {
+	if i.chain != nil {
+		i.chain.UnsubscribeEvents(i.subscription)
+	}
+
+	close(i.closeCh)
+	i.wg.Wait()
+}
+
+// watchEvents consumes the blockchain events without blocking the block writes
+func (i *Indexer) watchEvents() {
+	defer i.wg.Done()
+
+	for {
+		ev := i.subscription.GetEvent()
+		if ev == nil {
+			return
+		}
+
+		if ev.Type == blockchain.EventReorg {
+			i.markReorg(ev.OldChain)
+		}
+
+		i.notify()
+	}
+}
+
+func (i *Indexer) run() {
+	defer i.wg.Done()
+
+	for {
+		select {
+		case <-i.updateCh:
+			if err := i.handleReorg(); err != nil {
+				i.logger.Error("failed to roll back the index", "err", err)
+			}
+
+			if err := i.Sync(); err != nil {
+				i.logger.Error("failed to index the section", "err", err)
+			}
+		case <-i.closeCh:
+			return
+		}
+	}
+}
+
+func (i *Indexer) notify() {
+	select {
+	case i.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+func (i *Indexer) markReorg(oldChain []*types.Header) {
+	i.reorgLock.Lock()
+	defer i.reorgLock.Unlock()
+
+	for _, header := range oldChain {
+		// the genesis is never removed, so zero means there was no reorg
+		if i.reorgFrom == 0 || header.Number < i.reorgFrom {
+			i.reorgFrom = header.Number
+		}
+	}
+}
+
+// handleReorg drops the sections containing the blocks removed by the last reorgs
+func (i *Indexer) handleReorg() error {
+	i.reorgLock.Lock()
+	reorgFrom := i.reorgFrom
+	i.reorgFrom = 0
+	i.reorgLock.Unlock()
+
+	if reorgFrom == 0 {
+		return nil
+	}
+
+	return i.rollback(reorgFrom / SectionSize)
+}
+
+// rollback drops the indexed sections starting from the given one
+func (i *Indexer) rollback(section uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if section >= i.sections.Load() {
+		return nil
+	}
+
+	batch := storage.NewBatchWriter(i.db)
+	batch.PutBloomBitsSections(section)
+
+	if err := batch.WriteBatch(); err != nil {
+		return err
+	}
+
+	i.logger.Info("index rolled back", "sections", section)
+	i.sections.Store(section)
+
+	return nil
+}
+
+// Truncate drops the indexed sections containing the blocks above the head,
+// it's used when the chain is rewound while the indexer isn't running
+func (i *Indexer) Truncate(head uint64) error {
+	return i.rollback((head + 1) / SectionSize)
+}
+
+// Rebuild drops the whole index and builds it again from the stored receipts
+func (i *Indexer) Rebuild() error {
+	if err := i.rollback(0); err != nil {
+		return err
+	}
+
+	return i.Sync()
+}
+
+// Sync indexes all the sections which are completed and confirmed by the chain head
+func (i *Indexer) Sync() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	head, ok := i.db.ReadHeadNumber()
+	if !ok {
+		return nil
+	}
+
+	for {
+		section := i.sections.Load()
+
+		// the section is indexed once its last block is confirmed
+		if (section+1)*SectionSize-1+confirmations > head {
+			return nil
+		}
+
+		select {
+		case <-i.closeCh:
+			return nil
+		default:
+		}
+
+		if err := i.indexSection(section); err != nil {
+			return fmt.Errorf("section %d: %w", section, err)
+		}
+	}
+}
+
+func (i *Indexer) indexSection(section uint64) error {
+	gen := NewGenerator()
+
+	for n := section * SectionSize; n < (section+1)*SectionSize; n++ {
+		bloom, err := i.blockBloom(n)
+		if err != nil {
+			return err
+		}
+
+		if err := gen.AddBloom(bloom); err != nil {
+			return err
+		}
+	}
+
+	batch := storage.NewBatchWriter(i.db)
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			return err
+		}
+
+		batch.PutBloomBits(bit, section, encodeBits(bits))
+	}
+
+	batch.PutBloomBitsSections(section + 1)
+
+	if err := batch.WriteBatch(); err != nil {
+		return err
+	}
+
+	i.sections.Store(section + 1)
+	i.logger.Debug("section indexed", "section", section)
+
+	return nil
+}
+
+// blockBloom returns the bloom of the block logs, it's built from the receipts
+// as the headers of all the consensus engines don't contain the logs bloom
+func (i *Indexer) blockBloom(number uint64) (types.Bloom, error) {
+	hash, ok := i.db.ReadCanonicalHash(number)
+	if !ok {
+		return types.Bloom{}, fmt.Errorf("canonical hash of the block %d not found", number)
+	}
+
+	receipts, err := i.db.ReadReceipts(hash)
+	if err != nil {
+		// the receipts are not stored for the blocks without transactions
+		if errors.Is(err, storage.ErrNotFound) {
+			return types.Bloom{}, nil
+		}
+
+		return types.Bloom{}, err
+	}
+
+	return types.CreateBloom(receipts), nil
+}
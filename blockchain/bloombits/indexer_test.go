@@ -0,0 +1,214 @@
+package bloombits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1  = types.StringToAddress("1")
+	addr2  = types.StringToAddress("2")
+	topic1 = types.StringToHash("1")
+	topic2 = types.StringToHash("2")
+)
+
+// newTestChain writes the canonical chain up to the head with the logs of the given blocks
+func newTestChain(t *testing.T, head uint64, logs map[uint64][]*types.Log) storage.Storage {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	batch := storage.NewBatchWriter(db)
+
+	for n := uint64(0); n <= head; n++ {
+		hash := types.BytesToHash(common.EncodeUint64ToBytes(n))
+
+		batch.PutCanonicalHash(n, hash)
+
+		if blockLogs, ok := logs[n]; ok {
+			batch.PutReceipts(hash, []*types.Receipt{{Logs: blockLogs}})
+		}
+	}
+
+	batch.PutHeadNumber(head)
+	require.NoError(t, batch.WriteBatch())
+
+	return db
+}
+
+func TestIndexer_Match(t *testing.T) {
+	t.Parallel()
+
+	db := newTestChain(t, 2*SectionSize-1+confirmations, map[uint64][]*types.Log{
+		5:                {{Address: addr1, Topics: []types.Hash{topic1}}},
+		SectionSize + 10: {{Address: addr1, Topics: []types.Hash{topic2}}},
+		SectionSize + 20: {{Address: addr2, Topics: []types.Hash{topic1}}},
+	})
+
+	indexer := NewIndexer(hclog.NewNullLogger(), db)
+	require.NoError(t, indexer.Sync())
+	require.Equal(t, uint64(2), indexer.Sections())
+
+	// the number of the sections is persisted
+	assert.Equal(t, uint64(2), NewIndexer(hclog.NewNullLogger(), db).Sections())
+
+	tests := []struct {
+		name       string
+		from       uint64
+		to         uint64
+		filters    [][][]byte
+		candidates []uint64
+		next       uint64
+	}{
+		{
+			name:       "address",
+			from:       0,
+			to:         3 * SectionSize,
+			filters:    [][][]byte{{addr1.Bytes()}},
+			candidates: []uint64{5, SectionSize + 10},
+			next:       2 * SectionSize,
+		},
+		{
+			name:       "address or address",
+			from:       0,
+			to:         100,
+			filters:    [][][]byte{{addr1.Bytes(), addr2.Bytes()}},
+			candidates: []uint64{5},
+			next:       101,
+		},
+		{
+			name:       "address and topic",
+			from:       0,
+			to:         2 * SectionSize,
+			filters:    [][][]byte{{addr1.Bytes()}, {topic1.Bytes()}},
+			candidates: []uint64{5},
+			next:       2 * SectionSize,
+		},
+		{
+			name:       "any address and topic",
+			from:       SectionSize,
+			to:         2 * SectionSize,
+			filters:    [][][]byte{{}, {topic1.Bytes()}},
+			candidates: []uint64{SectionSize + 20},
+			next:       2 * SectionSize,
+		},
+		{
+			name:       "range",
+			from:       6,
+			to:         SectionSize + 10,
+			filters:    [][][]byte{{addr1.Bytes()}},
+			candidates: []uint64{SectionSize + 10},
+			next:       SectionSize + 11,
+		},
+		{
+			name:       "no match",
+			from:       0,
+			to:         2 * SectionSize,
+			filters:    [][][]byte{{types.StringToAddress("3").Bytes()}},
+			candidates: []uint64{},
+			next:       2 * SectionSize,
+		},
+		{
+			name: "not indexed",
+			from: 2 * SectionSize,
+			to:   3 * SectionSize,
+			next: 2 * SectionSize,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			candidates, next, err := indexer.Match(test.from, test.to, test.filters)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.candidates, candidates)
+			assert.Equal(t, test.next, next)
+		})
+	}
+
+	// every block matches without the filters
+	candidates, _, err := indexer.Match(10, 19, nil)
+	require.NoError(t, err)
+	assert.Len(t, candidates, 10)
+}
+
+func TestIndexer_Sync_Confirmations(t *testing.T) {
+	t.Parallel()
+
+	// the second section is not confirmed yet
+	db := newTestChain(t, 2*SectionSize-2+confirmations, nil)
+
+	indexer := NewIndexer(hclog.NewNullLogger(), db)
+	require.NoError(t, indexer.Sync())
+	assert.Equal(t, uint64(1), indexer.Sections())
+}
+
+func TestIndexer_Reorg(t *testing.T) {
+	t.Parallel()
+
+	db := newTestChain(t, 2*SectionSize-1+confirmations, nil)
+
+	indexer := NewIndexer(hclog.NewNullLogger(), db)
+	require.NoError(t, indexer.Sync())
+	require.Equal(t, uint64(2), indexer.Sections())
+
+	// the reorg drops the sections containing the removed blocks
+	indexer.markReorg([]*types.Header{{Number: SectionSize + 1}, {Number: SectionSize + 5}})
+	require.NoError(t, indexer.handleReorg())
+	assert.Equal(t, uint64(1), indexer.Sections())
+
+	sections, ok := db.ReadBloomBitsSections()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), sections)
+
+	require.NoError(t, indexer.Rebuild())
+	assert.Equal(t, uint64(2), indexer.Sections())
+}
+
+func TestIndexer_StartClose(t *testing.T) {
+	t.Parallel()
+
+	db := newTestChain(t, SectionSize-1+confirmations, nil)
+	chain := &mockChain{subscription: blockchain.NewMockSubscription()}
+
+	indexer := NewIndexer(hclog.NewNullLogger(), db)
+	indexer.Start(chain)
+
+	require.Eventually(t, func() bool {
+		return indexer.Sections() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	indexer.Close()
+	assert.True(t, chain.unsubscribed)
+}
+
+type mockChain struct {
+	subscription *blockchain.MockSubscription
+	unsubscribed bool
+}
+
+func (m *mockChain) SubscribeEvents() blockchain.Subscription {
+	return m.subscription
+}
+
+func (m *mockChain) UnsubscribeEvents(blockchain.Subscription) {
+	m.unsubscribed = true
+
+	// the closed subscription returns no events
+	m.subscription.Push(nil)
+}
@@ -0,0 +1,121 @@
+package bloombits
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+// Match returns the numbers of the blocks in the range which may contain the logs matching
+// the filters, and the number of the first block in the range which is not covered by the index.
+// A block matches if it matches all the filters, and it matches a filter
+// if it contains any of the filter values, the empty filters match every block
+func (i *Indexer) Match(from, to uint64, filters [][][]byte) ([]uint64, uint64, error) {
+	indexed := i.Sections() * SectionSize
+	if from > to || from >= indexed {
+		return nil, from, nil
+	}
+
+	end := to
+	if end >= indexed {
+		end = indexed - 1
+	}
+
+	candidates := make([]uint64, 0)
+
+	for section := from / SectionSize; section <= end/SectionSize; section++ {
+		vector, err := i.matchSection(section, filters)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		first := common.Max(from, section*SectionSize)
+		last := common.Min(end, (section+1)*SectionSize-1)
+
+		for n := first; n <= last; n++ {
+			if idx := n - section*SectionSize; vector[idx/8]&(1<<(7-idx%8)) != 0 {
+				candidates = append(candidates, n)
+			}
+		}
+	}
+
+	return candidates, end + 1, nil
+}
+
+// matchSection returns the bit-vector of the section blocks which match the filters
+func (i *Indexer) matchSection(section uint64, filters [][][]byte) ([]byte, error) {
+	// the same bit is often needed by the several values
+	vectors := make(map[uint][]byte)
+
+	readBits := func(bit uint) ([]byte, error) {
+		if bits, ok := vectors[bit]; ok {
+			return bits, nil
+		}
+
+		data, ok := i.db.ReadBloomBits(bit, section)
+		if !ok {
+			return nil, fmt.Errorf("bit %d of the section %d not found", bit, section)
+		}
+
+		bits, err := decodeBits(data)
+		if err != nil {
+			return nil, err
+		}
+
+		vectors[bit] = bits
+
+		return bits, nil
+	}
+
+	res := newFullVector()
+
+	for _, filter := range filters {
+		if len(filter) == 0 {
+			continue
+		}
+
+		filterRes := make([]byte, sectionBytes)
+
+		for _, value := range filter {
+			valueRes := newFullVector()
+
+			for _, bit := range BloomIndexes(value) {
+				bits, err := readBits(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				andBits(valueRes, bits)
+			}
+
+			orBits(filterRes, valueRes)
+		}
+
+		andBits(res, filterRes)
+	}
+
+	return res, nil
+}
+
+// newFullVector returns the bit-vector matching every block of a section
+func newFullVector() []byte {
+	vector := make([]byte, sectionBytes)
+
+	for idx := range vector {
+		vector[idx] = 0xff
+	}
+
+	return vector
+}
+
+func andBits(dst, src []byte) {
+	for idx := range dst {
+		dst[idx] &= src[idx]
+	}
+}
+
+func orBits(dst, src []byte) {
+	for idx := range dst {
+		dst[idx] |= src[idx]
+	}
+}
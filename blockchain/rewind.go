@@ -0,0 +1,114 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// RewindStorage moves the head of the chain in the storage back to the given canonical header.
+// The canonical hashes, the transaction lookups and the address index of the blocks above it are removed,
+// while their headers, bodies and receipts are kept, so the blocks can be imported again.
+// It returns the headers of the removed blocks, starting from the old head
+func RewindStorage(db storage.Storage, header *types.Header) ([]*types.Header, error) {
+	headNumber, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, fmt.Errorf("failed to read the head number")
+	}
+
+	if header.Number > headNumber {
+		return nil, fmt.Errorf("block %d is above the head %d", header.Number, headNumber)
+	}
+
+	batchWriter := storage.NewBatchWriter(db)
+	removed := make([]*types.Header, 0, headNumber-header.Number)
+
+	for n := headNumber; n > header.Number; n-- {
+		hash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			// the head is written after the canonical hashes, so there's nothing to remove
+			continue
+		}
+
+		removedHeader, err := db.ReadHeader(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the header %d: %w", n, err)
+		}
+
+		// the blocks without transactions may have no body
+		if body, err := db.ReadBody(hash); err == nil {
+			for _, txn := range body.Transactions {
+				if blockHash, ok := db.ReadTxLookup(txn.Hash); ok && blockHash == hash {
+					batchWriter.DeleteTxLookup(txn.Hash)
+				}
+			}
+		}
+
+		batchWriter.DeleteCanonicalHash(n)
+
+		removed = append(removed, removedHeader)
+	}
+
+	hashes := make([]types.Hash, 0, len(removed))
+	for _, removedHeader := range removed {
+		hashes = append(hashes, removedHeader.Hash)
+	}
+
+	if err := newAddressIndexBatch(db, batchWriter).truncate(hashes, header.Number); err != nil {
+		return nil, fmt.Errorf("failed to truncate the address index: %w", err)
+	}
+
+	batchWriter.PutHeadHash(header.Hash)
+	batchWriter.PutHeadNumber(header.Number)
+
+	if err := batchWriter.WriteBatch(); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// SetHead rewinds the chain to the canonical block with the given number, the removed
+// blocks are announced as the old chain of a reorg event. It returns the new head
+func (b *Blockchain) SetHead(number uint64) (*types.Header, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	header, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	td, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return nil, fmt.Errorf("failed to read the total difficulty of the block %d", number)
+	}
+
+	removed, err := RewindStorage(b.db, header)
+	if err != nil {
+		return nil, err
+	}
+
+	b.setCurrentHeader(header, td)
+
+	b.logger.Info("chain head rewound", "number", header.Number, "hash", header.Hash, "removed", len(removed))
+
+	if len(removed) == 0 {
+		return header, nil
+	}
+
+	evnt := &Event{}
+
+	for _, h := range removed {
+		evnt.AddOldHeader(h)
+	}
+
+	evnt.AddNewHeader(header)
+	evnt.Type = EventReorg
+	evnt.SetDifficulty(td)
+
+	b.dispatchEvent(evnt)
+
+	return header, nil
+}
@@ -73,6 +73,52 @@ func (b *BatchWriter) PutForks(forks []types.Hash) {
 	b.putRlp(FORK, EMPTY, &ff)
 }
 
+func (b *BatchWriter) PutBloomBits(bit uint, section uint64, bits []byte) {
+	b.batch.Put(bloomBitsKey(bit, section), bits)
+}
+
+func (b *BatchWriter) PutBloomBitsSections(n uint64) {
+	b.putWithPrefix(BLOOM_BITS, SECTIONS, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutBadBlocks(badBlocks []*BadBlock) {
+	bb := BadBlocks(badBlocks)
+
+	b.putRlp(BAD_BLOCKS, EMPTY, &bb)
+}
+
+func (b *BatchWriter) PutAddressIndexHead(n uint64) {
+	b.putWithPrefix(ADDRESS_TXS, NUMBER, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutAddressTxsCount(addr types.Address, n uint64) {
+	b.putWithPrefix(ADDRESS_TXS, addr.Bytes(), common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) PutAddressTx(addr types.Address, index uint64, addressTx *AddressTx) {
+	b.putRlp(ADDRESS_TXS, addressTxKey(addr, index), addressTx)
+}
+
+func (b *BatchWriter) PutTxAddresses(hash types.Hash, txAddresses TxAddresses) {
+	b.putRlp(TX_ADDRESSES, hash.Bytes(), &txAddresses)
+}
+
+func (b *BatchWriter) DeleteCanonicalHash(n uint64) {
+	b.deleteWithPrefix(CANONICAL, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) DeleteTxLookup(hash types.Hash) {
+	b.deleteWithPrefix(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteAddressTxsCount(addr types.Address) {
+	b.deleteWithPrefix(ADDRESS_TXS, addr.Bytes())
+}
+
+func (b *BatchWriter) DeleteAddressTx(addr types.Address, index uint64) {
+	b.deleteWithPrefix(ADDRESS_TXS, addressTxKey(addr, index))
+}
+
 func (b *BatchWriter) putRlp(p, k []byte, raw types.RLPMarshaler) {
 	var data []byte
 
@@ -91,6 +137,12 @@ func (b *BatchWriter) putWithPrefix(p, k, data []byte) {
 	b.batch.Put(fullKey, data)
 }
 
+func (b *BatchWriter) deleteWithPrefix(p, k []byte) {
+	fullKey := append(append(make([]byte, 0, len(p)+len(k)), p...), k...)
+
+	b.batch.Delete(fullKey)
+}
+
 func (b *BatchWriter) WriteBatch() error {
 	return b.batch.Write()
 }
@@ -2,6 +2,7 @@
 package storage
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 
@@ -39,13 +40,26 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// BLOOM_BITS is the prefix for the bloom-bits index
+	BLOOM_BITS = []byte("B")
+
+	// BAD_BLOCKS is the prefix for the blocks rejected by the verification
+	BAD_BLOCKS = []byte("x")
+
+	// ADDRESS_TXS is the prefix for the address index of the transactions
+	ADDRESS_TXS = []byte("a")
+
+	// TX_ADDRESSES is the prefix for the addresses touched by the transactions of the blocks
+	TX_ADDRESSES = []byte("t")
 )
 
 // Sub-prefixes
 var (
-	HASH   = []byte("hash")
-	NUMBER = []byte("number")
-	EMPTY  = []byte("empty")
+	HASH     = []byte("hash")
+	NUMBER   = []byte("number")
+	EMPTY    = []byte("empty")
+	SECTIONS = []byte("sections")
 )
 
 // KV is a key value storage interface.
@@ -191,6 +205,96 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// BLOOM BITS //
+
+// ReadBloomBits reads the bit-vector of the bloom bit in the given section
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	data, ok, err := s.db.Get(bloomBitsKey(bit, section))
+	if err != nil {
+		return nil, false
+	}
+
+	return data, ok
+}
+
+// ReadBloomBitsSections returns the number of the sections in the bloom-bits index
+func (s *KeyValueStorage) ReadBloomBitsSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, SECTIONS)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// BAD BLOCKS //
+
+// ReadBadBlocks reads the last blocks rejected by the verification
+func (s *KeyValueStorage) ReadBadBlocks() ([]*BadBlock, error) {
+	badBlocks := &BadBlocks{}
+	err := s.readRLP(BAD_BLOCKS, EMPTY, badBlocks)
+
+	return *badBlocks, err
+}
+
+// ADDRESS INDEX //
+
+// ReadAddressIndexHead returns the number of the last block in the address index
+func (s *KeyValueStorage) ReadAddressIndexHead() (uint64, bool) {
+	data, ok := s.get(ADDRESS_TXS, NUMBER)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// ReadAddressTxsCount returns the number of the transactions which touched the address
+func (s *KeyValueStorage) ReadAddressTxsCount(addr types.Address) (uint64, bool) {
+	data, ok := s.get(ADDRESS_TXS, addr.Bytes())
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// ReadAddressTx reads the transaction which touched the address at the given position,
+// the transactions of the address are ordered by the blocks and by the positions in the blocks
+func (s *KeyValueStorage) ReadAddressTx(addr types.Address, index uint64) (*AddressTx, error) {
+	addressTx := &AddressTx{}
+	err := s.readRLP(ADDRESS_TXS, addressTxKey(addr, index), addressTx)
+
+	return addressTx, err
+}
+
+// ReadTxAddresses reads the addresses touched by each transaction of the block
+func (s *KeyValueStorage) ReadTxAddresses(hash types.Hash) (TxAddresses, error) {
+	txAddresses := &TxAddresses{}
+	err := s.readRLP(TX_ADDRESSES, hash.Bytes(), txAddresses)
+
+	return *txAddresses, err
+}
+
+// addressTxKey returns the key of the transaction of the address without the prefix,
+// the position follows the address so the transactions of an address are stored next to each other
+func addressTxKey(addr types.Address, index uint64) []byte {
+	key := make([]byte, 0, types.AddressLength+8)
+	key = append(key, addr.Bytes()...)
+
+	return append(key, common.EncodeUint64ToBytes(index)...)
+}
+
+// bloomBitsKey returns the key of the bit-vector, the bit index
+// is followed by the section so the vectors of a bit are stored next to each other
+func bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 0, len(BLOOM_BITS)+2+8)
+	key = append(key, BLOOM_BITS...)
+	key = binary.BigEndian.AppendUint16(key, uint16(bit))
+
+	return append(key, common.EncodeUint64ToBytes(section)...)
+}
+
 var ErrNotFound = fmt.Errorf("not found")
 
 func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
@@ -26,6 +26,16 @@ type Storage interface {
 
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+	ReadBloomBitsSections() (uint64, bool)
+
+	ReadBadBlocks() ([]*BadBlock, error)
+
+	ReadAddressIndexHead() (uint64, bool)
+	ReadAddressTxsCount(addr types.Address) (uint64, bool)
+	ReadAddressTx(addr types.Address, index uint64) (*AddressTx, error)
+	ReadTxAddresses(hash types.Hash) (TxAddresses, error)
+
 	NewBatch() Batch
 
 	Close() error
@@ -49,6 +49,15 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testBloomBits", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
+	t.Run("testBadBlocks", func(t *testing.T) {
+		testBadBlocks(t, m)
+	})
+	t.Run("testAddressIndex", func(t *testing.T) {
+		testAddressIndex(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -394,6 +403,163 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomBitsSections()
+	require.False(t, ok)
+
+	batch := NewBatchWriter(s)
+
+	batch.PutBloomBits(1, 2, []byte{0x1, 0x2})
+	batch.PutBloomBits(2, 1, []byte{})
+	batch.PutBloomBitsSections(3)
+
+	require.NoError(t, batch.WriteBatch())
+
+	bits, ok := s.ReadBloomBits(1, 2)
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x1, 0x2}, bits)
+
+	bits, ok = s.ReadBloomBits(2, 1)
+	require.True(t, ok)
+	assert.Empty(t, bits)
+
+	_, ok = s.ReadBloomBits(2, 2)
+	assert.False(t, ok)
+
+	sections, ok := s.ReadBloomBitsSections()
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), sections)
+}
+
+func testBadBlocks(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, err := s.ReadBadBlocks()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	header := &types.Header{
+		Number:    5,
+		ExtraData: []byte{0x1},
+	}
+	header.ComputeHash()
+
+	tx := &types.Transaction{
+		Nonce: 1,
+		To:    &addr1,
+		Value: big.NewInt(10),
+		Input: []byte{0x2},
+		V:     big.NewInt(1),
+		R:     big.NewInt(1),
+		S:     big.NewInt(1),
+	}
+	tx.ComputeHash(header.Number)
+
+	badBlocks := []*BadBlock{
+		{
+			Block:  &types.Block{Header: header, Transactions: []*types.Transaction{tx}},
+			Reason: "invalid block state root",
+			Source: "peer",
+			Time:   100,
+		},
+		{
+			Block:  &types.Block{Header: header},
+			Reason: "invalid block gas used",
+			Time:   200,
+		},
+	}
+
+	batch := NewBatchWriter(s)
+	batch.PutBadBlocks(badBlocks)
+
+	require.NoError(t, batch.WriteBatch())
+
+	found, err := s.ReadBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	for i, badBlock := range found {
+		assert.Equal(t, header.Hash, badBlock.Block.Hash())
+		assert.Equal(t, badBlocks[i].Reason, badBlock.Reason)
+		assert.Equal(t, badBlocks[i].Source, badBlock.Source)
+		assert.Equal(t, badBlocks[i].Time, badBlock.Time)
+	}
+
+	require.Len(t, found[0].Block.Transactions, 1)
+	assert.Equal(t, tx.Hash, found[0].Block.Transactions[0].Hash)
+	assert.Empty(t, found[1].Block.Transactions)
+}
+
+func testAddressIndex(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadAddressIndexHead()
+	require.False(t, ok)
+
+	_, ok = s.ReadAddressTxsCount(addr1)
+	require.False(t, ok)
+
+	blockHash := types.StringToHash("1")
+	addressTxs := []*AddressTx{
+		{BlockNumber: 1, TxHash: types.StringToHash("2")},
+		{BlockNumber: 3, TxHash: types.StringToHash("3")},
+	}
+	txAddresses := TxAddresses{{addr1, addr2}, {}, {addr2}}
+
+	batch := NewBatchWriter(s)
+	batch.PutAddressIndexHead(3)
+	batch.PutAddressTxsCount(addr1, 2)
+	batch.PutAddressTx(addr1, 0, addressTxs[0])
+	batch.PutAddressTx(addr1, 1, addressTxs[1])
+	batch.PutTxAddresses(blockHash, txAddresses)
+
+	require.NoError(t, batch.WriteBatch())
+
+	head, ok := s.ReadAddressIndexHead()
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), head)
+
+	count, ok := s.ReadAddressTxsCount(addr1)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), count)
+
+	for i, addressTx := range addressTxs {
+		found, err := s.ReadAddressTx(addr1, uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, addressTx, found)
+	}
+
+	_, err := s.ReadAddressTx(addr2, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	found, err := s.ReadTxAddresses(blockHash)
+	require.NoError(t, err)
+	assert.Equal(t, txAddresses, found)
+
+	batch = NewBatchWriter(s)
+	batch.DeleteAddressTx(addr1, 0)
+	batch.DeleteAddressTx(addr1, 1)
+	batch.DeleteAddressTxsCount(addr1)
+
+	require.NoError(t, batch.WriteBatch())
+
+	_, ok = s.ReadAddressTxsCount(addr1)
+	require.False(t, ok)
+
+	_, err = s.ReadAddressTx(addr1, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -460,21 +626,35 @@ type readBodyDelegate func(types.Hash) (*types.Body, error)
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type readBloomBitsDelegate func(uint, uint64) ([]byte, bool)
+type readBloomBitsSectionsDelegate func() (uint64, bool)
+type readBadBlocksDelegate func() ([]*BadBlock, error)
+type readAddressIndexHeadDelegate func() (uint64, bool)
+type readAddressTxsCountDelegate func(types.Address) (uint64, bool)
+type readAddressTxDelegate func(types.Address, uint64) (*AddressTx, error)
+type readTxAddressesDelegate func(types.Hash) (TxAddresses, error)
 type closeDelegate func() error
 type newBatchDelegate func() Batch
 
 type MockStorage struct {
-	readCanonicalHashFn   readCanonicalHashDelegate
-	readHeadHashFn        readHeadHashDelegate
-	readHeadNumberFn      readHeadNumberDelegate
-	readForksFn           readForksDelegate
-	readTotalDifficultyFn readTotalDifficultyDelegate
-	readHeaderFn          readHeaderDelegate
-	readBodyFn            readBodyDelegate
-	readReceiptsFn        readReceiptsDelegate
-	readTxLookupFn        readTxLookupDelegate
-	closeFn               closeDelegate
-	newBatchFn            newBatchDelegate
+	readCanonicalHashFn     readCanonicalHashDelegate
+	readHeadHashFn          readHeadHashDelegate
+	readHeadNumberFn        readHeadNumberDelegate
+	readForksFn             readForksDelegate
+	readTotalDifficultyFn   readTotalDifficultyDelegate
+	readHeaderFn            readHeaderDelegate
+	readBodyFn              readBodyDelegate
+	readReceiptsFn          readReceiptsDelegate
+	readTxLookupFn          readTxLookupDelegate
+	readBloomBitsFn         readBloomBitsDelegate
+	readBloomBitsSectionsFn readBloomBitsSectionsDelegate
+	readBadBlocksFn         readBadBlocksDelegate
+	readAddressIndexHeadFn  readAddressIndexHeadDelegate
+	readAddressTxsCountFn   readAddressTxsCountDelegate
+	readAddressTxFn         readAddressTxDelegate
+	readTxAddressesFn       readTxAddressesDelegate
+	closeFn                 closeDelegate
+	newBatchFn              newBatchDelegate
 }
 
 func NewMockStorage() *MockStorage {
@@ -589,6 +769,90 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBitsSections() (uint64, bool) {
+	if m.readBloomBitsSectionsFn != nil {
+		return m.readBloomBitsSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomBitsSections(fn readBloomBitsSectionsDelegate) {
+	m.readBloomBitsSectionsFn = fn
+}
+
+func (m *MockStorage) ReadBadBlocks() ([]*BadBlock, error) {
+	if m.readBadBlocksFn != nil {
+		return m.readBadBlocksFn()
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadBadBlocks(fn readBadBlocksDelegate) {
+	m.readBadBlocksFn = fn
+}
+
+func (m *MockStorage) ReadAddressIndexHead() (uint64, bool) {
+	if m.readAddressIndexHeadFn != nil {
+		return m.readAddressIndexHeadFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadAddressIndexHead(fn readAddressIndexHeadDelegate) {
+	m.readAddressIndexHeadFn = fn
+}
+
+func (m *MockStorage) ReadAddressTxsCount(addr types.Address) (uint64, bool) {
+	if m.readAddressTxsCountFn != nil {
+		return m.readAddressTxsCountFn(addr)
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadAddressTxsCount(fn readAddressTxsCountDelegate) {
+	m.readAddressTxsCountFn = fn
+}
+
+func (m *MockStorage) ReadAddressTx(addr types.Address, index uint64) (*AddressTx, error) {
+	if m.readAddressTxFn != nil {
+		return m.readAddressTxFn(addr, index)
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadAddressTx(fn readAddressTxDelegate) {
+	m.readAddressTxFn = fn
+}
+
+func (m *MockStorage) ReadTxAddresses(hash types.Hash) (TxAddresses, error) {
+	if m.readTxAddressesFn != nil {
+		return m.readTxAddressesFn(hash)
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadTxAddresses(fn readTxAddressesDelegate) {
+	m.readTxAddressesFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
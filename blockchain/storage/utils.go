@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
 )
@@ -52,3 +54,207 @@ func (f *Forks) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// BadBlock is a block rejected by the verification, stored together
+// with the reason of the rejection and the source it was received from
+type BadBlock struct {
+	Block  *types.Block
+	Reason string
+	Source string
+	Time   uint64
+}
+
+type BadBlocks []*BadBlock
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (b *BadBlocks) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(b.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type
+func (b *BadBlocks) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	if len(*b) == 0 {
+		return ar.NewNullArray()
+	}
+
+	vr := ar.NewArray()
+
+	for _, badBlock := range *b {
+		vv := ar.NewArray()
+		vv.Set(ar.NewCopyBytes(badBlock.Block.MarshalRLP()))
+		vv.Set(ar.NewString(badBlock.Reason))
+		vv.Set(ar.NewString(badBlock.Source))
+		vv.Set(ar.NewUint(badBlock.Time))
+
+		vr.Set(vv)
+	}
+
+	return vr
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (b *BadBlocks) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(b.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (b *BadBlocks) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	badBlocks := make([]*BadBlock, len(elems))
+
+	for indx, elem := range elems {
+		fields, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != 4 {
+			return fmt.Errorf("incorrect number of elements to decode bad block, expected 4 but found %d", len(fields))
+		}
+
+		raw, err := fields[0].Bytes()
+		if err != nil {
+			return err
+		}
+
+		badBlock := &BadBlock{Block: &types.Block{}}
+		if err := badBlock.Block.UnmarshalRLP(raw); err != nil {
+			return err
+		}
+
+		if badBlock.Reason, err = fields[1].GetString(); err != nil {
+			return err
+		}
+
+		if badBlock.Source, err = fields[2].GetString(); err != nil {
+			return err
+		}
+
+		if badBlock.Time, err = fields[3].GetUint64(); err != nil {
+			return err
+		}
+
+		badBlocks[indx] = badBlock
+	}
+
+	*b = badBlocks
+
+	return nil
+}
+
+// AddressTx is a canonical transaction which touched an address, stored in the address index
+type AddressTx struct {
+	BlockNumber uint64
+	TxHash      types.Hash
+}
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (a *AddressTx) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(a.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type
+func (a *AddressTx) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+	vv.Set(ar.NewUint(a.BlockNumber))
+	vv.Set(ar.NewCopyBytes(a.TxHash.Bytes()))
+
+	return vv
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (a *AddressTx) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(a.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (a *AddressTx) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	fields, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(fields) != 2 {
+		return fmt.Errorf("incorrect number of elements to decode address tx, expected 2 but found %d", len(fields))
+	}
+
+	if a.BlockNumber, err = fields[0].GetUint64(); err != nil {
+		return err
+	}
+
+	return fields[1].GetHash(a.TxHash[:])
+}
+
+// TxAddresses holds the addresses touched by each transaction of a block,
+// so the transactions of the block can be removed from the address index
+type TxAddresses [][]types.Address
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (t *TxAddresses) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(t.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type
+func (t *TxAddresses) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	if len(*t) == 0 {
+		return ar.NewNullArray()
+	}
+
+	vr := ar.NewArray()
+
+	for _, addresses := range *t {
+		if len(addresses) == 0 {
+			vr.Set(ar.NewNullArray())
+
+			continue
+		}
+
+		vv := ar.NewArray()
+		for _, addr := range addresses {
+			vv.Set(ar.NewCopyBytes(addr.Bytes()))
+		}
+
+		vr.Set(vv)
+	}
+
+	return vr
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (t *TxAddresses) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(t.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (t *TxAddresses) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	txAddresses := make(TxAddresses, len(elems))
+
+	for indx, elem := range elems {
+		fields, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		addresses := make([]types.Address, len(fields))
+		for i, field := range fields {
+			if err := field.GetAddr(addresses[i][:]); err != nil {
+				return err
+			}
+		}
+
+		txAddresses[indx] = addresses
+	}
+
+	*t = txAddresses
+
+	return nil
+}
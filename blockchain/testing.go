@@ -122,7 +122,7 @@ func NewTestBlockchain(t *testing.T, headers []*types.Header) *Blockchain {
 
 		batchWriter.PutCanonicalHeader(headers[0], td)
 
-		if err := b.writeBatchAndUpdate(batchWriter, headers[0], td, true); err != nil {
+		if err := b.writeBatchAndUpdate(batchWriter, &types.Block{Header: headers[0]}, nil, td, true); err != nil {
 			t.Fatal(err)
 		}
 
@@ -290,9 +290,11 @@ func (m *MockVerifier) HookPreCommitState(fn preStateCommitDelegate) {
 // Executor delegators
 
 type processBlockDelegate func(types.Hash, *types.Block, types.Address) (*state.Transition, error)
+type beginTxnDelegate func(types.Hash, *types.Header, types.Address) (*state.Transition, error)
 
 type mockExecutor struct {
 	processBlockFn processBlockDelegate
+	beginTxnFn     beginTxnDelegate
 }
 
 func (m *mockExecutor) ProcessBlock(
@@ -311,6 +313,22 @@ func (m *mockExecutor) HookProcessBlock(fn processBlockDelegate) {
 	m.processBlockFn = fn
 }
 
+func (m *mockExecutor) BeginTxn(
+	parentRoot types.Hash,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*state.Transition, error) {
+	if m.beginTxnFn != nil {
+		return m.beginTxnFn(parentRoot, header, coinbaseReceiver)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutor) HookBeginTxn(fn beginTxnDelegate) {
+	m.beginTxnFn = fn
+}
+
 type mockSigner struct {
 	txFromByTxHash map[types.Hash]types.Address
 }
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/db/rebuildbloombits"
+	"github.com/0xPolygon/polygon-edge/command/db/rewind"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for maintaining the blockchain database of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db rebuild-bloombits
+		rebuildbloombits.GetCommand(),
+		// db rewind
+		rewind.GetCommand(),
+	)
+}
@@ -0,0 +1,61 @@
+package rebuildbloombits
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &rebuildParams{}
+)
+
+type rebuildParams struct {
+	dataDir string
+
+	sections uint64
+}
+
+func (p *rebuildParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *rebuildParams) rebuildIndex() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "db",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	indexer := bloombits.NewIndexer(logger, db)
+	if err := indexer.Rebuild(); err != nil {
+		return err
+	}
+
+	p.sections = indexer.Sections()
+
+	return nil
+}
+
+func (p *rebuildParams) getResult() command.CommandResult {
+	return &RebuildBloomBitsResult{
+		Sections: p.sections,
+		Blocks:   p.sections * bloombits.SectionSize,
+	}
+}
@@ -0,0 +1,43 @@
+package rebuildbloombits
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	rebuildCmd := &cobra.Command{
+		Use:   "rebuild-bloombits",
+		Short: "Drops the bloom-bits index of the logs and builds it again from the stored receipts",
+		Run:   runCommand,
+	}
+
+	setFlags(rebuildCmd)
+	helper.SetRequiredFlags(rebuildCmd, params.getRequiredFlags())
+
+	return rebuildCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rebuildIndex(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
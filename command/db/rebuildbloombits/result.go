@@ -0,0 +1,27 @@
+package rebuildbloombits
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RebuildBloomBitsResult struct {
+	Sections uint64 `json:"sections"`
+	Blocks   uint64 `json:"blocks"`
+}
+
+func (r *RebuildBloomBitsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REBUILD BLOOM-BITS]\n")
+	buffer.WriteString("Rebuilt the bloom-bits index successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Indexed sections|%d", r.Sections),
+		fmt.Sprintf("Indexed blocks|%d", r.Blocks),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,89 @@
+package rewind
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	toFlag      = "to"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+type rewindParams struct {
+	dataDir string
+	to      uint64
+
+	head    *types.Header
+	removed int
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+func (p *rewindParams) rewindChain() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "db",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	hash, ok := db.ReadCanonicalHash(p.to)
+	if !ok {
+		return fmt.Errorf("block %d not found", p.to)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("failed to read the header %d: %w", p.to, err)
+	}
+
+	removed, err := blockchain.RewindStorage(db, header)
+	if err != nil {
+		return err
+	}
+
+	if err := bloombits.NewIndexer(logger, db).Truncate(header.Number); err != nil {
+		return fmt.Errorf("failed to truncate the bloom-bits index: %w", err)
+	}
+
+	if err := polybft.RewindState(filepath.Join(p.dataDir, "consensus"), header, logger); err != nil {
+		return fmt.Errorf("failed to rewind the consensus state: %w", err)
+	}
+
+	p.head = header
+	p.removed = len(removed)
+
+	return nil
+}
+
+func (p *rewindParams) getResult() command.CommandResult {
+	return &RewindResult{
+		Number:  p.head.Number,
+		Hash:    p.head.Hash.String(),
+		Removed: p.removed,
+	}
+}
@@ -0,0 +1,29 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RewindResult struct {
+	Number  uint64 `json:"number"`
+	Hash    string `json:"hash"`
+	Removed int    `json:"removed"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REWIND]\n")
+	buffer.WriteString("Rewound the chain successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head number|%d", r.Number),
+		fmt.Sprintf("Head hash|%s", r.Hash),
+		fmt.Sprintf("Removed blocks|%d", r.Removed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -20,6 +20,7 @@ type Config struct {
 	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
 	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
 	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	JSONRPCIPCPath           string     `json:"jsonrpc_ipc_path" yaml:"jsonrpc_ipc_path"`
 	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
 	Network                  *Network   `json:"network" yaml:"network"`
 	ShouldSeal               bool       `json:"seal" yaml:"seal"`
@@ -39,7 +40,26 @@ type Config struct {
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
 
+	JSONRPCCheckpointFinality bool `json:"json_rpc_checkpoint_finality" yaml:"json_rpc_checkpoint_finality"`
+
+	JSONRPCHTTPAllow []string `json:"json_rpc_http_allow" yaml:"json_rpc_http_allow"`
+	JSONRPCHTTPDeny  []string `json:"json_rpc_http_deny" yaml:"json_rpc_http_deny"`
+	JSONRPCWSAllow   []string `json:"json_rpc_ws_allow" yaml:"json_rpc_ws_allow"`
+	JSONRPCWSDeny    []string `json:"json_rpc_ws_deny" yaml:"json_rpc_ws_deny"`
+
+	JSONRPCAuthKeysFile string `json:"json_rpc_auth_keys_file" yaml:"json_rpc_auth_keys_file"`
+
+	JSONRPCRateLimit *JSONRPCRateLimit `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+
+	JSONRPCSlowRequestThreshold time.Duration `json:"json_rpc_slow_request_threshold" yaml:"json_rpc_slow_request_threshold"`
+	JSONRPCResponseCacheSize    uint64        `json:"json_rpc_response_cache_size" yaml:"json_rpc_response_cache_size"`
+
+	JSONRPCAdmin    bool   `json:"json_rpc_admin" yaml:"json_rpc_admin"`
+	JSONRPCKeystore string `json:"json_rpc_keystore" yaml:"json_rpc_keystore"`
+
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
+
+	AddressIndex bool `json:"address_index" yaml:"address_index"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -65,6 +85,15 @@ type TxPool struct {
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
 }
 
+// JSONRPCRateLimit defines the per-IP rate limit of the JSON-RPC requests,
+// the method costs are set only in the config file
+type JSONRPCRateLimit struct {
+	RequestsPerSecond uint64            `json:"requests_per_second" yaml:"requests_per_second"`
+	Burst             uint64            `json:"burst" yaml:"burst"`
+	TrustedProxies    []string          `json:"trusted_proxies" yaml:"trusted_proxies"`
+	MethodCosts       map[string]uint64 `json:"method_costs" yaml:"method_costs"`
+}
+
 // Headers defines the HTTP response headers required to enable CORS.
 type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
@@ -137,6 +166,7 @@ func DefaultConfig() *Config {
 		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
 		WebSocketReadLimit:       DefaultWebSocketReadLimit,
 		MetricsInterval:          DefaultMetricsInterval,
+		JSONRPCRateLimit:         &JSONRPCRateLimit{},
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -44,7 +45,23 @@ const (
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
 
+	jsonRPCCheckpointFinalityFlag = "json-rpc-checkpoint-finality"
+	jsonRPCIPCPathFlag            = "json-rpc-ipc-path"
+	jsonRPCHTTPAllowFlag          = "json-rpc-http-allow"
+	jsonRPCHTTPDenyFlag           = "json-rpc-http-deny"
+	jsonRPCWSAllowFlag            = "json-rpc-ws-allow"
+	jsonRPCWSDenyFlag             = "json-rpc-ws-deny"
+	jsonRPCAuthKeysFileFlag       = "json-rpc-auth-keys-file"
+	jsonRPCIPRateLimitFlag        = "json-rpc-ip-rate-limit"
+	jsonRPCIPRateBurstFlag        = "json-rpc-ip-rate-burst"
+	jsonRPCTrustedProxiesFlag     = "json-rpc-trusted-proxies"
+	jsonRPCSlowRequestFlag        = "json-rpc-slow-request-threshold"
+	jsonRPCResponseCacheSizeFlag  = "json-rpc-response-cache-size"
+	jsonRPCAdminFlag              = "json-rpc-admin"
+	jsonRPCKeystoreFlag           = "json-rpc-keystore"
+
 	metricsIntervalFlag = "metrics-interval"
+	addressIndexFlag    = "address-index"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -60,9 +77,10 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &config.Config{
-			Telemetry: &config.Telemetry{},
-			Network:   &config.Network{},
-			TxPool:    &config.TxPool{},
+			Telemetry:        &config.Telemetry{},
+			Network:          &config.Network{},
+			TxPool:           &config.TxPool{},
+			JSONRPCRateLimit: &config.JSONRPCRateLimit{},
 		},
 	}
 )
@@ -154,11 +172,32 @@ func (p *serverParams) generateConfig() *server.Config {
 		Chain: p.genesisConfig,
 		JSONRPC: &server.JSONRPC{
 			JSONRPCAddr:              p.jsonRPCAddress,
+			IPCPath:                  p.rawConfig.JSONRPCIPCPath,
 			AccessControlAllowOrigin: p.rawConfig.CorsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			CheckpointFinality:       p.rawConfig.JSONRPCCheckpointFinality,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			HTTPPolicy: &jsonrpc.MethodPolicy{
+				Allow: p.rawConfig.JSONRPCHTTPAllow,
+				Deny:  p.rawConfig.JSONRPCHTTPDeny,
+			},
+			WSPolicy: &jsonrpc.MethodPolicy{
+				Allow: p.rawConfig.JSONRPCWSAllow,
+				Deny:  p.rawConfig.JSONRPCWSDeny,
+			},
+			AuthKeysFile: p.rawConfig.JSONRPCAuthKeysFile,
+			IPRateLimit: &jsonrpc.IPRateLimit{
+				RequestsPerSecond: p.rawConfig.JSONRPCRateLimit.RequestsPerSecond,
+				Burst:             p.rawConfig.JSONRPCRateLimit.Burst,
+				TrustedProxies:    p.rawConfig.JSONRPCRateLimit.TrustedProxies,
+				MethodCosts:       p.rawConfig.JSONRPCRateLimit.MethodCosts,
+			},
+			SlowRequestThreshold: p.rawConfig.JSONRPCSlowRequestThreshold,
+			ResponseCacheSize:    p.rawConfig.JSONRPCResponseCacheSize,
+			EnableAdmin:          p.rawConfig.JSONRPCAdmin,
+			KeystoreDir:          p.rawConfig.JSONRPCKeystore,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -190,5 +229,6 @@ func (p *serverParams) generateConfig() *server.Config {
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
 		MetricsInterval:       p.rawConfig.MetricsInterval,
+		AddressIndex:          p.rawConfig.AddressIndex,
 	}
 }
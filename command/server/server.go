@@ -207,6 +207,115 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCCheckpointFinality,
+		jsonRPCCheckpointFinalityFlag,
+		defaultConfig.JSONRPCCheckpointFinality,
+		"resolve the \"finalized\" block tag to the last block checkpointed on the rootchain "+
+			"instead of the head of the chain (PolyBFT only)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"the path of the unix domain socket (or the named pipe on windows) serving the JSON-RPC "+
+			"with the subscriptions, the IPC server is disabled if it's not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCHTTPAllow,
+		jsonRPCHTTPAllowFlag,
+		defaultConfig.JSONRPCHTTPAllow,
+		"the only json-rpc namespaces (e.g. eth) and methods (e.g. txpool_status) served over HTTP, "+
+			"all of them are served if it's not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCHTTPDeny,
+		jsonRPCHTTPDenyFlag,
+		defaultConfig.JSONRPCHTTPDeny,
+		"the json-rpc namespaces (e.g. debug) and methods (e.g. txpool_content) never served over HTTP",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCWSAllow,
+		jsonRPCWSAllowFlag,
+		defaultConfig.JSONRPCWSAllow,
+		"the only json-rpc namespaces (e.g. eth) and methods (e.g. txpool_status) served over WebSocket, "+
+			"all of them are served if it's not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCWSDeny,
+		jsonRPCWSDenyFlag,
+		defaultConfig.JSONRPCWSDeny,
+		"the json-rpc namespaces (e.g. debug) and methods (e.g. txpool_content) never served over WebSocket",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAuthKeysFile,
+		jsonRPCAuthKeysFileFlag,
+		defaultConfig.JSONRPCAuthKeysFile,
+		"the JSON file of the API keys and their quotas, the HTTP and WebSocket json-rpc requests "+
+			"have to be authenticated by one of the keys if it's set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.RequestsPerSecond,
+		jsonRPCIPRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit.RequestsPerSecond,
+		"the cost of the json-rpc requests served to each client IP per second "+
+			"(the cost of a request is one unless it's set in the config file), the limit is disabled if it's zero",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.Burst,
+		jsonRPCIPRateBurstFlag,
+		defaultConfig.JSONRPCRateLimit.Burst,
+		"the maximum cost of the json-rpc requests a client IP can spend at once, "+
+			"it's the per-second limit if it's zero",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCRateLimit.TrustedProxies,
+		jsonRPCTrustedProxiesFlag,
+		defaultConfig.JSONRPCRateLimit.TrustedProxies,
+		"the IPs and CIDRs of the proxies whose X-Forwarded-For header is used to identify the client IP",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.JSONRPCSlowRequestThreshold,
+		jsonRPCSlowRequestFlag,
+		defaultConfig.JSONRPCSlowRequestThreshold,
+		"the duration of the json-rpc requests which are logged together with their method and params, "+
+			"the logging is disabled if it's zero",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCResponseCacheSize,
+		jsonRPCResponseCacheSizeFlag,
+		defaultConfig.JSONRPCResponseCacheSize,
+		"the maximum size in bytes of the cached json-rpc results of the queries of the historical blocks, "+
+			"the cache is disabled if it's zero",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCAdmin,
+		jsonRPCAdminFlag,
+		defaultConfig.JSONRPCAdmin,
+		"serve the admin json-rpc namespace managing the peers, it's served on IPC, "+
+			"and also on HTTP and WS if the json-rpc address is a loopback address",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCKeystore,
+		jsonRPCKeystoreFlag,
+		defaultConfig.JSONRPCKeystore,
+		"the directory of the encrypted key files of the local accounts signing the json-rpc transactions, "+
+			"enables the personal namespace which is served as the admin one",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -249,6 +358,14 @@ func setFlags(cmd *cobra.Command) {
 		"the interval (in seconds) at which special metrics are generated. a value of zero means the metrics are disabled",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AddressIndex,
+		addressIndexFlag,
+		defaultConfig.AddressIndex,
+		"index the transactions by the addresses they touched, including the internal calls, "+
+			"so they can be searched by the ots_searchTransactions methods. The existing blocks are indexed in the background",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -51,6 +51,13 @@ type Consensus interface {
 	Close() error
 }
 
+// Rewinder is implemented by the consensus mechanisms which persist their own data
+// along the blocks, so that the data follows the chain when its head is moved back
+type Rewinder interface {
+	// Rewind rolls the consensus data back to the header, which is the new head of the chain
+	Rewind(header *types.Header) error
+}
+
 // Config is the configuration for the consensus
 type Config struct {
 	// Logger to be used by the consensus
@@ -98,4 +105,7 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetCurrentCheckpointBlock returns the last block checkpointed on the rootchain
+	GetCurrentCheckpointBlock() (uint64, error)
 }
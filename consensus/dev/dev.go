@@ -201,7 +201,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		Receipts: transition.Receipts(),
 	})
 
-	if _, err := d.blockchain.VerifyFinalizedBlock(block); err != nil {
+	if _, err := d.blockchain.VerifyFinalizedBlock(block, devConsensus); err != nil {
 		return err
 	}
 
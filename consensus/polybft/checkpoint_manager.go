@@ -2,6 +2,7 @@ package polybft
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -25,6 +26,8 @@ var (
 	currentCheckpointBlockNumMethod = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
 	// frequency at which checkpoints are sent to the rootchain (in blocks count)
 	defaultCheckpointsOffset = uint64(900)
+
+	errCheckpointsDisabled = errors.New("checkpoints are not submitted when the bridge is disabled")
 )
 
 type CheckpointManager interface {
@@ -32,6 +35,7 @@ type CheckpointManager interface {
 	PostBlock(req *PostBlockRequest) error
 	BuildEventRoot(epoch uint64) (types.Hash, error)
 	GenerateExitProof(exitID uint64) (types.Proof, error)
+	GetCurrentCheckpointBlock() (uint64, error)
 }
 
 var _ CheckpointManager = (*dummyCheckpointManager)(nil)
@@ -45,6 +49,9 @@ func (d *dummyCheckpointManager) BuildEventRoot(epoch uint64) (types.Hash, error
 func (d *dummyCheckpointManager) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyCheckpointManager) GetCurrentCheckpointBlock() (uint64, error) {
+	return 0, errCheckpointsDisabled
+}
 
 // EventSubscriber implementation
 func (d *dummyCheckpointManager) GetLogFilters() map[types.Address][]types.Hash {
@@ -300,6 +307,11 @@ func (c *checkpointManager) PostBlock(req *PostBlockRequest) error {
 	return nil
 }
 
+// GetCurrentCheckpointBlock returns the last block checkpointed on the rootchain
+func (c *checkpointManager) GetCurrentCheckpointBlock() (uint64, error) {
+	return getCurrentCheckpointBlock(c.rootChainRelayer, c.checkpointManagerAddr)
+}
+
 // BuildEventRoot returns an exit event root hash for exit tree of given epoch
 func (c *checkpointManager) BuildEventRoot(epoch uint64) (types.Hash, error) {
 	exitEvents, err := c.state.CheckpointStore.getExitEventsByEpoch(epoch)
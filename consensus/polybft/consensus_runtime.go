@@ -536,6 +536,63 @@ func (c *consensusRuntime) FSM() error {
 	return nil
 }
 
+// rewind rolls the consensus data and the runtime back to the header, which is the new head of the chain.
+// The proposer snapshot and the full validator set are calculated again and the epoch is restarted
+func (c *consensusRuntime) rewind(header *types.Header) error {
+	epochNumber, err := getHeaderEpoch(header)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.state.rewind(header.Number, epochNumber); err != nil {
+		return err
+	}
+
+	dbTx, err := c.state.beginDBTransaction(true)
+	if err != nil {
+		return fmt.Errorf("could not begin dbTx to rewind consensus runtime: %w", err)
+	}
+
+	defer dbTx.Rollback() //nolint:errcheck
+
+	proposerCalculator, err := NewProposerCalculator(c.config, c.proposerCalculator.logger, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to rewind proposer calculator: %w", err)
+	}
+
+	if stakeManager, ok := c.stakeManager.(*stakeManager); ok {
+		if err := stakeManager.init(c.config.blockchain, dbTx); err != nil {
+			return fmt.Errorf("failed to rewind stake manager: %w", err)
+		}
+	}
+
+	// the epoch is restarted even if its number is the same
+	lastEpoch := c.epoch
+	c.epoch = nil
+
+	epoch, err := c.restartEpoch(header, dbTx)
+	if err != nil {
+		c.epoch = lastEpoch
+
+		return fmt.Errorf("failed to restart epoch: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		c.epoch = lastEpoch
+
+		return fmt.Errorf("could not commit db tx to rewind consensus runtime: %w", err)
+	}
+
+	c.epoch = epoch
+	c.lastBuiltBlock = header
+	c.proposerCalculator = proposerCalculator
+
+	return nil
+}
+
 // restartEpoch resets the previously run epoch and moves to the next one
 // returns *epochMetadata different from nil if the lastEpoch is not the current one and everything was successful
 func (c *consensusRuntime) restartEpoch(header *types.Header, dbTx *bolt.Tx) (*epochMetadata, error) {
@@ -715,6 +772,12 @@ func (c *consensusRuntime) GenerateExitProof(exitID uint64) (types.Proof, error)
 	return c.checkpointManager.GenerateExitProof(exitID)
 }
 
+// GetCurrentCheckpointBlock returns the last block checkpointed on the rootchain
+// and is a bridge endpoint store function
+func (c *consensusRuntime) GetCurrentCheckpointBlock() (uint64, error) {
+	return c.checkpointManager.GetCurrentCheckpointBlock()
+}
+
 // GetStateSyncProof returns the proof for the state sync
 func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
@@ -686,6 +686,13 @@ func (p *Polybft) Close() error {
 	return nil
 }
 
+// Rewind rolls the consensus data back to the header, which is the new head of the chain
+func (p *Polybft) Rewind(header *types.Header) error {
+	p.validatorsCache.rewind(header.Number)
+
+	return p.runtime.rewind(header)
+}
+
 // GetSyncProgression retrieves the current sync progression, if any
 func (p *Polybft) GetSyncProgression() *progress.Progression {
 	return p.syncer.GetSyncProgression()
@@ -1,9 +1,13 @@
 package polybft
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	bolt "go.etcd.io/bbolt"
 )
@@ -159,6 +163,81 @@ func (s *State) getLastProcessedEventsBlock(dbTx *bolt.Tx) (uint64, error) {
 	return lastProcessed, err
 }
 
+// RewindState rolls the consensus data stored in the consensus directory of a stopped node
+// back to the header, which is the new head of the chain. Nothing is done if the directory
+// has no polybft state
+func RewindState(consensusDir string, header *types.Header, logger hclog.Logger) error {
+	path := filepath.Join(consensusDir, "polybft", stateFileName)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	epoch, err := getHeaderEpoch(header)
+	if err != nil {
+		return err
+	}
+
+	s, err := newState(path, logger, make(chan struct{}))
+	if err != nil {
+		return err
+	}
+
+	defer s.db.Close()
+
+	return s.rewind(header.Number, epoch)
+}
+
+// getHeaderEpoch returns the number of the epoch of the block from its checkpoint data
+func getHeaderEpoch(header *types.Header) (uint64, error) {
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode extra from the block#%d: %w", header.Number, err)
+	}
+
+	if extra.Checkpoint == nil {
+		return 0, nil
+	}
+
+	return extra.Checkpoint.EpochNumber, nil
+}
+
+// rewind removes the consensus data of the blocks above the given block of the given epoch,
+// so that the data is built again once the blocks are imported
+func (s *State) rewind(block, epoch uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.EpochStore.rewind(tx, block, epoch); err != nil {
+			return fmt.Errorf("failed to rewind epochs: %w", err)
+		}
+
+		if err := s.ProposerSnapshotStore.rewind(tx, block); err != nil {
+			return fmt.Errorf("failed to rewind proposer snapshot: %w", err)
+		}
+
+		if err := s.StateSyncStore.rewind(tx, block); err != nil {
+			return fmt.Errorf("failed to rewind state syncs: %w", err)
+		}
+
+		if err := s.StakeStore.rewind(tx, block); err != nil {
+			return fmt.Errorf("failed to rewind full validator set: %w", err)
+		}
+
+		lastProcessedBlock, err := s.getLastProcessedEventsBlock(tx)
+		if err != nil {
+			return err
+		}
+
+		if lastProcessedBlock <= block {
+			return nil
+		}
+
+		return s.insertLastProcessedEventsBlock(block, tx)
+	})
+}
+
 // beginDBTransaction creates and begins a transaction on BoltDB
 // Note that transaction needs to be manually rollback or committed
 func (s *State) beginDBTransaction(isWriteTx bool) (*bolt.Tx, error) {
@@ -263,6 +263,51 @@ func (s *EpochStore) removeAllValidatorSnapshots() error {
 	})
 }
 
+// rewind removes the validator snapshots computed at the blocks above the given one
+// and the buckets of the epochs which come after the epoch of the block
+func (s *EpochStore) rewind(tx *bolt.Tx, block, epoch uint64) error {
+	var removedKeys [][]byte
+
+	snapshotsBucket := tx.Bucket(validatorSnapshotsBucket)
+	if err := snapshotsBucket.ForEach(func(k, v []byte) error {
+		var snapshot *validatorSnapshot
+		if err := json.Unmarshal(v, &snapshot); err != nil {
+			return err
+		}
+
+		// the keys are copied, as they are not valid once the bucket is modified
+		if snapshot.EpochEndingBlock > block {
+			removedKeys = append(removedKeys, append([]byte(nil), k...))
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range removedKeys {
+		if err := snapshotsBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	removedKeys = nil
+
+	// the epochs are stored by their numbers, so the removed ones are at the end
+	cursor := tx.Bucket(epochsBucket).Cursor()
+	for k, _ := cursor.Seek(common.EncodeUint64ToBytes(epoch + 1)); k != nil; k, _ = cursor.Next() {
+		removedKeys = append(removedKeys, append([]byte(nil), k...))
+	}
+
+	for _, k := range removedKeys {
+		if err := tx.Bucket(epochsBucket).DeleteBucket(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // epochsDBStats returns stats of epochs bucket in db
 func (s *EpochStore) epochsDBStats() (*bolt.BucketStats, error) {
 	return bucketStats(epochsBucket, s.db)
@@ -61,6 +61,21 @@ func (s *ProposerSnapshotStore) getProposerSnapshot(dbTx *bolt.Tx) (*ProposerSna
 	return snapshot, err
 }
 
+// rewind removes the proposer snapshot if it's calculated for a block after the one following the given block,
+// the snapshot is then calculated again from the genesis
+func (s *ProposerSnapshotStore) rewind(tx *bolt.Tx, block uint64) error {
+	snapshot, err := s.getProposerSnapshot(tx)
+	if err != nil {
+		return err
+	}
+
+	if snapshot == nil || snapshot.Height <= block+1 {
+		return nil
+	}
+
+	return tx.Bucket(proposerSnapshotBucket).Delete(proposerSnapshotKey)
+}
+
 // writeProposerSnapshot writes proposer snapshot
 func (s *ProposerSnapshotStore) writeProposerSnapshot(snapshot *ProposerSnapshot, dbTx *bolt.Tx) error {
 	insertFn := func(tx *bolt.Tx) error {
@@ -51,6 +51,25 @@ func (s *StakeStore) insertFullValidatorSet(fullValidatorSet validatorSetState,
 	return insertFn(dbTx)
 }
 
+// rewind removes the full validator set if it's updated by a block above the given one,
+// the set is then built again from the genesis validators and the transfer events of the blocks
+func (s *StakeStore) rewind(tx *bolt.Tx, block uint64) error {
+	fullValidatorSet, err := s.getFullValidatorSet(tx)
+	if err != nil {
+		if errors.Is(err, errNoFullValidatorSet) {
+			return nil
+		}
+
+		return err
+	}
+
+	if fullValidatorSet.BlockNumber <= block {
+		return nil
+	}
+
+	return tx.Bucket(validatorSetBucket).Delete(fullValidatorSetKey)
+}
+
 // getFullValidatorSet returns full validator set from its bucket if exists
 // If the passed tx is already open (not nil), it will use it to get full validator set
 // If the passed tx is not open (it is nil), it will open a new transaction on db and get full validator set
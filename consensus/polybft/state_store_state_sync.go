@@ -414,6 +414,35 @@ func (s *StateSyncStore) updateStateSyncRelayerEvents(
 	return updateFn(dbTx)
 }
 
+// rewind removes the state sync relayer events of the blocks above the given block
+func (s *StateSyncStore) rewind(tx *bolt.Tx, block uint64) error {
+	var removedKeys [][]byte
+
+	relayerEventsBucket := tx.Bucket(stateSyncRelayerEventsBucket)
+	if err := relayerEventsBucket.ForEach(func(k, v []byte) error {
+		var event *StateSyncRelayerEventData
+		if err := json.Unmarshal(v, &event); err != nil {
+			return err
+		}
+
+		if event.BlockNumber > block {
+			removedKeys = append(removedKeys, append([]byte(nil), k...))
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range removedKeys {
+		if err := relayerEventsBucket.Delete(k); err != nil {
+			return fmt.Errorf("failed to remove state sync relayer event: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // getAllAvailableEvents retrieves all StateSyncRelayerEventData that should be sent as a transactions
 func (s *StateSyncStore) getAllAvailableEvents(limit int) (result []*StateSyncRelayerEventData, err error) {
 	if err = s.db.View(func(tx *bolt.Tx) error {
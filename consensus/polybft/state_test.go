@@ -0,0 +1,120 @@
+package polybft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// fillRewindTestState stores the consensus data of the first 3 epochs of 10 blocks
+func fillRewindTestState(t *testing.T, state *State) {
+	t.Helper()
+
+	for epoch := uint64(0); epoch < 3; epoch++ {
+		require.NoError(t, state.EpochStore.insertValidatorSnapshot(&validatorSnapshot{
+			Epoch:            epoch,
+			EpochEndingBlock: epoch * 10,
+		}, nil))
+		require.NoError(t, state.EpochStore.insertEpoch(epoch, nil))
+	}
+
+	require.NoError(t, state.ProposerSnapshotStore.writeProposerSnapshot(NewProposerSnapshot(26, nil), nil))
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{BlockNumber: 25, EpochID: 2}, nil))
+	require.NoError(t, state.StateSyncStore.updateStateSyncRelayerEvents([]*StateSyncRelayerEventData{
+		{EventID: 1, BlockNumber: 5},
+		{EventID: 2, BlockNumber: 15},
+		{EventID: 3, BlockNumber: 20},
+	}, nil, nil))
+	require.NoError(t, state.insertLastProcessedEventsBlock(25, nil))
+}
+
+func TestState_Rewind(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	fillRewindTestState(t, state)
+
+	// nothing is removed if the block is the last processed one
+	require.NoError(t, state.rewind(25, 2))
+
+	snapshot, err := state.EpochStore.getLastSnapshot(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), snapshot.Epoch)
+
+	proposerSnapshot, err := state.ProposerSnapshotStore.getProposerSnapshot(nil)
+	require.NoError(t, err)
+	require.NotNil(t, proposerSnapshot)
+
+	// the data of the blocks above 15 is removed
+	require.NoError(t, state.rewind(15, 1))
+
+	snapshot, err = state.EpochStore.getLastSnapshot(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), snapshot.Epoch)
+
+	assert.True(t, state.EpochStore.isEpochInserted(1))
+	assert.False(t, state.EpochStore.isEpochInserted(2))
+
+	proposerSnapshot, err = state.ProposerSnapshotStore.getProposerSnapshot(nil)
+	require.NoError(t, err)
+	assert.Nil(t, proposerSnapshot)
+
+	_, err = state.StakeStore.getFullValidatorSet(nil)
+	require.ErrorIs(t, err, errNoFullValidatorSet)
+
+	events, err := state.StateSyncStore.getAllAvailableEvents(0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(2), events[1].EventID)
+
+	lastProcessedBlock, err := state.getLastProcessedEventsBlock(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), lastProcessedBlock)
+}
+
+func TestState_RewindState(t *testing.T) {
+	t.Parallel()
+
+	consensusDir := t.TempDir()
+	path := filepath.Join(consensusDir, "polybft", stateFileName)
+
+	extra := &Extra{Checkpoint: &CheckpointData{EpochNumber: 1}}
+	header := &types.Header{Number: 15, ExtraData: extra.MarshalRLPTo(nil)}
+
+	// the directory without the polybft state is skipped
+	require.NoError(t, RewindState(consensusDir, header, hclog.NewNullLogger()))
+
+	_, err := os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, common.CreateDirSafe(filepath.Dir(path), 0750))
+
+	state, err := newState(path, hclog.NewNullLogger(), make(chan struct{}))
+	require.NoError(t, err)
+
+	fillRewindTestState(t, state)
+	require.NoError(t, state.db.Close())
+
+	require.NoError(t, RewindState(consensusDir, header, hclog.NewNullLogger()))
+
+	state, err = newState(path, hclog.NewNullLogger(), make(chan struct{}))
+	require.NoError(t, err)
+
+	defer state.db.Close()
+
+	assert.False(t, state.EpochStore.isEpochInserted(2))
+
+	lastProcessedBlock, err := state.getLastProcessedEventsBlock(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), lastProcessedBlock)
+
+	// the header without the extra can't be rewound to
+	require.Error(t, RewindState(consensusDir, &types.Header{Number: 10}, hclog.NewNullLogger()))
+}
@@ -48,6 +48,18 @@ func newValidatorsSnapshotCache(
 	}
 }
 
+// rewind drops the cached snapshots computed at the blocks above the given block
+func (v *validatorsSnapshotCache) rewind(block uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for epoch, snapshot := range v.snapshots {
+		if snapshot.EpochEndingBlock > block {
+			delete(v.snapshots, epoch)
+		}
+	}
+}
+
 // GetSnapshot tries to retrieve the most recent cached snapshot (if any) and
 // applies pending validator set deltas to it.
 // Otherwise, it builds a snapshot from scratch and applies pending validator set deltas.
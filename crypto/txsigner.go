@@ -38,8 +38,12 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 		signer = NewFrontierSigner(forks.Homestead)
 	}
 
-	// London signer requires a fallback signer that is defined above.
-	// This is the reason why the london signer check is separated.
+	// Berlin and London signers require a fallback signer that is defined above.
+	// This is the reason why the berlin and london signer checks are separated.
+	if forks.Berlin {
+		signer = NewBerlinSigner(chainID, forks.Homestead, signer)
+	}
+
 	if forks.London {
 		return NewLondonSigner(chainID, forks.Homestead, signer)
 	}
@@ -106,9 +110,7 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 			v.Set(a.NewUint(0))
 		}
 	} else {
-		//nolint:godox
-		// TODO: Introduce AccessList
-		v.Set(a.NewArray())
+		v.Set(tx.AccessList.MarshalRLPWith(a))
 	}
 
 	var hash []byte
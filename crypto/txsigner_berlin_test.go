@@ -0,0 +1,125 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBerlinSignerSender(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	accessList := types.TxAccessList{
+		{
+			Address:     types.StringToAddress("2"),
+			StorageKeys: []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+		},
+		{
+			Address: types.StringToAddress("3"),
+		},
+	}
+
+	testTable := []struct {
+		name    string
+		chainID *big.Int
+		txType  types.TxType
+	}{
+		{
+			"mainnet access list tx",
+			big.NewInt(1),
+			types.AccessListTx,
+		},
+		{
+			"geth private access list tx",
+			big.NewInt(1337),
+			types.AccessListTx,
+		},
+		{
+			"mega large access list tx",
+			big.NewInt(0).Exp(big.NewInt(2), big.NewInt(20), nil), // 2**20
+			types.AccessListTx,
+		},
+		{
+			"legacy tx uses fallback signer",
+			big.NewInt(100),
+			types.LegacyTx,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateECDSAKey()
+			require.NoError(t, err)
+
+			txn := &types.Transaction{
+				Type:     testCase.txType,
+				To:       &toAddress,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(0),
+				ChainID:  testCase.chainID,
+			}
+
+			if testCase.txType == types.AccessListTx {
+				txn.AccessList = accessList
+			}
+
+			chainID := testCase.chainID.Uint64()
+			signer := NewBerlinSigner(chainID, true, NewEIP155Signer(chainID, true))
+
+			signedTx, err := signer.SignTx(txn, key)
+			require.NoError(t, err)
+
+			recoveredSender, err := signer.Sender(signedTx)
+			require.NoError(t, err)
+			require.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+			if testCase.txType != types.AccessListTx {
+				return
+			}
+
+			// access list is part of the signed payload
+			signedTx.AccessList = signedTx.AccessList[:1]
+
+			recoveredSender, err = signer.Sender(signedTx)
+			if err == nil {
+				require.NotEqual(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+			}
+		})
+	}
+}
+
+func TestNewSigner_Berlin(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	to := types.StringToAddress("1")
+	txn := &types.Transaction{
+		Type:     types.AccessListTx,
+		To:       &to,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		ChainID:  big.NewInt(100),
+		AccessList: types.TxAccessList{
+			{Address: to, StorageKeys: []types.Hash{types.StringToHash("1")}},
+		},
+	}
+
+	signer := NewSigner(chain.AllForksEnabled.At(0), 100)
+
+	signedTx, err := signer.SignTx(txn, key)
+	require.NoError(t, err)
+
+	sender, err := signer.Sender(signedTx)
+	require.NoError(t, err)
+	require.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+}
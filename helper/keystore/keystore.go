@@ -2,12 +2,19 @@ package keystore
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/umbracle/ethgo/keystore"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 )
 
+var errMissingAddress = errors.New("the encrypted key has no address")
+
 type createFn func() ([]byte, error)
 
 // CreateIfNotExists generates a private key at the specified path,
@@ -53,3 +60,49 @@ func CreatePrivateKey(create createFn) ([]byte, error) {
 	// Encode it to a readable format (hex) and return
 	return []byte(hex.EncodeToString(keyBuff)), nil
 }
+
+// EncryptKey encrypts the private key in the web3 secret storage (v3) format.
+// The address is kept in plain text, so the keys can be listed without their passwords.
+// The optional scrypt params are N and P, their defaults are 2^18 and 1
+func EncryptKey(key []byte, address string, password string, scryptParams ...int) ([]byte, error) {
+	encrypted, err := keystore.EncryptV3(key, password, scryptParams...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt private key, %w", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encrypted, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["address"] = strings.ToLower(strings.TrimPrefix(address, "0x"))
+
+	return json.Marshal(fields)
+}
+
+// DecryptKey decrypts the private key encrypted in the web3 secret storage (v3) format
+func DecryptKey(content []byte, password string) ([]byte, error) {
+	key, err := keystore.DecryptV3(content, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt private key, %w", err)
+	}
+
+	return key, nil
+}
+
+// ReadKeyAddress returns the plain text address of the encrypted key
+func ReadKeyAddress(content []byte) (string, error) {
+	var fields struct {
+		Address string `json:"address"`
+	}
+
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return "", fmt.Errorf("unable to read encrypted key, %w", err)
+	}
+
+	if fields.Address == "" {
+		return "", errMissingAddress
+	}
+
+	return fields.Address, nil
+}
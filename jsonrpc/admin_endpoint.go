@@ -0,0 +1,116 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// adminStore provides access to the methods needed by admin endpoint
+type adminStore interface {
+	// Header returns the current header of the chain
+	Header() *types.Header
+
+	// GetNodeAddrs returns the ID of the node and its full p2p addresses
+	GetNodeAddrs() (string, []string)
+
+	// GetPeerInfos returns the connected peers
+	GetPeerInfos() []*PeerInfo
+
+	// JoinPeer attempts to connect to the peer given by its p2p multiaddr
+	JoinPeer(rawPeerMultiaddr string) error
+
+	// AddTrustedPeer marks the peer given by its p2p multiaddr as trusted and attempts to connect to it
+	AddTrustedPeer(rawPeerMultiaddr string) error
+
+	// RemovePeer disconnects from the peer given by its p2p multiaddr or ID and untrusts it
+	RemovePeer(rawPeer string) error
+}
+
+// NodeInfo is the result of admin_nodeInfo
+type NodeInfo struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Enode       string                 `json:"enode"`
+	ListenAddrs []string               `json:"listenAddrs"`
+	Protocols   map[string]interface{} `json:"protocols"`
+}
+
+// PeerInfo is the connected peer returned by admin_peers
+type PeerInfo struct {
+	ID      string          `json:"id"`
+	Addrs   []string        `json:"addrs"`
+	Caps    []string        `json:"caps"`
+	Network PeerNetworkInfo `json:"network"`
+}
+
+type PeerNetworkInfo struct {
+	Trusted bool `json:"trusted"`
+}
+
+// Admin is the admin jsonrpc endpoint, it's served only on IPC or on the loopback address
+type Admin struct {
+	store     adminStore
+	chainID   uint64
+	chainName string
+}
+
+// NodeInfo returns the information about the running node (admin_nodeInfo),
+// the enode is the p2p multiaddr of the node, as accepted by admin_addPeer
+func (a *Admin) NodeInfo() (interface{}, error) {
+	id, addrs := a.store.GetNodeAddrs()
+
+	protocol := map[string]interface{}{
+		"network": a.chainID,
+	}
+
+	if header := a.store.Header(); header != nil {
+		protocol["head"] = header.Hash
+		protocol["number"] = argUint64(header.Number)
+	}
+
+	info := &NodeInfo{
+		ID:          id,
+		Name:        clientVersion(a.chainName),
+		ListenAddrs: addrs,
+		Protocols:   map[string]interface{}{"edge": protocol},
+	}
+
+	if len(addrs) > 0 {
+		info.Enode = addrs[0]
+	}
+
+	return info, nil
+}
+
+// Peers returns the connected peers (admin_peers)
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.GetPeerInfos(), nil
+}
+
+// AddPeer marks the peer given by its p2p multiaddr for dialing (admin_addPeer)
+func (a *Admin) AddPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.JoinPeer(rawPeerMultiaddr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer given by its p2p multiaddr or ID,
+// the peer is also removed from the trusted peers (admin_removePeer)
+func (a *Admin) RemovePeer(rawPeer string) (interface{}, error) {
+	if err := a.store.RemovePeer(rawPeer); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// AddTrustedPeer marks the peer given by its p2p multiaddr as trusted and dials it (admin_addTrustedPeer),
+// the trusted peers are connected regardless of the peer limits
+func (a *Admin) AddTrustedPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.AddTrustedPeer(rawPeerMultiaddr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
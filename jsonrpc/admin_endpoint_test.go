@@ -0,0 +1,185 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const testPeerAddr = "/ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW"
+
+type mockAdminStore struct {
+	*mockStore
+
+	joined  []string
+	trusted []string
+	removed []string
+}
+
+func (m *mockAdminStore) GetNodeAddrs() (string, []string) {
+	return "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW", []string{testPeerAddr}
+}
+
+func (m *mockAdminStore) GetPeerInfos() []*PeerInfo {
+	peers := make([]*PeerInfo, 0, len(m.joined))
+	for _, addr := range m.joined {
+		peers = append(peers, &PeerInfo{ID: addr, Caps: []string{}, Addrs: []string{}})
+	}
+
+	return peers
+}
+
+func (m *mockAdminStore) JoinPeer(rawPeerMultiaddr string) error {
+	if rawPeerMultiaddr == "" {
+		return errors.New("invalid multiaddr")
+	}
+
+	m.joined = append(m.joined, rawPeerMultiaddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) AddTrustedPeer(rawPeerMultiaddr string) error {
+	m.trusted = append(m.trusted, rawPeerMultiaddr)
+
+	return m.JoinPeer(rawPeerMultiaddr)
+}
+
+func (m *mockAdminStore) RemovePeer(rawPeer string) error {
+	m.removed = append(m.removed, rawPeer)
+
+	return nil
+}
+
+func newTestAdminDispatcher(t *testing.T, loopback bool) (*Dispatcher, *mockAdminStore) {
+	t.Helper()
+
+	store := &mockAdminStore{mockStore: newMockStore()}
+	store.header = &types.Header{Number: 10}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:         100,
+			chainName:       "test-chain",
+			enableAdmin:     true,
+			localOnLoopback: loopback,
+		},
+	)
+
+	return dispatcher, store
+}
+
+func TestAdminEndpoint_Disabled(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err := dispatcher.HandleIPC([]byte(`{"method": "admin_nodeInfo", "params": []}`), &mockWsConn{})
+	require.NoError(t, err)
+
+	var res interface{}
+
+	var rpcErr *ObjectError
+	require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+
+	// the store has to support the admin namespace once it's enabled
+	_, err = newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{enableAdmin: true})
+	require.Error(t, err)
+}
+
+func TestAdminEndpoint_Transports(t *testing.T) {
+	t.Parallel()
+
+	req := []byte(`{"method": "admin_peers", "params": []}`)
+
+	for _, loopback := range []bool{false, true} {
+		dispatcher, _ := newTestAdminDispatcher(t, loopback)
+
+		var res []*PeerInfo
+
+		// the IPC connections are always served
+		resp, err := dispatcher.HandleIPC(req, &mockWsConn{})
+		require.NoError(t, err)
+		require.NoError(t, expectJSONResult(resp, &res))
+
+		resp, err = dispatcher.Handle(req)
+		require.NoError(t, err)
+
+		if loopback {
+			require.NoError(t, expectJSONResult(resp, &res))
+		} else {
+			var rpcErr *ObjectError
+			require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+			assert.Equal(t, -32601, rpcErr.Code)
+		}
+	}
+}
+
+func TestAdminEndpoint_Peers(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, store := newTestAdminDispatcher(t, true)
+
+	call := func(method, param string, res interface{}) error {
+		resp, err := dispatcher.Handle([]byte(`{"method": "` + method + `", "params": ["` + param + `"]}`))
+		require.NoError(t, err)
+
+		return expectJSONResult(resp, res)
+	}
+
+	var ok bool
+
+	require.NoError(t, call("admin_addPeer", testPeerAddr, &ok))
+	assert.True(t, ok)
+
+	require.NoError(t, call("admin_addTrustedPeer", testPeerAddr, &ok))
+	assert.True(t, ok)
+
+	require.NoError(t, call("admin_removePeer", testPeerAddr, &ok))
+	assert.True(t, ok)
+
+	assert.Equal(t, []string{testPeerAddr, testPeerAddr}, store.joined)
+	assert.Equal(t, []string{testPeerAddr}, store.trusted)
+	assert.Equal(t, []string{testPeerAddr}, store.removed)
+
+	require.Error(t, call("admin_addPeer", "", &ok))
+
+	var peers []*PeerInfo
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "admin_peers", "params": []}`))
+	require.NoError(t, err)
+	require.NoError(t, expectJSONResult(resp, &peers))
+	assert.Len(t, peers, 2)
+}
+
+func TestAdminEndpoint_NodeInfo(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, store := newTestAdminDispatcher(t, true)
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "admin_nodeInfo", "params": []}`))
+	require.NoError(t, err)
+
+	var info NodeInfo
+
+	require.NoError(t, expectJSONResult(resp, &info))
+
+	assert.Equal(t, "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW", info.ID)
+	assert.Equal(t, testPeerAddr, info.Enode)
+	assert.Equal(t, clientVersion("test-chain"), info.Name)
+	assert.Equal(t, map[string]interface{}{
+		"edge": map[string]interface{}{
+			"network": float64(100),
+			"head":    store.header.Hash.String(),
+			"number":  "0xa",
+		},
+	}, info.Protocols)
+}
@@ -0,0 +1,250 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"golang.org/x/sync/semaphore"
+)
+
+var (
+	errMissingToken = errors.New("missing auth token")
+	errInvalidToken = errors.New("invalid auth token")
+	errExpiredToken = errors.New("auth token expired")
+)
+
+// APIKey is the key of a JSON-RPC client, the client authenticates either with the key itself
+// as the bearer token, or with a JWT signed by the secret (HS256) whose subject is the key name.
+// The zero quotas are not enforced
+type APIKey struct {
+	Name                  string `json:"name"`
+	Key                   string `json:"key"`
+	Secret                string `json:"secret"`
+	RequestsPerSecond     uint64 `json:"requestsPerSecond"`
+	MaxConcurrentRequests uint64 `json:"maxConcurrentRequests"`
+	BlockRangeLimit       uint64 `json:"blockRangeLimit"`
+}
+
+// authKey is the authenticated key together with the state of its quotas
+type authKey struct {
+	*APIKey
+
+	limiter    *rateLimiter
+	concurrent *semaphore.Weighted
+}
+
+// KeyStore holds the keys accepted by the JSON-RPC server
+type KeyStore struct {
+	// byDigest indexes the keys by the digest of the key value,
+	// so the lookup time doesn't depend on the matching prefix of the token
+	byDigest map[[sha256.Size]byte]*authKey
+	byName   map[string]*authKey
+}
+
+// NewKeyStore validates the keys and returns the key store
+func NewKeyStore(keys []*APIKey) (*KeyStore, error) {
+	s := &KeyStore{
+		byDigest: make(map[[sha256.Size]byte]*authKey, len(keys)),
+		byName:   make(map[string]*authKey, len(keys)),
+	}
+
+	for idx, key := range keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("key #%d has no name", idx)
+		}
+
+		if key.Key == "" && key.Secret == "" {
+			return nil, fmt.Errorf("key %s has neither key nor secret", key.Name)
+		}
+
+		if _, ok := s.byName[key.Name]; ok {
+			return nil, fmt.Errorf("key %s is defined more than once", key.Name)
+		}
+
+		k := &authKey{APIKey: key}
+
+		if key.RequestsPerSecond != 0 {
+			k.limiter = newRateLimiter(key.RequestsPerSecond, key.RequestsPerSecond)
+		}
+
+		if key.MaxConcurrentRequests != 0 {
+			k.concurrent = semaphore.NewWeighted(int64(key.MaxConcurrentRequests))
+		}
+
+		if key.Key != "" {
+			digest := sha256.Sum256([]byte(key.Key))
+			if _, ok := s.byDigest[digest]; ok {
+				return nil, fmt.Errorf("key %s is not unique", key.Name)
+			}
+
+			s.byDigest[digest] = k
+		}
+
+		s.byName[key.Name] = k
+	}
+
+	return s, nil
+}
+
+// LoadKeyStore reads the key store from the JSON file in the form of {"keys": [...]}
+func LoadKeyStore(path string) (*KeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Keys []*APIKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode the key store %s: %w", path, err)
+	}
+
+	return NewKeyStore(file.Keys)
+}
+
+// authenticateRequest returns the key of the HTTP request, the WS clients
+// which can't set the headers may pass the token as the query parameter
+func (s *KeyStore) authenticateRequest(req *http.Request, allowQuery bool) (*authKey, error) {
+	token := ""
+
+	if header := req.Header.Get("Authorization"); header != "" {
+		scheme, value, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return nil, errInvalidToken
+		}
+
+		token = strings.TrimSpace(value)
+	} else if allowQuery {
+		token = req.URL.Query().Get("token")
+	}
+
+	if token == "" {
+		return nil, errMissingToken
+	}
+
+	return s.authenticate(token)
+}
+
+// authenticate returns the key of the token, which is either the key itself or a JWT
+func (s *KeyStore) authenticate(token string) (*authKey, error) {
+	if strings.Count(token, ".") == 2 {
+		return s.authenticateJWT(token)
+	}
+
+	key, ok := s.byDigest[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, errInvalidToken
+	}
+
+	return key, nil
+}
+
+// authenticateJWT verifies the HS256 JWT against the secret of the key named by its subject
+func (s *KeyStore) authenticateJWT(token string) (*authKey, error) {
+	parts := strings.Split(token, ".")
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	var claims struct {
+		Subject   string `json:"sub"`
+		ExpiresAt *int64 `json:"exp"`
+		NotBefore *int64 `json:"nbf"`
+	}
+
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, errInvalidToken
+	}
+
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, errInvalidToken
+	}
+
+	key, ok := s.byName[claims.Subject]
+	if !ok || key.Secret == "" {
+		return nil, errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, []byte(key.Secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	now := time.Now().Unix()
+
+	if claims.ExpiresAt != nil && now >= *claims.ExpiresAt {
+		return nil, errExpiredToken
+	}
+
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return nil, errInvalidToken
+	}
+
+	return key, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// acquire takes the request from the rate and the concurrency quotas of the key,
+// the returned function releases the concurrency quota once the request is handled
+func (k *authKey) acquire() (func(), Error) {
+	if k.limiter != nil && !k.limiter.allow() {
+		k.reject("rate")
+
+		return nil, NewLimitExceededError(fmt.Sprintf("rate limit of %d requests per second exceeded", k.RequestsPerSecond))
+	}
+
+	if k.concurrent == nil {
+		return func() {}, nil
+	}
+
+	if !k.concurrent.TryAcquire(1) {
+		k.reject("concurrency")
+
+		return nil, NewLimitExceededError(fmt.Sprintf("limit of %d concurrent requests exceeded", k.MaxConcurrentRequests))
+	}
+
+	return func() { k.concurrent.Release(1) }, nil
+}
+
+// reject counts the request rejected by the quota of the key
+func (k *authKey) reject(quota string) {
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetric, "quota_rejected"}, 1, []metrics.Label{
+		{Name: "key", Value: k.Name},
+		{Name: "quota", Value: quota},
+	})
+}
+
+// authRejected counts the request rejected by the authentication
+func authRejected(transport serverType, err error) {
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetric, "auth_rejected"}, 1, []metrics.Label{
+		{Name: "transport", Value: transport.String()},
+		{Name: "reason", Value: err.Error()},
+	})
+}
@@ -0,0 +1,303 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signJWT(t *testing.T, secret string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestKeyStore_Load(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": [
+		{"name": "indexer", "key": "abc", "requestsPerSecond": 10, "blockRangeLimit": 100},
+		{"name": "explorer", "secret": "s3cret", "maxConcurrentRequests": 2}
+	]}`), 0600))
+
+	store, err := LoadKeyStore(path)
+	require.NoError(t, err)
+
+	key, err := store.authenticate("abc")
+	require.NoError(t, err)
+	assert.Equal(t, "indexer", key.Name)
+	assert.Equal(t, uint64(100), key.BlockRangeLimit)
+	assert.NotNil(t, key.limiter)
+	assert.Nil(t, key.concurrent)
+
+	_, err = LoadKeyStore(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestKeyStore_InvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		keys []*APIKey
+	}{
+		{"no name", []*APIKey{{Key: "a"}}},
+		{"no key nor secret", []*APIKey{{Name: "a"}}},
+		{"duplicated name", []*APIKey{{Name: "a", Key: "a"}, {Name: "a", Key: "b"}}},
+		{"duplicated key", []*APIKey{{Name: "a", Key: "a"}, {Name: "b", Key: "a"}}},
+	}
+
+	for _, c := range cases {
+		_, err := NewKeyStore(c.keys)
+		require.Error(t, err, c.name)
+	}
+}
+
+func TestKeyStore_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewKeyStore([]*APIKey{
+		{Name: "plain", Key: "key1"},
+		{Name: "jwt", Secret: "secret"},
+	})
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+
+	cases := []struct {
+		name  string
+		token string
+		key   string
+		err   error
+	}{
+		{"plain key", "key1", "plain", nil},
+		{"unknown key", "key2", "", errInvalidToken},
+		{"jwt", signJWT(t, "secret", map[string]interface{}{"sub": "jwt", "exp": now + 60}), "jwt", nil},
+		{"jwt without expiry", signJWT(t, "secret", map[string]interface{}{"sub": "jwt"}), "jwt", nil},
+		{"jwt with wrong secret", signJWT(t, "other", map[string]interface{}{"sub": "jwt"}), "", errInvalidToken},
+		{"jwt of key without secret", signJWT(t, "", map[string]interface{}{"sub": "plain"}), "", errInvalidToken},
+		{"expired jwt", signJWT(t, "secret", map[string]interface{}{"sub": "jwt", "exp": now - 1}), "", errExpiredToken},
+		{"premature jwt", signJWT(t, "secret", map[string]interface{}{"sub": "jwt", "nbf": now + 60}), "", errInvalidToken},
+		{"malformed jwt", "a.b.c", "", errInvalidToken},
+	}
+
+	for _, c := range cases {
+		key, err := store.authenticate(c.token)
+		if c.err != nil {
+			require.ErrorIs(t, err, c.err, c.name)
+
+			continue
+		}
+
+		require.NoError(t, err, c.name)
+		assert.Equal(t, c.key, key.Name, c.name)
+	}
+}
+
+func TestKeyStore_AuthenticateRequest(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewKeyStore([]*APIKey{{Name: "plain", Key: "key1"}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	_, err = store.authenticateRequest(req, false)
+	require.ErrorIs(t, err, errMissingToken)
+
+	req.Header.Set("Authorization", "Basic key1")
+	_, err = store.authenticateRequest(req, false)
+	require.ErrorIs(t, err, errInvalidToken)
+
+	req.Header.Set("Authorization", "Bearer key1")
+	_, err = store.authenticateRequest(req, false)
+	require.NoError(t, err)
+
+	// the query parameter is accepted only if it's allowed
+	req = httptest.NewRequest("GET", "/ws?token=key1", nil)
+	_, err = store.authenticateRequest(req, false)
+	require.ErrorIs(t, err, errMissingToken)
+
+	_, err = store.authenticateRequest(req, true)
+	require.NoError(t, err)
+}
+
+func TestAuthKey_Quotas(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewKeyStore([]*APIKey{
+		{Name: "rate", Key: "rate", RequestsPerSecond: 2},
+		{Name: "concurrency", Key: "concurrency", MaxConcurrentRequests: 1},
+	})
+	require.NoError(t, err)
+
+	// rate
+	key, err := store.authenticate("rate")
+	require.NoError(t, err)
+
+	now := time.Now()
+	key.limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		release, err := key.acquire()
+		require.Nil(t, err)
+		release()
+	}
+
+	_, rpcErr := key.acquire()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, -32005, rpcErr.ErrorCode())
+
+	// the bucket is refilled over time
+	now = now.Add(500 * time.Millisecond)
+
+	_, rpcErr = key.acquire()
+	require.Nil(t, rpcErr)
+
+	// concurrency
+	key, err = store.authenticate("concurrency")
+	require.NoError(t, err)
+
+	release, rpcErr := key.acquire()
+	require.Nil(t, rpcErr)
+
+	_, rpcErr = key.acquire()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, -32005, rpcErr.ErrorCode())
+
+	release()
+
+	_, rpcErr = key.acquire()
+	require.Nil(t, rpcErr)
+}
+
+func TestDispatcher_KeyBlockRangeLimit(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	store.header.Number = 1000
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+		},
+	)
+
+	keys, err := NewKeyStore([]*APIKey{{Name: "limited", Key: "limited", BlockRangeLimit: 100}})
+	require.NoError(t, err)
+
+	key, err := keys.authenticate("limited")
+	require.NoError(t, err)
+
+	cases := []struct {
+		method   string
+		params   string
+		rejected bool
+	}{
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0x65"}]`, false},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0x66"}]`, true},
+		{"eth_getLogs", `[{"fromBlock":"0x1"}]`, true},
+		{"eth_getLogs", `[{}]`, false},
+		{"eth_newFilter", `[{"fromBlock":"earliest","toBlock":"latest"}]`, true},
+		{"trace_filter", `[{"toBlock":"0x200"}]`, true},
+		{"trace_filter", `[{"fromBlock":"0x1f4"}]`, true},
+		{"trace_filter", `[{"fromBlock":"0x384"}]`, false},
+		{"eth_blockNumber", `[]`, false},
+	}
+
+	for _, c := range cases {
+		req := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":%s}`, c.method, c.params)
+
+		res, err := dispatcher.handleWithKey([]byte(req), key)
+		require.NoError(t, err)
+
+		resp := &ErrorResponse{}
+		require.NoError(t, json.Unmarshal(res, resp))
+
+		if c.rejected {
+			require.NotNil(t, resp.Error, c.params)
+			assert.Equal(t, -32005, resp.Error.Code, c.params)
+		} else if resp.Error != nil {
+			assert.NotEqual(t, -32005, resp.Error.Code, c.params)
+		}
+	}
+
+	// the range of the requests without the key is not limited
+	res, err := dispatcher.Handle([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[{"fromBlock":"0x1"}]}`))
+	require.NoError(t, err)
+
+	resp := &ErrorResponse{}
+	require.NoError(t, json.Unmarshal(res, resp))
+	assert.Nil(t, resp.Error)
+}
+
+func TestJSONRPC_Authentication(t *testing.T) {
+	t.Parallel()
+
+	keys, err := NewKeyStore([]*APIKey{{Name: "team", Key: "key1"}})
+	require.NoError(t, err)
+
+	j, err := newTestJSONRPC(t)
+	require.NoError(t, err)
+
+	j.config.KeyStore = keys
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(
+			"POST", "/",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`),
+		)
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		w := httptest.NewRecorder()
+		j.handle(w, req)
+
+		return w
+	}
+
+	for _, token := range []string{"", "key2"} {
+		w := request(token)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+
+		resp := &ErrorResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, -32001, resp.Error.Code)
+	}
+
+	w := request("key1")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	resp := &SuccessResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Nil(t, resp.Error)
+}
@@ -2,18 +2,32 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-const callTracerName = "callTracer"
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+)
+
+// accountRangeMaxResults is the maximum number of the accounts returned by debug_accountRange
+const accountRangeMaxResults = 256
+
+// storageRangeMaxResults is the maximum number of the slots returned by debug_storageRangeAt
+const storageRangeMaxResults = 1024
 
 var (
 	defaultTraceTimeout = 5 * time.Second
@@ -30,6 +44,9 @@ type debugBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the last finalized block
+	FinalizedHeader() (*types.Header, error)
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
@@ -42,14 +59,30 @@ type debugBlockchainStore interface {
 	// GetBlockByNumber gets a block using the provided height
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// SetHead rewinds the chain to the block with the given number
+	SetHead(number uint64) error
+
+	// GetBadBlocks returns the last blocks rejected by the verification
+	GetBadBlocks() ([]*storage.BadBlock, error)
+
 	// TraceBlock traces all transactions in the given block
 	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
 
 	// TraceTxn traces a transaction in the block, associated with the given hash
 	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
 
-	// TraceCall traces a single call at the point when the given header is mined
-	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	// TraceCall traces a single call at the point when the given header is mined,
+	// with the given state and block overrides applied
+	TraceCall(
+		*types.Transaction,
+		*types.Header,
+		types.StateOverride,
+		*types.BlockOverride,
+		tracer.Tracer,
+	) (interface{}, error)
 }
 
 type debugTxPoolStore interface {
@@ -58,6 +91,9 @@ type debugTxPoolStore interface {
 
 type debugStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+
+	// GetStateAt returns the state and its root after the first txIndex transactions of the block are applied
+	GetStateAt(block *types.Block, txIndex int) (*itrie.State, types.Hash, error)
 }
 
 type debugStore interface {
@@ -87,6 +123,17 @@ type TraceConfig struct {
 	DisableStructLogs bool    `json:"disableStructLogs"`
 	Timeout           *string `json:"timeout"`
 	Tracer            string  `json:"tracer"`
+
+	// TracerConfig is the configuration specific to the selected tracer
+	TracerConfig json.RawMessage `json:"tracerConfig"`
+}
+
+// TraceCallConfig is the config of debug_traceCall, which can override the state and the block context
+type TraceCallConfig struct {
+	TraceConfig
+
+	StateOverrides *stateOverride `json:"stateOverrides"`
+	BlockOverrides *blockOverride `json:"blockOverrides"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -181,11 +228,15 @@ func (d *Debug) TraceTransaction(
 func (d *Debug) TraceCall(
 	arg *txnArgs,
 	filter BlockNumberOrHash,
-	config *TraceConfig,
+	config *TraceCallConfig,
 ) (interface{}, error) {
 	return d.throttling.AttemptRequest(
 		context.Background(),
 		func() (interface{}, error) {
+			if config == nil {
+				return nil, ErrNoConfig
+			}
+
 			header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
 			if err != nil {
 				return nil, ErrHeaderNotFound
@@ -196,23 +247,320 @@ func (d *Debug) TraceCall(
 				return nil, err
 			}
 
+			blockOverride := config.BlockOverrides.ToType()
+
 			// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 			if tx.Gas == 0 {
-				tx.Gas = header.GasLimit
+				tx.Gas = blockOverride.Apply(header).GasLimit
 			}
 
-			tracer, cancel, err := newTracer(config)
+			tracer, cancel, err := newTracer(&config.TraceConfig)
 			if err != nil {
 				return nil, err
 			}
 
 			defer cancel()
 
-			return d.store.TraceCall(tx, header, tracer)
+			return d.store.TraceCall(tx, header, config.StateOverrides.ToType(), blockOverride, tracer)
+		},
+	)
+}
+
+// GetRawHeader returns the RLP encoded header (debug_getRawHeader)
+func (d *Debug) GetRawHeader(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return argBytes(header.MarshalRLP()), nil
+}
+
+// GetRawBlock returns the RLP encoded block (debug_getRawBlock)
+func (d *Debug) GetRawBlock(filter BlockNumberOrHash) (interface{}, error) {
+	block, err := d.getBlock(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return argBytes(block.MarshalRLP()), nil
+}
+
+// GetRawReceipts returns the RLP encoded receipts of the block, the typed receipts
+// are prefixed by their types as in the receipts trie (debug_getRawReceipts)
+func (d *Debug) GetRawReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := d.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]argBytes, 0, len(receipts))
+	for _, receipt := range receipts {
+		res = append(res, receipt.MarshalRLP())
+	}
+
+	return res, nil
+}
+
+// GetRawTransaction returns the RLP encoded sealed transaction, or null if it's not found (debug_getRawTransaction)
+func (d *Debug) GetRawTransaction(txHash types.Hash) (interface{}, error) {
+	tx, _ := GetTxAndBlockByTxHash(txHash, d.store)
+	if tx == nil {
+		return nil, nil
+	}
+
+	return argBytes(tx.MarshalRLP()), nil
+}
+
+// StorageRangeAt returns the storage slots of the account after the first txIndex transactions of the block
+// are applied, starting from the given key hash, at most 1024 of them.
+// The next key is set if there are more slots (debug_storageRangeAt)
+func (d *Debug) StorageRangeAt(
+	blockHash types.Hash,
+	txIndex int,
+	address types.Address,
+	keyStart argBytes,
+	maxResult int,
+) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			block, ok := d.store.GetBlockByHash(blockHash, true)
+			if !ok {
+				return nil, fmt.Errorf("block %s not found", blockHash)
+			}
+
+			st, root, err := d.store.GetStateAt(block, txIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			if maxResult <= 0 || maxResult > storageRangeMaxResults {
+				maxResult = storageRangeMaxResults
+			}
+
+			res := &storageRangeResult{
+				Storage: make(map[types.Hash]storageEntry),
+			}
+
+			account, err := getStateAccount(st, root, address)
+			if err != nil || account == nil {
+				return res, err
+			}
+
+			err = st.IterateStorage(account.Root, types.BytesToHash(keyStart), func(key, value types.Hash) bool {
+				if len(res.Storage) >= maxResult {
+					res.NextKey = &key
+
+					return false
+				}
+
+				res.Storage[key] = storageEntry{Value: value}
+
+				return true
+			})
+
+			return res, err
+		},
+	)
+}
+
+// AccountRange returns the accounts of the state at the block, starting from the given key hash,
+// at most 256 of them. The incompletes param is accepted for compatibility, all the accounts
+// are returned by the hashes of their addresses, as their preimages aren't stored (debug_accountRange)
+func (d *Debug) AccountRange(
+	filter BlockNumberOrHash,
+	start argBytes,
+	maxResults int,
+	noCode bool,
+	noStorage bool,
+	_ bool,
+) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			block, err := d.getBlock(filter)
+			if err != nil {
+				return nil, err
+			}
+
+			if maxResults <= 0 || maxResults > accountRangeMaxResults {
+				maxResults = accountRangeMaxResults
+			}
+
+			return d.dumpState(block, types.BytesToHash(start), maxResults, noCode, noStorage)
+		},
+	)
+}
+
+// DumpBlock returns all the accounts of the state at the block together with their code and storage,
+// the dump isn't paged, so it's served only on the local machine (debug_dumpBlock)
+func (d *Debug) DumpBlock(number BlockNumber) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			num, err := GetNumericBlockNumber(number, d.store)
+			if err != nil {
+				return nil, err
+			}
+
+			block, ok := d.store.GetBlockByNumber(num, true)
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", num)
+			}
+
+			return d.dumpState(block, types.ZeroHash, 0, false, false)
+		},
+	)
+}
+
+// SetHead rewinds the chain to the block with the given number, the blocks above it are no longer canonical
+// and are imported again by the syncer (debug_setHead)
+func (d *Debug) SetHead(number argUint64) (interface{}, error) {
+	if err := d.store.SetHead(uint64(number)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// GetBadBlocks returns the last blocks rejected by the verification together with the reasons
+// of the rejections and the sources they were received from, the most recent first (debug_getBadBlocks)
+func (d *Debug) GetBadBlocks() (interface{}, error) {
+	badBlocks, err := d.store.GetBadBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*badBlock, 0, len(badBlocks))
+	for _, bb := range badBlocks {
+		res = append(res, &badBlock{
+			Hash:      bb.Block.Hash(),
+			Block:     toBlock(bb.Block, true),
+			RLP:       bb.Block.MarshalRLP(),
+			Reason:    bb.Reason,
+			Source:    bb.Source,
+			Timestamp: argUint64(bb.Time),
+		})
+	}
+
+	return res, nil
+}
+
+// TraceBadBlock traces the transactions of the bad block with the given hash
+// on top of the state of its parent (debug_traceBadBlock)
+func (d *Debug) TraceBadBlock(
+	blockHash types.Hash,
+	config *TraceConfig,
+) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			badBlocks, err := d.store.GetBadBlocks()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, bb := range badBlocks {
+				if bb.Block.Hash() == blockHash {
+					return d.traceBlock(bb.Block, config)
+				}
+			}
+
+			return nil, fmt.Errorf("bad block %s not found", blockHash)
 		},
 	)
 }
 
+func (d *Debug) getBlock(filter BlockNumberOrHash) (*types.Block, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := d.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", header.Hash)
+	}
+
+	return block, nil
+}
+
+// dumpState returns the accounts of the state at the block starting from the given key hash,
+// at most maxResults of them, or all of them if it's zero
+func (d *Debug) dumpState(
+	block *types.Block,
+	start types.Hash,
+	maxResults int,
+	noCode bool,
+	noStorage bool,
+) (*stateDump, error) {
+	st, root, err := d.store.GetStateAt(block, len(block.Transactions))
+	if err != nil {
+		return nil, err
+	}
+
+	dump := &stateDump{
+		Root:     root,
+		Accounts: make(map[types.Hash]*dumpAccount),
+	}
+
+	var dumpErr error
+
+	err = st.IterateAccounts(root, start, func(key types.Hash, account *state.Account) bool {
+		if maxResults > 0 && len(dump.Accounts) >= maxResults {
+			dump.Next = &key
+
+			return false
+		}
+
+		dumped := &dumpAccount{
+			Balance:  account.Balance.String(),
+			Nonce:    account.Nonce,
+			Root:     account.Root,
+			CodeHash: types.BytesToHash(account.CodeHash),
+		}
+
+		if !noCode {
+			dumped.Code, _ = st.GetCode(dumped.CodeHash)
+		}
+
+		if !noStorage {
+			dumped.Storage = make(map[types.Hash]types.Hash)
+
+			dumpErr = st.IterateStorage(account.Root, types.ZeroHash, func(key, value types.Hash) bool {
+				dumped.Storage[key] = value
+
+				return true
+			})
+		}
+
+		dump.Accounts[key] = dumped
+
+		return dumpErr == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dump, dumpErr
+}
+
+// getStateAccount returns the account of the state at the root, nil if it doesn't exist
+func getStateAccount(st *itrie.State, root types.Hash, address types.Address) (*state.Account, error) {
+	snap, err := st.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.GetAccount(address)
+}
+
 func (d *Debug) traceBlock(
 	block *types.Block,
 	config *TraceConfig,
@@ -254,9 +602,20 @@ func newTracer(config *TraceConfig) (
 
 	var tracer tracer.Tracer
 
-	if config.Tracer == callTracerName {
+	switch config.Tracer {
+	case callTracerName:
 		tracer = &calltracer.CallTracer{}
-	} else {
+	case prestateTracerName:
+		prestateConfig := prestatetracer.Config{}
+
+		if len(config.TracerConfig) > 0 {
+			if err := json.Unmarshal(config.TracerConfig, &prestateConfig); err != nil {
+				return nil, nil, fmt.Errorf("invalid tracer config: %w", err)
+			}
+		}
+
+		tracer = prestatetracer.NewPrestateTracer(prestateConfig)
+	default:
 		tracer = structtracer.NewStructTracer(structtracer.Config{
 			EnableMemory:     config.EnableMemory && !config.DisableStructLogs,
 			EnableStack:      !config.DisableStack && !config.DisableStructLogs,
@@ -266,6 +625,12 @@ func newTracer(config *TraceConfig) (
 		})
 	}
 
+	// cancellation of context is done by caller
+	return tracer, cancelTracerOnTimeout(tracer, timeout), nil
+}
+
+// cancelTracerOnTimeout cancels the tracer once the given timeout expires
+func cancelTracerOnTimeout(tracer tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
@@ -276,6 +641,5 @@ func newTracer(config *TraceConfig) (
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
 }
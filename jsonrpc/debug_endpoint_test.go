@@ -2,36 +2,52 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
 type debugEndpointMockStore struct {
 	headerFn            func() *types.Header
+	finalizedHeaderFn   func() (*types.Header, error)
 	getHeaderByNumberFn func(uint64) (*types.Header, bool)
 	readTxLookupFn      func(types.Hash) (types.Hash, bool)
 	getBlockByHashFn    func(types.Hash, bool) (*types.Block, bool)
 	getBlockByNumberFn  func(uint64, bool) (*types.Block, bool)
 	traceBlockFn        func(*types.Block, tracer.Tracer) ([]interface{}, error)
 	traceTxnFn          func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
-	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	traceCallFn         func(*types.Transaction, *types.Header, types.StateOverride, *types.BlockOverride, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	getReceiptsFn       func(types.Hash) ([]*types.Receipt, error)
+	getStateAtFn        func(*types.Block, int) (*itrie.State, types.Hash, error)
+	setHeadFn           func(uint64) error
+	getBadBlocksFn      func() ([]*storage.BadBlock, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
 	return s.headerFn()
 }
 
+func (s *debugEndpointMockStore) FinalizedHeader() (*types.Header, error) {
+	return s.finalizedHeaderFn()
+}
+
 func (s *debugEndpointMockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	return s.getHeaderByNumberFn(num)
 }
@@ -56,8 +72,14 @@ func (s *debugEndpointMockStore) TraceTxn(block *types.Block, targetTx types.Has
 	return s.traceTxnFn(block, targetTx, tracer)
 }
 
-func (s *debugEndpointMockStore) TraceCall(tx *types.Transaction, parent *types.Header, tracer tracer.Tracer) (interface{}, error) {
-	return s.traceCallFn(tx, parent, tracer)
+func (s *debugEndpointMockStore) TraceCall(
+	tx *types.Transaction,
+	parent *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+	tracer tracer.Tracer,
+) (interface{}, error) {
+	return s.traceCallFn(tx, parent, override, blockOverride, tracer)
 }
 
 func (s *debugEndpointMockStore) GetNonce(acc types.Address) uint64 {
@@ -68,6 +90,22 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return s.getReceiptsFn(hash)
+}
+
+func (s *debugEndpointMockStore) GetStateAt(block *types.Block, txIndex int) (*itrie.State, types.Hash, error) {
+	return s.getStateAtFn(block, txIndex)
+}
+
+func (s *debugEndpointMockStore) SetHead(number uint64) error {
+	return s.setHeadFn(number)
+}
+
+func (s *debugEndpointMockStore) GetBadBlocks() ([]*storage.BadBlock, error) {
+	return s.getBadBlocksFn()
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -171,6 +209,16 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				DisableStructLogs: true,
 			},
 		},
+		{
+			input: `{
+				"tracer": "prestateTracer",
+				"tracerConfig": {"diffMode": true}
+			}`,
+			expected: TraceConfig{
+				Tracer:       "prestateTracer",
+				TracerConfig: json.RawMessage(`{"diffMode": true}`),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -590,6 +638,10 @@ func TestTraceCall(t *testing.T) {
 		input     = argBytes([]byte("input"))
 		nonce     = argUint64(1)
 
+		overrideBalance  = argUint64(100)
+		overrideNumber   = argUint64(20)
+		overrideGasLimit = argUint64(50000)
+
 		blockNumber = BlockNumber(testBlock10.Number())
 
 		txArg = &txnArgs{
@@ -623,7 +675,7 @@ func TestTraceCall(t *testing.T) {
 		name   string
 		arg    *txnArgs
 		filter BlockNumberOrHash
-		config *TraceConfig
+		config *TraceCallConfig
 		store  *debugEndpointMockStore
 		result interface{}
 		err    bool
@@ -634,16 +686,78 @@ func TestTraceCall(t *testing.T) {
 			filter: BlockNumberOrHash{
 				BlockNumber: &blockNumber,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
 					assert.Equal(t, testBlock10.Number(), num)
 
 					return testHeader10, true
 				},
-				traceCallFn: func(tx *types.Transaction, header *types.Header, tracer tracer.Tracer) (interface{}, error) {
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					blockOverride *types.BlockOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
 					assert.Equal(t, decodedTx, tx)
 					assert.Equal(t, testHeader10, header)
+					assert.Nil(t, override)
+					assert.Nil(t, blockOverride)
+
+					return testTraceResult, nil
+				},
+				headerFn: func() *types.Header {
+					return testLatestHeader
+				},
+				getAccountFn: func(h types.Hash, a types.Address) (*Account, error) {
+					return &Account{Nonce: 1}, nil
+				},
+			},
+			result: testTraceResult,
+			err:    false,
+		},
+		{
+			name: "should pass the state and block overrides",
+			arg: &txnArgs{
+				From:  &from,
+				To:    &to,
+				Nonce: &nonce,
+			},
+			filter: BlockNumberOrHash{
+				BlockNumber: &blockNumber,
+			},
+			config: &TraceCallConfig{
+				StateOverrides: &stateOverride{
+					to: overrideAccount{Balance: &overrideBalance},
+				},
+				BlockOverrides: &blockOverride{
+					Number:   &overrideNumber,
+					GasLimit: &overrideGasLimit,
+					Coinbase: &to,
+				},
+			},
+			store: &debugEndpointMockStore{
+				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+					return testHeader10, true
+				},
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					blockOverride *types.BlockOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
+					assert.Equal(t, uint64(overrideGasLimit), tx.Gas)
+					assert.Equal(t, testHeader10, header)
+					assert.Equal(t, types.StateOverride{
+						to: types.OverrideAccount{Balance: new(big.Int).SetUint64(uint64(overrideBalance))},
+					}, override)
+					assert.Equal(t, &types.BlockOverride{
+						Number:   (*uint64)(&overrideNumber),
+						GasLimit: (*uint64)(&overrideGasLimit),
+						Coinbase: &to,
+					}, blockOverride)
 
 					return testTraceResult, nil
 				},
@@ -657,13 +771,22 @@ func TestTraceCall(t *testing.T) {
 			result: testTraceResult,
 			err:    false,
 		},
+		{
+			name:   "should return error if config is missing",
+			arg:    txArg,
+			filter: BlockNumberOrHash{},
+			config: nil,
+			store:  &debugEndpointMockStore{},
+			result: nil,
+			err:    true,
+		},
 		{
 			name: "should return error if block not found",
 			arg:  txArg,
 			filter: BlockNumberOrHash{
 				BlockHash: &testHeader10.Hash,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
 					assert.Equal(t, testHeader10.Hash, hash)
@@ -691,7 +814,7 @@ func TestTraceCall(t *testing.T) {
 				Nonce:    &nonce,
 			},
 			filter: BlockNumberOrHash{},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				headerFn: func() *types.Header {
 					return testLatestHeader
@@ -833,4 +956,334 @@ func Test_newTracer(t *testing.T) {
 			EnableStructLogs: false,
 		}, st.Config)
 	})
+
+	t.Run("should create prestate tracer with tracer config", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       prestateTracerName,
+			TracerConfig: json.RawMessage(`{"diffMode": true}`),
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, &prestatetracer.PrestateTracer{}, tracer)
+
+		res, err := tracer.GetResult()
+		assert.NoError(t, err)
+		assert.IsType(t, &prestatetracer.DiffResult{}, res)
+	})
+
+	t.Run("should return error if tracer config is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       prestateTracerName,
+			TracerConfig: json.RawMessage(`{"diffMode": 1}`),
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.Error(t, err)
+	})
+}
+
+func TestDebugRawData(t *testing.T) {
+	t.Parallel()
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Gas:      21000,
+		To:       &addr1,
+		Value:    big.NewInt(100),
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+	}
+	tx.ComputeHash(1)
+
+	block := &types.Block{
+		Header:       &types.Header{Number: 10, ExtraData: []byte{}},
+		Transactions: []*types.Transaction{tx},
+	}
+	block.Header.ComputeHash()
+
+	status := types.ReceiptSuccess
+	receipts := []*types.Receipt{
+		{Status: &status, CumulativeGasUsed: 21000, TxHash: tx.Hash},
+		{Status: &status, CumulativeGasUsed: 42000, TransactionType: types.DynamicFeeTx},
+	}
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return block.Header
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			return block, hash == block.Hash()
+		},
+		readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+			return block.Hash(), hash == tx.Hash
+		},
+		getReceiptsFn: func(hash types.Hash) ([]*types.Receipt, error) {
+			assert.Equal(t, block.Hash(), hash)
+
+			return receipts, nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+	filter := BlockNumberOrHash{BlockHash: &block.Header.Hash}
+
+	res, err := endpoint.GetRawHeader(filter)
+	require.NoError(t, err)
+	assert.Equal(t, argBytes(block.Header.MarshalRLP()), res)
+
+	res, err = endpoint.GetRawBlock(BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	decodedBlock := &types.Block{}
+	require.NoError(t, decodedBlock.UnmarshalRLP(res.(argBytes)))
+	assert.Equal(t, block.Hash(), decodedBlock.Hash())
+	require.Len(t, decodedBlock.Transactions, 1)
+
+	res, err = endpoint.GetRawReceipts(filter)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, argBytes(receipts[0].MarshalRLP()), res.([]argBytes)[0])
+	assert.Equal(t, byte(types.DynamicFeeTx), res.([]argBytes)[1][0])
+
+	res, err = endpoint.GetRawTransaction(tx.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, argBytes(tx.MarshalRLP()), res)
+
+	res, err = endpoint.GetRawTransaction(types.StringToHash("1"))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDebugStateDump(t *testing.T) {
+	t.Parallel()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	objs := make([]*state.Object, 0, 10)
+
+	for i := 0; i < 10; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i == 0 {
+			obj.Code = []byte{0x60, 0x00}
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(obj.Code))
+			obj.DirtyCode = true
+
+			for j := 0; j < 5; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(j + 1)}).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, rootBytes, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+	block := &types.Block{Header: &types.Header{Number: 10, StateRoot: root}}
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return block.Header
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			return block, true
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			return block, num == 10
+		},
+		getStateAtFn: func(b *types.Block, txIndex int) (*itrie.State, types.Hash, error) {
+			assert.Equal(t, block, b)
+
+			return st, root, nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+	contractKey := types.BytesToHash(crypto.Keccak256(objs[0].Address.Bytes()))
+
+	res, err := endpoint.DumpBlock(LatestBlockNumber)
+	require.NoError(t, err)
+
+	dump, ok := res.(*stateDump)
+	require.True(t, ok)
+	assert.Equal(t, root, dump.Root)
+	assert.Len(t, dump.Accounts, 10)
+	assert.Nil(t, dump.Next)
+
+	contract := dump.Accounts[contractKey]
+	require.NotNil(t, contract)
+	assert.Equal(t, argBytes(objs[0].Code), contract.Code)
+	assert.Len(t, contract.Storage, 5)
+
+	// the accounts are paged by their keys
+	res, err = endpoint.AccountRange(BlockNumberOrHash{}, nil, 4, true, true, false)
+	require.NoError(t, err)
+
+	page, ok := res.(*stateDump)
+	require.True(t, ok)
+	require.Len(t, page.Accounts, 4)
+	require.NotNil(t, page.Next)
+
+	for _, account := range page.Accounts {
+		assert.Empty(t, account.Code)
+		assert.Empty(t, account.Storage)
+	}
+
+	res, err = endpoint.AccountRange(BlockNumberOrHash{}, page.Next.Bytes(), 0, true, true, false)
+	require.NoError(t, err)
+
+	rest, ok := res.(*stateDump)
+	require.True(t, ok)
+	assert.Len(t, rest.Accounts, 6)
+	assert.Nil(t, rest.Next)
+
+	// the storage is paged by the slot hashes
+	res, err = endpoint.StorageRangeAt(block.Hash(), 0, objs[0].Address, nil, 3)
+	require.NoError(t, err)
+
+	storage, ok := res.(*storageRangeResult)
+	require.True(t, ok)
+	assert.Len(t, storage.Storage, 3)
+	require.NotNil(t, storage.NextKey)
+
+	res, err = endpoint.StorageRangeAt(block.Hash(), 0, objs[0].Address, storage.NextKey.Bytes(), 3)
+	require.NoError(t, err)
+
+	storage, ok = res.(*storageRangeResult)
+	require.True(t, ok)
+	assert.Len(t, storage.Storage, 2)
+	assert.Nil(t, storage.NextKey)
+
+	slotKey := types.BytesToHash(crypto.Keccak256(types.BytesToHash([]byte{4}).Bytes()))
+	if entry, ok := storage.Storage[slotKey]; ok {
+		assert.Equal(t, types.BytesToHash([]byte{5}), entry.Value)
+	}
+
+	// the maximum number of the slots is clamped
+	res, err = endpoint.StorageRangeAt(block.Hash(), 0, objs[0].Address, nil, 0)
+	require.NoError(t, err)
+
+	storage, ok = res.(*storageRangeResult)
+	require.True(t, ok)
+	assert.Len(t, storage.Storage, 5)
+	assert.Nil(t, storage.NextKey)
+
+	// the missing account has no storage
+	res, err = endpoint.StorageRangeAt(block.Hash(), 0, types.StringToAddress("ff"), nil, 3)
+	require.NoError(t, err)
+	assert.Empty(t, res.(*storageRangeResult).Storage)
+
+	// the whole dump is served as the admin namespace, not on HTTP beyond the loopback address
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "debug_dumpBlock", "params": ["latest"]}`))
+	require.NoError(t, err)
+
+	var rpcErr *ObjectError
+	require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestDebugSetHead(t *testing.T) {
+	t.Parallel()
+
+	head := uint64(10)
+
+	store := &debugEndpointMockStore{
+		setHeadFn: func(number uint64) error {
+			if number > head {
+				return fmt.Errorf("block %d is above the head %d", number, head)
+			}
+
+			head = number
+
+			return nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+
+	res, err := endpoint.SetHead(5)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+	assert.Equal(t, uint64(5), head)
+
+	_, err = endpoint.SetHead(6)
+	require.ErrorContains(t, err, "above the head")
+
+	// the method is served as the admin one, not on HTTP beyond the loopback address
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "debug_setHead", "params": ["0x1"]}`))
+	require.NoError(t, err)
+
+	var rpcErr *ObjectError
+	require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestDebugBadBlocks(t *testing.T) {
+	t.Parallel()
+
+	store := &debugEndpointMockStore{
+		getBadBlocksFn: func() ([]*storage.BadBlock, error) {
+			return []*storage.BadBlock{
+				{
+					Block:  testBlock10,
+					Reason: "invalid block state root",
+					Source: "peer",
+					Time:   100,
+				},
+			}, nil
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			assert.Equal(t, testBlock10, block)
+
+			return testTraceResults, nil
+		},
+	}
+
+	endpoint := NewDebug(store, 100000)
+
+	res, err := endpoint.GetBadBlocks()
+	require.NoError(t, err)
+
+	badBlocks, ok := res.([]*badBlock)
+	require.True(t, ok)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, testBlock10.Hash(), badBlocks[0].Hash)
+	assert.Equal(t, testBlock10.Hash(), badBlocks[0].Block.Hash)
+	assert.Equal(t, argBytes(testBlock10.MarshalRLP()), badBlocks[0].RLP)
+	assert.Equal(t, "invalid block state root", badBlocks[0].Reason)
+	assert.Equal(t, "peer", badBlocks[0].Source)
+	assert.Equal(t, argUint64(100), badBlocks[0].Timestamp)
+
+	res, err = endpoint.TraceBadBlock(testBlock10.Hash(), &TraceConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, testTraceResults, res)
+
+	_, err = endpoint.TraceBadBlock(testHash11, &TraceConfig{})
+	require.ErrorContains(t, err, "not found")
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 )
@@ -33,21 +34,28 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Bridge *Bridge
-	Debug  *Debug
+	Eth      *Eth
+	Web3     *Web3
+	Net      *Net
+	TxPool   *TxPool
+	Bridge   *Bridge
+	Debug    *Debug
+	Trace    *Trace
+	Ots      *Ots
+	Admin    *Admin
+	Personal *Personal
+	RPC      *RPC
 }
 
 // Dispatcher handles all json rpc requests by delegating
 // the execution flow to the corresponding service
 type Dispatcher struct {
 	logger        hclog.Logger
+	store         JSONRPCStore
 	serviceMap    map[string]*serviceData
 	filterManager *FilterManager
 	endpoints     endpoints
+	responseCache *responseCache
 
 	params *dispatcherParams
 }
@@ -61,6 +69,23 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	httpPolicy *MethodPolicy
+	wsPolicy   *MethodPolicy
+
+	// slowRequestThreshold is the duration of the requests which are logged, zero disables the logging
+	slowRequestThreshold time.Duration
+
+	// responseCacheSize is the maximum size in bytes of the cached results, zero disables the cache
+	responseCacheSize uint64
+
+	// enableAdmin registers the admin namespace, which is served on IPC, and also on HTTP
+	// and WS if localOnLoopback is set, as their server listens on the loopback address
+	enableAdmin     bool
+	localOnLoopback bool
+
+	// signer enables the signing methods and the personal namespace, which is served as the admin namespace
+	signer *LocalSigner
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -73,13 +98,19 @@ func newDispatcher(
 	params *dispatcherParams,
 ) (*Dispatcher, error) {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:        logger.Named("dispatcher"),
+		store:         store,
+		params:        params,
+		responseCache: newResponseCache(params.responseCacheSize),
 	}
 
 	if store != nil {
 		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
 		go d.filterManager.Run()
+
+		if d.responseCache != nil {
+			go d.responseCache.run(store.SubscribeEvents())
+		}
 	}
 
 	if err := d.registerEndpoints(store); err != nil {
@@ -96,6 +127,7 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		d.params.signer,
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -111,6 +143,9 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		store,
 	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
+	d.endpoints.Trace = NewTrace(store, d.params.concurrentRequestsDebug, d.params.blockRangeLimit)
+	d.endpoints.Ots = NewOts(store, d.params.concurrentRequestsDebug)
+	d.endpoints.RPC = &RPC{}
 
 	var err error
 
@@ -134,10 +169,56 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	if err = d.registerService("trace", d.endpoints.Trace); err != nil {
+		return err
+	}
+
+	if err = d.registerService("ots", d.endpoints.Ots); err != nil {
+		return err
+	}
+
+	if d.params.enableAdmin {
+		adminStore, ok := store.(adminStore)
+		if !ok {
+			return errors.New("jsonrpc: the store doesn't support the admin namespace")
+		}
+
+		d.endpoints.Admin = &Admin{
+			adminStore,
+			d.params.chainID,
+			d.params.chainName,
+		}
+
+		if err = d.registerService("admin", d.endpoints.Admin); err != nil {
+			return err
+		}
+	}
+
+	if d.params.signer != nil {
+		d.endpoints.Personal = &Personal{
+			d.params.signer,
+		}
+
+		if err = d.registerService("personal", d.endpoints.Personal); err != nil {
+			return err
+		}
+	}
+
+	if err = d.registerService("rpc", d.endpoints.RPC); err != nil {
+		return err
+	}
+
+	// the document describes all the registered methods, including rpc_discover itself
+	d.endpoints.RPC.document = d.generateOpenRPCDocument()
+
+	return nil
 }
 
-func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
+func (d *Dispatcher) getFnHandler(req Request, transport serverType) (*serviceData, *funcData, Error) {
 	callName := strings.SplitN(req.Method, "_", 2)
 	if len(callName) != 2 {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -156,9 +237,106 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 		return nil, nil, NewMethodNotFoundError(req.Method)
 	}
 
+	if err := d.checkPolicy(req.Method, transport); err != nil {
+		return nil, nil, err
+	}
+
 	return service, fd, nil
 }
 
+// checkPolicy returns an error if the method is not allowed on the transport
+func (d *Dispatcher) checkPolicy(method string, transport serverType) Error {
+	var policy *MethodPolicy
+
+	switch transport {
+	case serverHTTP:
+		policy = d.params.httpPolicy
+	case serverWS:
+		policy = d.params.wsPolicy
+	}
+
+	if !policy.isAllowed(method) {
+		return NewMethodNotAllowedError(method)
+	}
+
+	// the local methods manage the node and its keys, or they are too heavy,
+	// so they are never exposed beyond the local machine
+	if transport != serverIPC && !d.params.localOnLoopback && d.isLocalMethod(method) {
+		return NewMethodNotAllowedError(method)
+	}
+
+	return nil
+}
+
+// isLocalMethod returns true if the method is served only on the local machine,
+// the signing methods are too if they are signed by the local accounts
+func (d *Dispatcher) isLocalMethod(method string) bool {
+	switch method {
+	case "debug_setHead", "debug_dumpBlock":
+		return true
+	case "eth_sendTransaction", "eth_signTransaction", "eth_sign", "eth_signTypedData_v4":
+		return d.params.signer != nil
+	}
+
+	return strings.HasPrefix(method, "admin_") || strings.HasPrefix(method, "personal_")
+}
+
+// checkBlockRange returns an error if the block range of the request exceeds the limit of the key,
+// the malformed params are not checked as they are rejected by the endpoint
+func (d *Dispatcher) checkBlockRange(req Request, key *authKey) Error {
+	if key.BlockRangeLimit == 0 || d.store == nil {
+		return nil
+	}
+
+	defaultFrom := ""
+
+	switch req.Method {
+	case "eth_getLogs", "eth_newFilter":
+	case "trace_filter":
+		defaultFrom = earliest
+	default:
+		return nil
+	}
+
+	var params []struct {
+		BlockHash *types.Hash `json:"blockHash"`
+		FromBlock string      `json:"fromBlock"`
+		ToBlock   string      `json:"toBlock"`
+	}
+
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 || params[0].BlockHash != nil {
+		return nil
+	}
+
+	if params[0].FromBlock == "" {
+		params[0].FromBlock = defaultFrom
+	}
+
+	resolve := func(str string) (uint64, bool) {
+		number, err := stringToBlockNumberSafe(str)
+		if err != nil {
+			return 0, false
+		}
+
+		num, err := GetNumericBlockNumber(number, d.store)
+
+		return num, err == nil
+	}
+
+	from, fromOk := resolve(params[0].FromBlock)
+	to, toOk := resolve(params[0].ToBlock)
+
+	if !fromOk || !toOk || to <= from || to-from <= key.BlockRangeLimit {
+		return nil
+	}
+
+	key.reject("block_range")
+
+	return NewLimitExceededError(
+		fmt.Sprintf("block range of %d blocks exceeds the limit of %d", to-from, key.BlockRangeLimit),
+	)
+}
+
 type wsConn interface {
 	WriteMessage(messageType int, data []byte) error
 	GetFilterID() string
@@ -240,6 +418,28 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 }
 
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	return d.handleWsWithKey(reqBody, conn, nil)
+}
+
+// handleWsWithKey handles the requests of the WS connection authenticated by the key,
+// the quotas of the key are applied to each request
+func (d *Dispatcher) handleWsWithKey(reqBody []byte, conn wsConn, key *authKey) ([]byte, error) {
+	return d.handleConn(reqBody, conn, serverWS, key)
+}
+
+// HandleIPC handles the requests of the IPC connection the same way as the WS ones,
+// the method policies are not applied to the local connections
+func (d *Dispatcher) HandleIPC(reqBody []byte, conn wsConn) ([]byte, error) {
+	return d.handleConn(reqBody, conn, serverIPC, nil)
+}
+
+// handleConn handles the requests of the connections which support the subscriptions
+func (d *Dispatcher) handleConn(
+	reqBody []byte,
+	conn wsConn,
+	transport serverType,
+	key *authKey,
+) ([]byte, error) {
 	const (
 		openSquareBracket  byte = '['
 		closeSquareBracket byte = ']'
@@ -271,7 +471,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		responses := make([][]byte, len(batchReq))
 
 		for i, req := range batchReq {
-			responses[i], err = d.handleSingleWs(req, conn).Bytes()
+			responses[i], err = d.handleSingleWs(req, conn, transport, key).Bytes()
 			if err != nil {
 				return nil, err
 			}
@@ -293,10 +493,10 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	return d.handleSingleWs(req, conn).Bytes()
+	return d.handleSingleWs(req, conn, transport, key).Bytes()
 }
 
-func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
+func (d *Dispatcher) handleSingleWs(req Request, conn wsConn, transport serverType, key *authKey) Response {
 	id, err := formatID(req.ID)
 	if err != nil {
 		return NewRPCResponse(nil, "2.0", nil, err)
@@ -304,29 +504,64 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 
 	var response []byte
 
-	switch req.Method {
-	case "eth_subscribe":
-		var filterID string
+	if req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe" {
+		start := time.Now()
+		response, err = d.handleSubscriptionReq(req, conn, transport, key)
+		d.observeRequest(req, transport, time.Since(start), response, err)
+	} else {
+		// its a normal query that we handle with the dispatcher
+		response, err = d.handleReq(req, transport, key)
+	}
 
-		// if the request method is eth_subscribe we need to create a new filter with ws connection
-		if filterID, err = d.handleSubscribe(req, conn); err == nil {
-			response = []byte(fmt.Sprintf("\"%s\"", filterID))
+	return NewRPCResponse(id, "2.0", response, err)
+}
+
+// handleSubscriptionReq handles eth_subscribe and eth_unsubscribe, the subscriptions
+// are not handled by the endpoints, so their policy and quotas are checked here
+func (d *Dispatcher) handleSubscriptionReq(
+	req Request,
+	conn wsConn,
+	transport serverType,
+	key *authKey,
+) ([]byte, Error) {
+	if err := d.checkPolicy(req.Method, transport); err != nil {
+		return nil, err
+	}
+
+	if key != nil {
+		release, err := key.acquire()
+		if err != nil {
+			return nil, err
 		}
-	case "eth_unsubscribe":
-		var ok bool
 
-		if ok, err = d.handleUnsubscribe(req); err == nil {
-			response = []byte(strconv.FormatBool(ok))
+		defer release()
+	}
+
+	if req.Method == "eth_subscribe" {
+		// if the request method is eth_subscribe we need to create a new filter with ws connection
+		filterID, err := d.handleSubscribe(req, conn)
+		if err != nil {
+			return nil, err
 		}
-	default:
-		// its a normal query that we handle with the dispatcher
-		response, err = d.handleReq(req)
+
+		return []byte(fmt.Sprintf("\"%s\"", filterID)), nil
 	}
 
-	return NewRPCResponse(id, "2.0", response, err)
+	ok, err := d.handleUnsubscribe(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.FormatBool(ok)), nil
 }
 
 func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return d.handleWithKey(reqBody, nil)
+}
+
+// handleWithKey handles the HTTP request authenticated by the key,
+// the quotas of the key are applied to each request of the batch
+func (d *Dispatcher) handleWithKey(reqBody []byte, key *authKey) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -342,7 +577,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleReq(req, serverHTTP, key)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -371,7 +606,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleReq(req, serverHTTP, key)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -391,14 +626,36 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+// handleReq handles the request and records its metrics
+func (d *Dispatcher) handleReq(req Request, transport serverType, key *authKey) ([]byte, Error) {
+	start := time.Now()
+	data, err := d.dispatchReq(req, transport, key)
+	d.observeRequest(req, transport, time.Since(start), data, err)
+
+	return data, err
+}
+
+func (d *Dispatcher) dispatchReq(req Request, transport serverType, key *authKey) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
-	service, fd, ferr := d.getFnHandler(req)
+	service, fd, ferr := d.getFnHandler(req, transport)
 	if ferr != nil {
 		return nil, ferr
 	}
 
+	if key != nil {
+		release, err := key.acquire()
+		if err != nil {
+			return nil, err
+		}
+
+		defer release()
+
+		if err := d.checkBlockRange(req, key); err != nil {
+			return nil, err
+		}
+	}
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
@@ -416,6 +673,22 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		}
 	}
 
+	var cacheGeneration uint64
+
+	cacheKey, cacheable := d.responseCacheKey(req)
+	if cacheable {
+		data, generation, ok := d.responseCache.get(cacheKey)
+		if ok {
+			observeCacheLookup(req.Method, true)
+
+			return data, nil
+		}
+
+		observeCacheLookup(req.Method, false)
+
+		cacheGeneration = generation
+	}
+
 	var (
 		data []byte
 		err  error
@@ -452,9 +725,28 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		}
 	}
 
+	if cacheable {
+		d.responseCache.add(cacheKey, data, cacheGeneration)
+	}
+
 	return data, nil
 }
 
+// responseCacheKey returns the key of the request
+// if the cache is enabled and the result of the request can be cached
+func (d *Dispatcher) responseCacheKey(req Request) (string, bool) {
+	if d.responseCache == nil || d.store == nil {
+		return "", false
+	}
+
+	head := d.store.Header()
+	if head == nil {
+		return "", false
+	}
+
+	return d.responseCache.cacheKey(req, head.Number)
+}
+
 func (d *Dispatcher) logInternalError(method string, err error) {
 	d.logger.Warn("failed to dispatch", "method", method, "err", err)
 }
@@ -30,7 +30,7 @@ func FuzzDispatcherFuncDecode(f *testing.F) {
 		_, err := dispatcher.handleReq(Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
-		})
+		}, serverHTTP, nil)
 		if err != nil {
 			return err
 		}
@@ -258,7 +258,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 		_, err := dispatcher.handleReq(Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
-		})
+		}, serverHTTP, nil)
 		assert.NoError(t, err)
 
 		return <-srv.msgCh
@@ -557,6 +557,81 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	assert.Equal(t, "true", string(resp.Result))
 }
 
+func TestDispatcher_MethodPolicy(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			httpPolicy:              &MethodPolicy{Deny: []string{"eth_blockNumber"}},
+			wsPolicy:                &MethodPolicy{Allow: []string{"net"}},
+		},
+	)
+	mockConn := &mockWsConn{
+		SetFilterIDFn:  func(s string) {},
+		GetFilterIDFn:  func() string { return "" },
+		WriteMessageFn: func(i int, b []byte) error { return nil },
+	}
+
+	request := func(method string) []byte {
+		return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, method))
+	}
+
+	checkError := func(res []byte, expected Error) {
+		t.Helper()
+
+		resp := &ErrorResponse{}
+		require.NoError(t, json.Unmarshal(res, resp))
+
+		if expected == nil {
+			assert.Nil(t, resp.Error)
+
+			return
+		}
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, expected.ErrorCode(), resp.Error.Code)
+		assert.Equal(t, expected.Error(), resp.Error.Message)
+	}
+
+	// HTTP
+	res, err := dispatcher.Handle(request("eth_blockNumber"))
+	require.NoError(t, err)
+	checkError(res, NewMethodNotAllowedError("eth_blockNumber"))
+
+	res, err = dispatcher.Handle(request("net_version"))
+	require.NoError(t, err)
+	checkError(res, nil)
+
+	// the unknown methods are not found
+	res, err = dispatcher.Handle(request("eth_unknown"))
+	require.NoError(t, err)
+	checkError(res, NewMethodNotFoundError("eth_unknown"))
+
+	// WS
+	res, err = dispatcher.HandleWs(request("eth_blockNumber"), mockConn)
+	require.NoError(t, err)
+	checkError(res, NewMethodNotAllowedError("eth_blockNumber"))
+
+	res, err = dispatcher.HandleWs(
+		[]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`), mockConn)
+	require.NoError(t, err)
+	checkError(res, NewMethodNotAllowedError("eth_subscribe"))
+
+	res, err = dispatcher.HandleWs(request("net_version"), mockConn)
+	require.NoError(t, err)
+	checkError(res, nil)
+
+	// IPC is not restricted
+	res, err = dispatcher.HandleIPC(request("eth_blockNumber"), mockConn)
+	require.NoError(t, err)
+	checkError(res, nil)
+}
+
 func newTestDispatcher(tb testing.TB, logger hclog.Logger, store JSONRPCStore, params *dispatcherParams) *Dispatcher {
 	tb.Helper()
 
@@ -76,9 +76,48 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32601
+}
+
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32001
+}
+
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
 func NewInvalidRequestError(msg string) *invalidRequestError {
 	return &invalidRequestError{msg}
 }
@@ -90,6 +129,14 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewUnauthorizedError(msg string) *unauthorizedError {
+	return &unauthorizedError{msg}
+}
+
+func NewLimitExceededError(msg string) *limitExceededError {
+	return &limitExceededError{msg}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
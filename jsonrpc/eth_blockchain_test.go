@@ -10,9 +10,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/transfertracer"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -53,6 +55,33 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_FinalizedAndSafe(t *testing.T) {
+	store := &mockBlockStore{}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	// with instant finality both tags resolve to the head
+	for _, blockNum := range []BlockNumber{FinalizedBlockNumber, SafeBlockNumber} {
+		res, err := eth.GetBlockByNumber(blockNum, false)
+		assert.NoError(t, err)
+		assert.Equal(t, argUint64(9), res.(*block).Number) //nolint:forcetypeassert
+	}
+
+	// the finalized block may lag behind the head
+	store.finalized = store.blocks[5].Header
+
+	res, err := eth.GetBlockByNumber(FinalizedBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(5), res.(*block).Number) //nolint:forcetypeassert
+
+	res, err = eth.GetBlockByNumber(SafeBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(9), res.(*block).Number) //nolint:forcetypeassert
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -246,6 +275,103 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from  = types.StringToAddress("1")
+		to    = types.StringToAddress("2")
+		value = big.NewInt(100)
+	)
+
+	store := newMockBlockStore()
+	block := newTestBlock(1, hash4)
+	store.add(block)
+
+	txn0 := newTestTransaction(uint64(0), addr0)
+	txn1 := newTestTransaction(uint64(1), addr1)
+	block.Transactions = []*types.Transaction{txn0, txn1}
+
+	erc20Log := &types.Log{
+		Address: addr1,
+		Topics: []types.Hash{
+			transfertracer.TransferEventTopic,
+			types.BytesToHash(from.Bytes()),
+			types.BytesToHash(to.Bytes()),
+		},
+		Data: types.BytesToHash(value.Bytes()).Bytes(),
+	}
+
+	receipt0 := &types.Receipt{
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash1}},
+			{Topics: []types.Hash{hash2}},
+		},
+	}
+	receipt0.SetStatus(types.ReceiptSuccess)
+
+	receipt1 := &types.Receipt{
+		Logs: []*types.Log{erc20Log},
+	}
+	receipt1.SetStatus(types.ReceiptFailed)
+
+	store.receipts[hash4] = []*types.Receipt{receipt0, receipt1}
+
+	eth := newTestEthEndpoint(store)
+	number := BlockNumber(1)
+
+	t.Run("returns all the receipts of the block", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockNumber: &number}, nil)
+		require.NoError(t, err)
+
+		receipts, ok := res.([]*receipt)
+		require.True(t, ok)
+		require.Len(t, receipts, 2)
+
+		assert.Equal(t, txn0.Hash, receipts[0].TxHash)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), receipts[0].Status)
+		assert.Len(t, receipts[0].Logs, 2)
+
+		assert.Equal(t, txn1.Hash, receipts[1].TxHash)
+		assert.Equal(t, argUint64(1), receipts[1].TxIndex)
+		assert.Equal(t, argUint64(types.ReceiptFailed), receipts[1].Status)
+		require.Len(t, receipts[1].Logs, 1)
+		assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+		assert.Nil(t, receipts[1].Logs[0].Transfer)
+	})
+
+	t.Run("decodes the transfer logs if requested", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(
+			BlockNumberOrHash{BlockHash: &hash4},
+			&blockReceiptsOpts{DecodeTransfers: true},
+		)
+		require.NoError(t, err)
+
+		receipts, ok := res.([]*receipt)
+		require.True(t, ok)
+		require.Len(t, receipts, 2)
+
+		assert.Nil(t, receipts[0].Logs[0].Transfer)
+		assert.Equal(t, &transferLog{
+			From:  from,
+			To:    to,
+			Value: argBig(*value),
+		}, receipts[1].Logs[0].Transfer)
+	})
+
+	t.Run("returns error if the block is not found", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash3}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -382,7 +508,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -406,7 +532,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -432,7 +558,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 		bres := res.([]byte) //nolint:forcetypeassert
@@ -456,8 +582,11 @@ type mockBlockStore struct {
 	returnValue     []byte
 	forksInTime     chain.ForksInTime
 	baseFee         uint64
+	finalized       *types.Header
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
+	simulateBlocksFn       func([]*SimulationBlock, bool, bool) ([]*SimulatedBlock, error)
+	logCandidatesFn        func(uint64, uint64, [][][]byte) ([]uint64, uint64, error)
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -575,6 +704,15 @@ func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	block, ok := m.GetBlockByNumber(blockNumber, false)
+	if !ok {
+		return nil, false
+	}
+
+	return block.Header, true
+}
+
 func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Hash() == hash {
@@ -589,6 +727,14 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
+func (m *mockBlockStore) FinalizedHeader() (*types.Header, error) {
+	if m.finalized != nil {
+		return m.finalized, nil
+	}
+
+	return m.Header(), nil
+}
+
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
 		for _, txn := range block.Transactions {
@@ -628,13 +774,22 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types.StateOverride, _ *types.BlockOverride, _ bool) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{
 		Err:         m.ethCallError,
 		ReturnValue: m.returnValue,
 	}, nil
 }
 
+func (m *mockBlockStore) SimulateBlocks(
+	_ *types.Header,
+	blocks []*SimulationBlock,
+	validation bool,
+	traceTransfers bool,
+) ([]*SimulatedBlock, error) {
+	return m.simulateBlocksFn(blocks, validation, traceTransfers)
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
@@ -647,6 +802,14 @@ func (m *mockBlockStore) TxPoolSubscribe(request *proto.SubscribeRequest) (<-cha
 	return nil, nil, nil
 }
 
+func (m *mockBlockStore) GetLogCandidates(from, to uint64, filters [][][]byte) ([]uint64, uint64, error) {
+	if m.logCandidatesFn != nil {
+		return m.logCandidatesFn(from, to, filters)
+	}
+
+	return nil, from, nil
+}
+
 func (m *mockBlockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	return &Account{Nonce: 0}, nil
 }
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,11 +10,16 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/accesslisttracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -41,12 +47,16 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, storageKeys []types.Hash) (*itrie.AccountProof, error)
 }
 
 type ethBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the last finalized block
+	FinalizedHeader() (*types.Header, error)
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
@@ -70,9 +80,26 @@ type ethBlockchainStore interface {
 		header *types.Header,
 		txn *types.Transaction,
 		override types.StateOverride,
+		blockOverride *types.BlockOverride,
 		nonPayable bool,
 	) (*runtime.ExecutionResult, error)
 
+	// ApplyTxnWithTracer applies a transaction object to the blockchain while tracing its execution
+	ApplyTxnWithTracer(
+		header *types.Header,
+		txn *types.Transaction,
+		tracer tracer.Tracer,
+	) (*runtime.ExecutionResult, error)
+
+	// SimulateBlocks applies the calls of the given blocks on top of the state of the parent header,
+	// so that every call sees the state changes of the previous ones
+	SimulateBlocks(
+		parent *types.Header,
+		blocks []*SimulationBlock,
+		validation bool,
+		traceTransfers bool,
+	) ([]*SimulatedBlock, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -98,6 +125,9 @@ type Eth struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+
+	// signer signs the transactions and the messages of the local accounts, nil if it's not enabled
+	signer *LocalSigner
 }
 
 var (
@@ -216,10 +246,170 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
-// SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
-func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
-	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
-		" use eth_sendRawTransaction instead")
+// Accounts returns the addresses of the local accounts, if the local signer is enabled
+func (e *Eth) Accounts() (interface{}, error) {
+	if e.signer == nil {
+		return []types.Address{}, nil
+	}
+
+	return e.signer.Accounts(), nil
+}
+
+// SendTransaction signs the transaction by the unlocked local account and sends it,
+// the nonce, the gas and the fees are filled if they are not provided
+func (e *Eth) SendTransaction(arg *txnArgs) (interface{}, error) {
+	if e.signer == nil {
+		return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
+			" use eth_sendRawTransaction instead")
+	}
+
+	tx, err := e.signTransaction(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.store.AddTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SignTransaction signs the transaction by the unlocked local account without sending it,
+// the nonce, the gas and the fees are filled if they are not provided
+func (e *Eth) SignTransaction(arg *txnArgs) (interface{}, error) {
+	if e.signer == nil {
+		return nil, errNoLocalSigner
+	}
+
+	tx, err := e.signTransaction(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{
+		Raw: tx.MarshalRLP(),
+		Tx:  toPendingTransaction(tx),
+	}, nil
+}
+
+// Sign signs the message by the unlocked local account, the message is prefixed
+// as defined by EIP-191, so the signature can't be used to sign a transaction
+func (e *Eth) Sign(address types.Address, data argBytes) (interface{}, error) {
+	if e.signer == nil {
+		return nil, errNoLocalSigner
+	}
+
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data))
+
+	sig, err := e.signer.SignHash(address, crypto.Keccak256([]byte(prefix), data))
+	if err != nil {
+		return nil, err
+	}
+
+	return argBytes(sig), nil
+}
+
+// SignTypedData_v4 signs the EIP-712 typed data by the unlocked local account
+//
+//nolint:stylecheck
+func (e *Eth) SignTypedData_v4(address types.Address, data json.RawMessage) (interface{}, error) {
+	if e.signer == nil {
+		return nil, errNoLocalSigner
+	}
+
+	typed, err := decodeTypedData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := typed.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := e.signer.SignHash(address, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return argBytes(sig), nil
+}
+
+// signTransaction fills the missing fields of the transaction and signs it by the local signer
+func (e *Eth) signTransaction(arg *txnArgs) (*types.Transaction, error) {
+	if arg == nil || arg.From == nil {
+		return nil, errors.New("the sender of the transaction is not set")
+	}
+
+	header := e.store.Header()
+	forks := e.store.GetForksInTime(header.Number)
+
+	// the nonce follows the pending transactions of the account
+	if arg.Nonce == nil {
+		arg.Nonce = argUintPtr(e.store.GetNonce(*arg.From))
+	}
+
+	if arg.Type == nil {
+		txType := types.LegacyTx
+		if forks.London && arg.GasPrice == nil {
+			txType = types.DynamicFeeTx
+		}
+
+		arg.Type = argUintPtr(uint64(txType))
+	}
+
+	if types.TxType(*arg.Type) == types.DynamicFeeTx {
+		if arg.GasTipCap == nil {
+			tip, err := e.store.MaxPriorityFeePerGas()
+			if err != nil {
+				return nil, err
+			}
+
+			arg.GasTipCap = argBytesPtr(tip.Bytes())
+		}
+
+		if arg.GasFeeCap == nil {
+			// the fee cap covers the base fee doubling, so the transaction stays executable for a few blocks
+			feeCap := new(big.Int).SetUint64(e.store.GetBaseFee())
+			feeCap.Lsh(feeCap, 1).Add(feeCap, new(big.Int).SetBytes(*arg.GasTipCap))
+
+			arg.GasFeeCap = argBytesPtr(feeCap.Bytes())
+		}
+	} else if arg.GasPrice == nil {
+		gasPrice, err := e.getGasPrice()
+		if err != nil {
+			return nil, err
+		}
+
+		arg.GasPrice = argBytesPtr(new(big.Int).SetUint64(gasPrice).Bytes())
+	}
+
+	if arg.Gas == nil {
+		// the estimation fills the defaults of its args, so it gets a copy
+		estimateArg := *arg
+
+		gas, err := e.EstimateGas(&estimateArg, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		arg.Gas = argUintPtr(uint64(gas.(argUint64)))
+	}
+
+	tx, err := DecodeTxn(arg, header.Number, e.store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err = e.signer.SignTx(tx, forks, e.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash(header.Number)
+
+	return tx, nil
 }
 
 // GetTransactionByHash returns a transaction by its hash.
@@ -344,6 +534,60 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	return toReceipt(raw, txn, uint64(txIndex), block.Header, logs), nil
 }
 
+// blockReceiptsOpts are the options of eth_getBlockReceipts
+type blockReceiptsOpts struct {
+	// DecodeTransfers adds the decoded sender, receiver and value to the ERC-20 Transfer logs
+	DecodeTransfers bool `json:"decodeTransfers"`
+}
+
+// GetBlockReceipts returns the receipts of all the transactions in the given block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash, opts *blockReceiptsOpts) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		// block not found
+		return nil, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		return []*receipt{}, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts for block with hash [%s] not found", block.Hash())
+	}
+
+	var (
+		res      = make([]*receipt, len(receipts))
+		logIndex = 0
+	)
+
+	for idx, raw := range receipts {
+		txn := block.Transactions[idx]
+		logs := toLogs(raw.Logs, uint64(logIndex), uint64(idx), block.Header, txn.Hash)
+
+		if opts != nil && opts.DecodeTransfers {
+			for _, log := range logs {
+				log.Transfer = decodeTransferLog(log)
+			}
+		}
+
+		res[idx] = toReceipt(raw, txn, uint64(idx), block.Header, logs)
+		logIndex += len(raw.Logs)
+	}
+
+	return res, nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Eth) GetStorageAt(
 	address types.Address,
@@ -454,8 +698,52 @@ func (o *overrideAccount) ToType() types.OverrideAccount {
 // StateOverride is the collection of overridden accounts.
 type stateOverride map[types.Address]overrideAccount
 
+func (s *stateOverride) ToType() types.StateOverride {
+	if s == nil {
+		return nil
+	}
+
+	res := types.StateOverride{}
+
+	for addr, o := range *s {
+		res[addr] = o.ToType()
+	}
+
+	return res
+}
+
+// blockOverride is the set of overridden block context fields
+type blockOverride struct {
+	Number     *argUint64     `json:"number"`
+	Difficulty *argUint64     `json:"difficulty"`
+	Time       *argUint64     `json:"time"`
+	GasLimit   *argUint64     `json:"gasLimit"`
+	Coinbase   *types.Address `json:"coinbase"`
+	BaseFee    *argUint64     `json:"baseFee"`
+}
+
+func (o *blockOverride) ToType() *types.BlockOverride {
+	if o == nil {
+		return nil
+	}
+
+	return &types.BlockOverride{
+		Number:     (*uint64)(o.Number),
+		Difficulty: (*uint64)(o.Difficulty),
+		Time:       (*uint64)(o.Time),
+		GasLimit:   (*uint64)(o.GasLimit),
+		Coinbase:   o.Coinbase,
+		BaseFee:    (*uint64)(o.BaseFee),
+	}
+}
+
 // Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOverride) (interface{}, error) {
+func (e *Eth) Call(
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (interface{}, error) {
 	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
 	if err != nil {
 		return nil, err
@@ -466,9 +754,11 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, err
 	}
 
+	blockOverride := apiBlockOverride.ToType()
+
 	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 	if transaction.Gas == 0 {
-		transaction.Gas = header.GasLimit
+		transaction.Gas = blockOverride.Apply(header).GasLimit
 	}
 
 	// Force transaction gas price if empty
@@ -476,16 +766,8 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, err
 	}
 
-	var override types.StateOverride
-	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
-	}
-
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override, true)
+	result, err := e.store.ApplyTxn(header, transaction, apiOverride.ToType(), blockOverride, true)
 	if err != nil {
 		return nil, err
 	}
@@ -526,7 +808,13 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	if transaction.IsValueTransfer() {
 		// if it is a simple value transfer or a contract creation,
 		// we already know what is the transaction gas cost, no need to apply transaction
-		gasCost, err := state.TransactionGasCost(transaction, forksInTime.Homestead, forksInTime.Istanbul)
+		gasCost, err := state.TransactionGasCost(
+			transaction,
+			forksInTime.Homestead,
+			forksInTime.Istanbul,
+			forksInTime.Berlin,
+			forksInTime.Shanghai,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -635,7 +923,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 		transaction.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true)
+		result, applyErr := e.store.ApplyTxn(header, transaction, nil, nil, true)
 
 		if result != nil {
 			data = []byte(hex.EncodeToString(result.ReturnValue))
@@ -709,6 +997,66 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	return argUint64(highEnd), nil
 }
 
+// CreateAccessList creates an EIP-2930 access list for the given transaction,
+// based on the state of the referenced block.
+// It returns the access list and the gas used by the transaction when the access list is applied
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := DecodeTxn(arg, header.Number, e.store, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	// sender, recipient and precompiles are always warm, so they are not part of the access list
+	to := crypto.CreateAddress(transaction.From, transaction.Nonce)
+	if transaction.To != nil {
+		to = *transaction.To
+	}
+
+	forksInTime := e.store.GetForksInTime(header.Number)
+	excluded := append(precompiled.NewPrecompiled().Addresses(&forksInTime), transaction.From, to)
+
+	// run the transaction until the access list is stable,
+	// since touching new slots can change the execution path
+	prevTracer := accesslisttracer.NewAccessListTracer(transaction.AccessList, excluded)
+
+	for {
+		accessList := prevTracer.AccessList()
+		transaction.AccessList = accessList
+
+		accessListTracer := accesslisttracer.NewAccessListTracer(accessList, excluded)
+
+		result, err := e.store.ApplyTxnWithTracer(header, transaction, accessListTracer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply transaction: %w", err)
+		}
+
+		if accessListTracer.Equal(prevTracer) {
+			res := &accessListResult{
+				AccessList: accessList,
+				GasUsed:    argUint64(result.GasUsed),
+			}
+
+			if result.Failed() {
+				res.Error = result.Err.Error()
+			}
+
+			return res, nil
+		}
+
+		prevTracer = accessListTracer
+	}
+}
+
 // GetFilterLogs returns an array of logs for the specified filter
 func (e *Eth) GetFilterLogs(id string) (interface{}, error) {
 	logFilter, err := e.filterManager.GetLogFilterFromID(id)
@@ -800,6 +1148,26 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the account and storage values of the given account
+// at the referenced block, including the merkle proofs (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
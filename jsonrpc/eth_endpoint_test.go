@@ -299,13 +299,13 @@ func TestEth_TxnType(t *testing.T) {
 
 func newTestEthEndpoint(store testStore) *Eth {
 	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, 0,
+		hclog.NewNullLogger(), store, 100, nil, 0, nil,
 	}
 }
 
 func newTestEthEndpointWithPriceLimit(store testStore, priceLimit uint64) *Eth {
 	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, priceLimit,
+		hclog.NewNullLogger(), store, 100, nil, priceLimit, nil,
 	}
 }
 
@@ -391,3 +391,43 @@ func TestOverrideAccount_ToType(t *testing.T) {
 	require.Equal(t, state, convertedAcc.State)
 	require.Equal(t, stateDiff, convertedAcc.StateDiff)
 }
+
+func TestBlockOverride_ToType(t *testing.T) {
+	t.Parallel()
+
+	var nilOverride *blockOverride
+	require.Nil(t, nilOverride.ToType())
+
+	coinbase := types.StringToAddress("1")
+
+	override := &blockOverride{
+		Number:   toArgUint64Ptr(10),
+		Time:     toArgUint64Ptr(20),
+		Coinbase: &coinbase,
+		BaseFee:  toArgUint64Ptr(30),
+	}
+
+	converted := override.ToType()
+	require.Equal(t, uint64(10), *converted.Number)
+	require.Nil(t, converted.Difficulty)
+	require.Equal(t, uint64(20), *converted.Time)
+	require.Nil(t, converted.GasLimit)
+	require.Equal(t, coinbase, *converted.Coinbase)
+	require.Equal(t, uint64(30), *converted.BaseFee)
+}
+
+func TestStateOverride_ToType(t *testing.T) {
+	t.Parallel()
+
+	var nilOverride *stateOverride
+	require.Nil(t, nilOverride.ToType())
+
+	addr := types.StringToAddress("1")
+	override := &stateOverride{
+		addr: overrideAccount{Nonce: toArgUint64Ptr(1)},
+	}
+
+	converted := override.ToType()
+	require.Len(t, converted, 1)
+	require.Equal(t, uint64(1), *converted[addr].Nonce)
+}
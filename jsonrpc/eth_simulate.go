@@ -0,0 +1,271 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks simulated by a single request
+	maxSimulateBlocks = 256
+
+	// simulateTimestampIncrement is the default timestamp difference between the simulated blocks
+	simulateTimestampIncrement = 1
+
+	// simulateRevertErrorCode is the error code of the reverted simulated calls
+	simulateRevertErrorCode = 3
+
+	// simulateVMErrorCode is the error code of the simulated calls failed in the VM
+	simulateVMErrorCode = -32015
+)
+
+var (
+	ErrSimulateNoBlocks       = errors.New("no blocks to simulate")
+	ErrSimulateTooManyBlocks  = fmt.Errorf("too many blocks to simulate, the limit is %d", maxSimulateBlocks)
+	ErrSimulateBlockNumber    = errors.New("block numbers must be in order")
+	ErrSimulateBlockTimestamp = errors.New("block timestamps must be in order")
+)
+
+// SimulationBlock is a block of calls simulated by eth_simulateV1
+type SimulationBlock struct {
+	Header        *types.Header
+	StateOverride types.StateOverride
+	Calls         []*SimulationCall
+}
+
+// SimulationCall is a call of the simulated block
+type SimulationCall struct {
+	Tx *types.Transaction
+	// NonceSet is true if the nonce is given by the caller,
+	// otherwise it's taken from the simulated state
+	NonceSet bool
+}
+
+// SimulatedBlock is the block built from the simulated calls
+type SimulatedBlock struct {
+	Block   *types.Block
+	Results []*SimulatedCallResult
+}
+
+// SimulatedCallResult is the result of a simulated call
+type SimulatedCallResult struct {
+	Result *runtime.ExecutionResult
+	Logs   []*types.Log
+}
+
+// simulateOpts is the request of eth_simulateV1
+type simulateOpts struct {
+	BlockStateCalls        []*simulateBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+}
+
+type simulateBlock struct {
+	BlockOverrides *blockOverride `json:"blockOverrides"`
+	StateOverrides *stateOverride `json:"stateOverrides"`
+	Calls          []*txnArgs     `json:"calls"`
+}
+
+type simulatedBlockResult struct {
+	*block
+	Calls []*simulatedCallResult `json:"calls"`
+}
+
+type simulatedCallResult struct {
+	ReturnData argBytes            `json:"returnData"`
+	Logs       []*Log              `json:"logs"`
+	GasUsed    argUint64           `json:"gasUsed"`
+	Status     argUint64           `json:"status"`
+	Error      *simulatedCallError `json:"error,omitempty"`
+}
+
+type simulatedCallError struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    *argBytes `json:"data,omitempty"`
+}
+
+// SimulateV1 simulates the calls of a sequence of blocks on top of the given block,
+// every call sees the state changes of the previous ones and nothing is broadcasted
+func (e *Eth) SimulateV1(opts *simulateOpts, filter BlockNumberOrHash) (interface{}, error) {
+	if opts == nil || len(opts.BlockStateCalls) == 0 {
+		return nil, ErrSimulateNoBlocks
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, ErrSimulateTooManyBlocks
+	}
+
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := e.decodeSimulationBlocks(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated, err := e.store.SimulateBlocks(header, blocks, opts.Validation, opts.TraceTransfers)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*simulatedBlockResult, len(simulated))
+
+	for idx, simulatedBlock := range simulated {
+		res[idx] = toSimulatedBlockResult(simulatedBlock, opts.ReturnFullTransactions)
+	}
+
+	return res, nil
+}
+
+// decodeSimulationBlocks builds the headers of the simulated blocks on top of the given header
+// and decodes their calls
+func (e *Eth) decodeSimulationBlocks(parent *types.Header, opts *simulateOpts) ([]*SimulationBlock, error) {
+	blocks := make([]*SimulationBlock, len(opts.BlockStateCalls))
+	prev := parent
+
+	for idx, blockCalls := range opts.BlockStateCalls {
+		if blockCalls == nil {
+			blockCalls = &simulateBlock{}
+		}
+
+		header := &types.Header{
+			Number:     prev.Number + 1,
+			Timestamp:  prev.Timestamp + simulateTimestampIncrement,
+			GasLimit:   prev.GasLimit,
+			Difficulty: prev.Difficulty,
+		}
+
+		// the fees are charged only if the validation is enabled
+		if opts.Validation {
+			header.BaseFee = prev.BaseFee
+		}
+
+		blockOverride := blockCalls.BlockOverrides.ToType()
+		header = blockOverride.Apply(header)
+
+		if blockOverride != nil && blockOverride.Coinbase != nil {
+			header.Miner = blockOverride.Coinbase.Bytes()
+		}
+
+		if header.Number <= prev.Number {
+			return nil, fmt.Errorf("%w: block %d is not after %d", ErrSimulateBlockNumber, header.Number, prev.Number)
+		}
+
+		if header.Timestamp <= prev.Timestamp {
+			return nil, fmt.Errorf("%w: timestamp %d is not after %d",
+				ErrSimulateBlockTimestamp, header.Timestamp, prev.Timestamp)
+		}
+
+		calls := make([]*SimulationCall, len(blockCalls.Calls))
+
+		for callIdx, arg := range blockCalls.Calls {
+			call, err := e.decodeSimulationCall(arg, header.Number, opts.Validation)
+			if err != nil {
+				return nil, fmt.Errorf("block %d, call %d: %w", idx, callIdx, err)
+			}
+
+			calls[callIdx] = call
+		}
+
+		blocks[idx] = &SimulationBlock{
+			Header:        header,
+			StateOverride: blockCalls.StateOverrides.ToType(),
+			Calls:         calls,
+		}
+
+		prev = header
+	}
+
+	return blocks, nil
+}
+
+func (e *Eth) decodeSimulationCall(arg *txnArgs, blockNumber uint64, validation bool) (*SimulationCall, error) {
+	if arg == nil {
+		return nil, errors.New("missing call")
+	}
+
+	// the missing nonce is taken from the simulated state
+	nonceSet := arg.Nonce != nil
+	if !nonceSet {
+		arg.Nonce = argUintPtr(0)
+	}
+
+	tx, err := DecodeTxn(arg, blockNumber, e.store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if validation {
+		if err := e.fillTransactionGasPrice(tx); err != nil {
+			return nil, err
+		}
+	} else {
+		// the fees are not charged without validation
+		tx.GasPrice, tx.GasTipCap, tx.GasFeeCap = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+	}
+
+	// the simulated calls are not signed
+	tx.V, tx.R, tx.S = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+
+	return &SimulationCall{
+		Tx:       tx,
+		NonceSet: nonceSet,
+	}, nil
+}
+
+func toSimulatedBlockResult(simulated *SimulatedBlock, fullTx bool) *simulatedBlockResult {
+	header := simulated.Block.Header
+	res := &simulatedBlockResult{
+		block: toBlock(simulated.Block, fullTx),
+		Calls: make([]*simulatedCallResult, len(simulated.Results)),
+	}
+
+	logIndex := uint64(0)
+
+	for idx, callResult := range simulated.Results {
+		var (
+			txHash = simulated.Block.Transactions[idx].Hash
+			result = callResult.Result
+		)
+
+		call := &simulatedCallResult{
+			ReturnData: argBytes(result.ReturnValue),
+			Logs:       toLogs(callResult.Logs, logIndex, uint64(idx), header, txHash),
+			GasUsed:    argUint64(result.GasUsed),
+			Status:     argUint64(types.ReceiptSuccess),
+		}
+
+		if result.Failed() {
+			call.Status = argUint64(types.ReceiptFailed)
+			call.Error = toSimulatedCallError(result)
+		}
+
+		logIndex += uint64(len(callResult.Logs))
+		res.Calls[idx] = call
+	}
+
+	return res
+}
+
+func toSimulatedCallError(result *runtime.ExecutionResult) *simulatedCallError {
+	if result.Reverted() {
+		return &simulatedCallError{
+			Code:    simulateRevertErrorCode,
+			Message: constructErrorFromRevert(result).Error(),
+			Data:    argBytesPtr(result.ReturnValue),
+		}
+	}
+
+	return &simulatedCallError{
+		Code:    simulateVMErrorCode,
+		Message: result.Err.Error(),
+	}
+}
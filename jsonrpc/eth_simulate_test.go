@@ -0,0 +1,269 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestSimulateParent() *types.Block {
+	block := newTestBlock(100, hash1)
+	block.Header.Timestamp = 1000
+	block.Header.GasLimit = 1000000
+	block.Header.BaseFee = 5
+
+	return block
+}
+
+func TestEth_SimulateV1(t *testing.T) {
+	t.Parallel()
+
+	var (
+		number   = argUint64(110)
+		time     = argUint64(2000)
+		coinbase = types.StringToAddress("3")
+		balance  = argUint64(7)
+		testLog  = &types.Log{Address: addr1, Topics: []types.Hash{hash1}, Data: []byte{0x1}}
+	)
+
+	store := newMockBlockStore()
+	store.add(newTestSimulateParent())
+	store.simulateBlocksFn = func(
+		blocks []*SimulationBlock,
+		validation bool,
+		traceTransfers bool,
+	) ([]*SimulatedBlock, error) {
+		assert.False(t, validation)
+		assert.True(t, traceTransfers)
+		require.Len(t, blocks, 2)
+
+		// the first block follows the parent block
+		first := blocks[0]
+		assert.Equal(t, uint64(101), first.Header.Number)
+		assert.Equal(t, uint64(1001), first.Header.Timestamp)
+		assert.Equal(t, uint64(1000000), first.Header.GasLimit)
+		assert.Equal(t, uint64(0), first.Header.BaseFee, "the fees are not charged without validation")
+		assert.Empty(t, first.Header.Miner)
+		assert.Nil(t, first.StateOverride)
+		require.Len(t, first.Calls, 1)
+		assert.False(t, first.Calls[0].NonceSet)
+		assert.Equal(t, addr0, first.Calls[0].Tx.From)
+		assert.Equal(t, big.NewInt(0), first.Calls[0].Tx.GasPrice)
+
+		// the second block is overridden
+		second := blocks[1]
+		assert.Equal(t, uint64(110), second.Header.Number)
+		assert.Equal(t, uint64(2000), second.Header.Timestamp)
+		assert.Equal(t, coinbase.Bytes(), second.Header.Miner)
+		assert.Equal(t, big.NewInt(7), second.StateOverride[addr0].Balance)
+		require.Len(t, second.Calls, 1)
+		assert.True(t, second.Calls[0].NonceSet)
+		assert.Equal(t, uint64(3), second.Calls[0].Tx.Nonce)
+
+		res := make([]*SimulatedBlock, len(blocks))
+
+		for idx, block := range blocks {
+			txs := make([]*types.Transaction, len(block.Calls))
+			for callIdx, call := range block.Calls {
+				txs[callIdx] = call.Tx
+			}
+
+			block.Header.Hash = types.BytesToHash([]byte{byte(idx + 1)})
+
+			res[idx] = &SimulatedBlock{
+				Block: &types.Block{Header: block.Header, Transactions: txs},
+			}
+		}
+
+		res[0].Results = []*SimulatedCallResult{
+			{
+				Result: &runtime.ExecutionResult{ReturnValue: []byte{0x2}, GasUsed: 21000},
+				Logs:   []*types.Log{testLog},
+			},
+		}
+		res[1].Results = []*SimulatedCallResult{
+			{
+				Result: &runtime.ExecutionResult{
+					ReturnValue: []byte{0x3},
+					GasUsed:     30000,
+					Err:         runtime.ErrExecutionReverted,
+				},
+			},
+		}
+
+		return res, nil
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.SimulateV1(&simulateOpts{
+		BlockStateCalls: []*simulateBlock{
+			{
+				Calls: []*txnArgs{{From: &addr0, To: &addr1}},
+			},
+			{
+				BlockOverrides: &blockOverride{
+					Number:   &number,
+					Time:     &time,
+					Coinbase: &coinbase,
+				},
+				StateOverrides: &stateOverride{
+					addr0: overrideAccount{Balance: &balance},
+				},
+				Calls: []*txnArgs{{From: &addr0, To: &addr1, Nonce: argUintPtr(3)}},
+			},
+		},
+		TraceTransfers: true,
+	}, BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	blocks, ok := res.([]*simulatedBlockResult)
+	require.True(t, ok)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, argUint64(101), blocks[0].Number)
+	assert.Equal(t, []*simulatedCallResult{
+		{
+			ReturnData: argBytes{0x2},
+			Logs: []*Log{
+				{
+					Address:     addr1,
+					Topics:      []types.Hash{hash1},
+					Data:        argBytes{0x1},
+					BlockNumber: 101,
+					BlockHash:   types.BytesToHash([]byte{1}),
+					TxHash:      blocks[0].Transactions[0].getHash(),
+				},
+			},
+			GasUsed: 21000,
+			Status:  argUint64(types.ReceiptSuccess),
+		},
+	}, blocks[0].Calls)
+
+	assert.Equal(t, argUint64(110), blocks[1].Number)
+	assert.Equal(t, []*simulatedCallResult{
+		{
+			ReturnData: argBytes{0x3},
+			Logs:       []*Log{},
+			GasUsed:    30000,
+			Status:     argUint64(types.ReceiptFailed),
+			Error: &simulatedCallError{
+				Code:    simulateRevertErrorCode,
+				Message: runtime.ErrExecutionReverted.Error(),
+				Data:    argBytesPtr([]byte{0x3}),
+			},
+		},
+	}, blocks[1].Calls)
+}
+
+func TestEth_SimulateV1_Validation(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	store.add(newTestSimulateParent())
+	store.baseFee = 5
+	store.maxPriorityFeePerGasFn = func() (*big.Int, error) {
+		return big.NewInt(1), nil
+	}
+	store.simulateBlocksFn = func(
+		blocks []*SimulationBlock,
+		validation bool,
+		_ bool,
+	) ([]*SimulatedBlock, error) {
+		assert.True(t, validation)
+		assert.Equal(t, uint64(5), blocks[0].Header.BaseFee)
+
+		// the gas price is estimated if it's missing
+		assert.Equal(t, big.NewInt(6), blocks[0].Calls[0].Tx.GasPrice)
+
+		return []*SimulatedBlock{}, nil
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	_, err := eth.SimulateV1(&simulateOpts{
+		BlockStateCalls: []*simulateBlock{
+			{
+				Calls: []*txnArgs{{From: &addr0, To: &addr1}},
+			},
+		},
+		Validation: true,
+	}, BlockNumberOrHash{})
+	require.NoError(t, err)
+}
+
+func TestEth_SimulateV1_Errors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		number = argUint64(100)
+		time   = argUint64(1000)
+	)
+
+	store := newMockBlockStore()
+	store.add(newTestSimulateParent())
+
+	eth := newTestEthEndpoint(store)
+
+	tests := []struct {
+		name string
+		opts *simulateOpts
+		err  error
+	}{
+		{
+			name: "should return error if there are no blocks",
+			opts: &simulateOpts{},
+			err:  ErrSimulateNoBlocks,
+		},
+		{
+			name: "should return error if there are too many blocks",
+			opts: &simulateOpts{
+				BlockStateCalls: make([]*simulateBlock, maxSimulateBlocks+1),
+			},
+			err: ErrSimulateTooManyBlocks,
+		},
+		{
+			name: "should return error if the block number is not increasing",
+			opts: &simulateOpts{
+				BlockStateCalls: []*simulateBlock{
+					{BlockOverrides: &blockOverride{Number: &number}},
+				},
+			},
+			err: ErrSimulateBlockNumber,
+		},
+		{
+			name: "should return error if the timestamp is not increasing",
+			opts: &simulateOpts{
+				BlockStateCalls: []*simulateBlock{
+					{BlockOverrides: &blockOverride{Time: &time}},
+				},
+			},
+			err: ErrSimulateBlockTimestamp,
+		},
+		{
+			name: "should return error if the contract creation has no data",
+			opts: &simulateOpts{
+				BlockStateCalls: []*simulateBlock{
+					{Calls: []*txnArgs{{From: &addr0}}},
+				},
+			},
+			err: ErrNoDataInContractCreation,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := eth.SimulateV1(test.opts, BlockNumberOrHash{})
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
+
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -575,6 +580,79 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	slot := types.BytesToHash([]byte{0x1})
+	proofState := itrie.NewState(itrie.NewMemoryStorage())
+
+	_, root, err := proofState.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr0,
+			Balance:  big.NewInt(100),
+			Nonce:    2,
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: slot.Bytes(), Val: types.BytesToHash([]byte{0x2a}).Bytes()},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	store := &mockSpecialStore{
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      hash1,
+				Number:    0,
+				StateRoot: types.BytesToHash(root),
+			},
+		},
+		proofState: proofState,
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockHash: &hash1})
+	assert.NoError(t, err)
+
+	proof, ok := res.(*accountProof)
+	assert.True(t, ok)
+	assert.Equal(t, addr0, proof.Address)
+	assert.Equal(t, argUint64(2), proof.Nonce)
+	assert.Equal(t, "0x64", toJSONText(t, proof.Balance))
+	assert.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+	assert.Len(t, proof.StorageProof, 1)
+	assert.Equal(t, "0x2a", toJSONText(t, proof.StorageProof[0].Value))
+
+	nodes := make([][]byte, len(proof.AccountProof))
+	for i, node := range proof.AccountProof {
+		nodes[i] = node
+	}
+
+	account, err := itrie.VerifyAccountProof(types.BytesToHash(root), addr0, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, proof.StorageHash, account.Root)
+
+	nodes = make([][]byte, len(proof.StorageProof[0].Proof))
+	for i, node := range proof.StorageProof[0].Proof {
+		nodes[i] = node
+	}
+
+	value, err := itrie.VerifyStorageProof(account.Root, slot, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, types.BytesToHash([]byte{0x2a}), value)
+}
+
+func toJSONText(t *testing.T, v argBig) string {
+	t.Helper()
+
+	res, err := v.MarshalText()
+	assert.NoError(t, err)
+
+	return string(res)
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -827,12 +905,78 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	assert.Equal(t, state.TxGasContractCreation, uint64(estimateUint64))
 }
 
+func TestEth_CreateAccessList(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0x1000")
+	contract := types.StringToAddress("0x2000")
+	slot := types.BytesToHash([]byte{0x5})
+
+	// the contract reads slot 0 and then the slot which key is stored in slot 0
+	// PUSH1 0x00 SLOAD SLOAD STOP
+	code := []byte{0x60, 0x00, 0x54, 0x54, 0x00}
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   chain.AllForksEnabled.Copy().RemoveFork(chain.London),
+		ChainID: 100,
+	}, st, hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	root, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		contract: {
+			Code:    code,
+			Storage: map[types.Hash]types.Hash{types.ZeroHash: slot},
+		},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	store := getExampleStore()
+	store.account.address = sender
+	store.applyTxnWithTracerHook = func(txn *types.Transaction, tracer tracer.Tracer) (*runtime.ExecutionResult, error) {
+		transition, err := executor.BeginTxn(root, &types.Header{GasLimit: 1000000}, types.ZeroAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		transition.SetNonPayable(true)
+		transition.SetTracer(tracer)
+
+		return transition.Apply(txn)
+	}
+
+	ethEndpoint := newTestEthEndpoint(store)
+
+	res, err := ethEndpoint.CreateAccessList(&txnArgs{
+		From: &sender,
+		To:   &contract,
+		Gas:  argUintPtr(100000),
+	}, BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	result, ok := res.(*accessListResult)
+	require.True(t, ok)
+	require.Empty(t, result.Error)
+	require.Equal(t, types.TxAccessList{
+		{Address: contract, StorageKeys: []types.Hash{types.ZeroHash, slot}},
+	}, result.AccessList)
+
+	// intrinsic gas, access list and two warm storage reads
+	require.Equal(t, argUint64(state.TxGas+state.TxAccessListAddressGas+2*state.TxAccessListStorageKeyGas+3+100+100),
+		result.GasUsed)
+}
+
 type mockSpecialStore struct {
 	ethStore
-	account *mockAccount
-	block   *types.Block
+	account    *mockAccount
+	block      *types.Block
+	proofState *itrie.State
 
-	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	applyTxnHook           func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	applyTxnWithTracerHook func(txn *types.Transaction, tracer tracer.Tracer) (*runtime.ExecutionResult, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -863,6 +1007,10 @@ func (m *mockSpecialStore) Header() *types.Header {
 	return m.block.Header
 }
 
+func (m *mockSpecialStore) FinalizedHeader() (*types.Header, error) {
+	return m.block.Header, nil
+}
+
 func (m *mockSpecialStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	if m.block.Header.Number != num {
 		return nil, false
@@ -898,14 +1046,34 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(
+	root types.Hash,
+	addr types.Address,
+	storageKeys []types.Hash,
+) (*itrie.AccountProof, error) {
+	return m.proofState.GetProof(root, addr, storageKeys)
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.AllForksEnabled.At(0)
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _ *types.BlockOverride, _ bool) (*runtime.ExecutionResult, error) {
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+func (m *mockSpecialStore) ApplyTxnWithTracer(
+	_ *types.Header,
+	txn *types.Transaction,
+	tracer tracer.Tracer,
+) (*runtime.ExecutionResult, error) {
+	if m.applyTxnWithTracerHook != nil {
+		return m.applyTxnWithTracerHook(txn, tracer)
+	}
+
+	return &runtime.ExecutionResult{}, nil
+}
@@ -296,6 +296,9 @@ type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the last finalized block
+	FinalizedHeader() (*types.Header, error)
+
 	// SubscribeEvents subscribes for chain head events
 	SubscribeEvents() blockchain.Subscription
 
@@ -310,6 +313,10 @@ type filterManagerStore interface {
 
 	// TxPoolSubscribe subscribes for tx pool events
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
+
+	// GetLogCandidates returns the numbers of the blocks in the range which may contain the logs
+	// matching the filters according to the bloom-bits index, and the first block which is not indexed
+	GetLogCandidates(from, to uint64, filters [][][]byte) ([]uint64, uint64, error)
 }
 
 // FilterManager manages all running filters
@@ -531,22 +538,32 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		return nil, ErrBlockRangeTooHigh
 	}
 
+	// the indexed blocks which can't contain the logs are skipped,
+	// the rest of the range is checked block by block
+	candidates, next, err := f.store.GetLogCandidates(from, to, query.bloomFilters())
+	if err != nil {
+		return nil, err
+	}
+
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
-		block, ok := f.store.GetBlockByNumber(i, true)
-		if !ok {
-			break
+	for _, i := range candidates {
+		blockLogs, ok, err := f.getLogsFromBlockNumber(query, i)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return logs, nil
 		}
 
-		if len(block.Transactions) == 0 {
-			// do not check logs if no txs
-			continue
-		}
+		logs = append(logs, blockLogs...)
+	}
 
-		blockLogs, err := f.getLogsFromBlock(query, block)
+	for i := next; i <= to; i++ {
+		blockLogs, ok, err := f.getLogsFromBlockNumber(query, i)
 		if err != nil {
 			return nil, err
+		} else if !ok {
+			break
 		}
 
 		logs = append(logs, blockLogs...)
@@ -555,6 +572,24 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 	return logs, nil
 }
 
+// getLogsFromBlockNumber returns the logs of the block matching the query,
+// and false if the block doesn't exist
+func (f *FilterManager) getLogsFromBlockNumber(query *LogQuery, number uint64) ([]*Log, bool, error) {
+	block, ok := f.store.GetBlockByNumber(number, true)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		// do not check logs if no txs
+		return nil, true, nil
+	}
+
+	logs, err := f.getLogsFromBlock(query, block)
+
+	return logs, true, err
+}
+
 // GetLogsForQuery return array of logs for given query
 func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
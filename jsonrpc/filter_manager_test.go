@@ -154,6 +154,59 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_BloomBits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = types.StringToAddress("1")
+		topic   = types.StringToHash("2")
+		testLog = &types.Log{Address: addr, Topics: []types.Hash{topic}}
+	)
+
+	store := &mockBlockStore{receipts: map[types.Hash][]*types.Receipt{}}
+
+	for i := 0; i < 6; i++ {
+		block := &types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{createTestTransaction(types.StringToHash(strconv.Itoa(i)))},
+		}
+
+		store.receipts[block.Hash()] = []*types.Receipt{{Logs: []*types.Log{testLog}}}
+		store.appendBlocksToStore([]*types.Block{block})
+	}
+
+	// the blocks up to 3 are indexed and only the block 2 may contain the logs
+	store.logCandidatesFn = func(from, to uint64, filters [][][]byte) ([]uint64, uint64, error) {
+		assert.Equal(t, uint64(1), from)
+		assert.Equal(t, uint64(5), to)
+		assert.Equal(t, [][][]byte{{addr.Bytes()}, {topic.Bytes()}}, filters)
+
+		return []uint64{2}, 4, nil
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	logs, err := f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   5,
+		Addresses: []types.Address{addr},
+		Topics:    [][]types.Hash{{topic}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	assert.Equal(t, argUint64(2), logs[0].BlockNumber)
+	assert.Equal(t, argUint64(4), logs[1].BlockNumber)
+	assert.Equal(t, argUint64(5), logs[2].BlockNumber)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
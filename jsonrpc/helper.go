@@ -11,6 +11,7 @@ import (
 var (
 	ErrHeaderNotFound           = errors.New("header not found")
 	ErrLatestNotFound           = errors.New("latest header not found")
+	ErrFinalizedNotFound        = errors.New("finalized header not found")
 	ErrNegativeBlockNumber      = errors.New("invalid argument 0: block number must not be negative")
 	ErrFailedFetchGenesis       = errors.New("error fetching genesis block header")
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
@@ -18,12 +19,13 @@ var (
 
 type latestHeaderGetter interface {
 	Header() *types.Header
+	FinalizedHeader() (*types.Header, error)
 }
 
 // GetNumericBlockNumber returns block number based on current state or specified number
 func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64, error) {
 	switch number {
-	case LatestBlockNumber, PendingBlockNumber:
+	case LatestBlockNumber, PendingBlockNumber, SafeBlockNumber:
 		latest := store.Header()
 		if latest == nil {
 			return 0, ErrLatestNotFound
@@ -31,6 +33,14 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 		return latest.Number, nil
 
+	case FinalizedBlockNumber:
+		finalized, err := store.FinalizedHeader()
+		if err != nil {
+			return 0, err
+		}
+
+		return finalized.Number, nil
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -45,15 +55,19 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 type headerGetter interface {
 	Header() *types.Header
+	FinalizedHeader() (*types.Header, error)
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 }
 
 // GetBlockHeader returns a header using the provided number
 func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, error) {
 	switch number {
-	case PendingBlockNumber, LatestBlockNumber:
+	case PendingBlockNumber, LatestBlockNumber, SafeBlockNumber:
 		return store.Header(), nil
 
+	case FinalizedBlockNumber:
+		return store.FinalizedHeader()
+
 	case EarliestBlockNumber:
 		header, ok := store.GetHeaderByNumber(uint64(0))
 		if !ok {
@@ -99,6 +113,7 @@ func GetTxAndBlockByTxHash(txHash types.Hash, store txLookupAndBlockGetter) (*ty
 
 type blockGetter interface {
 	Header() *types.Header
+	FinalizedHeader() (*types.Header, error)
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 }
@@ -130,6 +145,7 @@ func GetHeaderFromBlockNumberOrHash(bnh BlockNumberOrHash, store blockGetter) (*
 
 type nonceGetter interface {
 	Header() *types.Header
+	FinalizedHeader() (*types.Header, error)
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetNonce(types.Address) uint64
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
@@ -235,6 +251,10 @@ func DecodeTxn(arg *txnArgs, blockNumber uint64, store nonceGetter, forceSetNonc
 		txn.To = arg.To
 	}
 
+	if arg.AccessList != nil {
+		txn.AccessList = *arg.AccessList
+	}
+
 	txn.ComputeHash(blockNumber)
 
 	return txn, nil
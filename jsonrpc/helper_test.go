@@ -184,9 +184,46 @@ func TestGetNumericBlockNumber(t *testing.T) {
 			expected: 10,
 			err:      nil,
 		},
+		{
+			name: "should return latest if found and safe is given",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				headerFn: func() *types.Header {
+					return &types.Header{
+						Number: 10,
+					}
+				},
+			},
+			expected: 10,
+			err:      nil,
+		},
+		{
+			name: "should return the finalized block's number if finalized is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() (*types.Header, error) {
+					return &types.Header{
+						Number: 8,
+					}, nil
+				},
+			},
+			expected: 8,
+			err:      nil,
+		},
+		{
+			name: "should return error if the finalized block is not found",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() (*types.Header, error) {
+					return nil, ErrFinalizedNotFound
+				},
+			},
+			expected: 0,
+			err:      ErrFinalizedNotFound,
+		},
 		{
 			name:     "should return error if negative number is given",
-			num:      -5,
+			num:      -10,
 			store:    &debugEndpointMockStore{},
 			expected: 0,
 			err:      ErrNegativeBlockNumber,
@@ -272,6 +309,28 @@ func TestGetBlockHeader(t *testing.T) {
 			expected: testLatestHeader,
 			err:      nil,
 		},
+		{
+			name: "should return latest if safe is given",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				headerFn: func() *types.Header {
+					return testLatestHeader
+				},
+			},
+			expected: testLatestHeader,
+			err:      nil,
+		},
+		{
+			name: "should return the finalized header if finalized is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() (*types.Header, error) {
+					return testHeader10, nil
+				},
+			},
+			expected: testHeader10,
+			err:      nil,
+		},
 		{
 			name: "should return header at arbitrary height",
 			num:  10,
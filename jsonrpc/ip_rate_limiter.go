@@ -0,0 +1,260 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+// sweepInterval is the interval at which the buckets of the idle clients are dropped
+const sweepInterval = time.Minute
+
+// IPRateLimit limits the cost of the requests served to each client IP
+type IPRateLimit struct {
+	// RequestsPerSecond is the cost refilled to the bucket of the client every second,
+	// the limiter is disabled if it's zero
+	RequestsPerSecond uint64
+
+	// Burst is the maximum cost the client can spend at once, it's RequestsPerSecond if it's zero
+	Burst uint64
+
+	// TrustedProxies lists the IPs and the CIDRs of the proxies whose X-Forwarded-For header is honoured
+	TrustedProxies []string
+
+	// MethodCosts maps the namespaces (e.g. "debug") and the full method names (e.g. "eth_getLogs")
+	// to the cost of their requests, the cost of the rest of the methods is one
+	MethodCosts map[string]uint64
+}
+
+// ipRateLimiter keeps a token bucket for each client IP
+type ipRateLimiter struct {
+	rate    uint64
+	burst   uint64
+	trusted []*net.IPNet
+	costs   map[string]uint64
+
+	lock      sync.Mutex
+	buckets   map[string]*rateLimiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// newIPRateLimiter returns the limiter of the config, it returns nil if the limiter is disabled
+func newIPRateLimiter(config *IPRateLimit) (*ipRateLimiter, error) {
+	if config == nil || config.RequestsPerSecond == 0 {
+		return nil, nil
+	}
+
+	l := &ipRateLimiter{
+		rate:      config.RequestsPerSecond,
+		burst:     config.Burst,
+		costs:     config.MethodCosts,
+		buckets:   make(map[string]*rateLimiter),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+
+	if l.burst == 0 {
+		l.burst = l.rate
+	}
+
+	for _, proxy := range config.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %s", proxy)
+			}
+
+			l.trusted = append(l.trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %w", proxy, err)
+		}
+
+		l.trusted = append(l.trusted, network)
+	}
+
+	return l, nil
+}
+
+// clientIP returns the IP of the client, the X-Forwarded-For hops are followed
+// from the closest one as long as they are added by the trusted proxies
+func (l *ipRateLimiter) clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+
+	if !l.isTrusted(ip) {
+		return ip.String()
+	}
+
+	hops := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+
+		ip = hop
+
+		if !l.isTrusted(hop) {
+			break
+		}
+	}
+
+	return ip.String()
+}
+
+func (l *ipRateLimiter) isTrusted(ip net.IP) bool {
+	for _, network := range l.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// methodCost returns the cost of the method, the cost of the method takes precedence over its namespace
+func (l *ipRateLimiter) methodCost(method string) uint64 {
+	if cost, ok := l.costs[method]; ok {
+		return cost
+	}
+
+	namespace, _, _ := strings.Cut(method, "_")
+	if cost, ok := l.costs[namespace]; ok {
+		return cost
+	}
+
+	return 1
+}
+
+// requestCost returns the cost of the single or the batch request, the malformed
+// requests cost one as they are rejected without being handled
+func (l *ipRateLimiter) requestCost(body []byte) uint64 {
+	type methodOnly struct {
+		Method string `json:"method"`
+	}
+
+	body = bytes.TrimLeft(body, " \t\r\n")
+
+	if len(body) > 0 && body[0] == '[' {
+		var batch []methodOnly
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return 1
+		}
+
+		cost := uint64(0)
+		for _, req := range batch {
+			cost += l.methodCost(req.Method)
+		}
+
+		return cost
+	}
+
+	var req methodOnly
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 1
+	}
+
+	return l.methodCost(req.Method)
+}
+
+// allow takes the cost from the bucket of the client, it returns false if the client exceeded the limit
+func (l *ipRateLimiter) allow(ip string, cost uint64, transport serverType) bool {
+	l.lock.Lock()
+
+	if now := l.now(); now.Sub(l.lastSweep) >= sweepInterval {
+		// the refilled buckets are the same as the new ones
+		for key, bucket := range l.buckets {
+			if bucket.isFull() {
+				delete(l.buckets, key)
+			}
+		}
+
+		l.lastSweep = now
+	}
+
+	bucket, ok := l.buckets[ip]
+	if !ok {
+		bucket = newRateLimiter(l.rate, l.burst)
+		bucket.now = l.now
+		l.buckets[ip] = bucket
+	}
+
+	l.lock.Unlock()
+
+	if bucket.allowN(cost) {
+		return true
+	}
+
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetric, "ip_rate_rejected"}, 1, []metrics.Label{
+		{Name: "transport", Value: transport.String()},
+	})
+
+	return false
+}
+
+// rateLimitExceededResponse returns the response to the request rejected by the limiter
+func rateLimitExceededResponse() []byte {
+	resp, _ := NewRPCResponse(nil, "2.0", nil, NewLimitExceededError("rate limit exceeded")).Bytes()
+
+	return resp
+}
+
+// rateLimitMiddlewareFactory builds a middleware which rejects the HTTP requests
+// of the clients exceeding the rate limit, the middleware is a no-op without the limiter
+func rateLimitMiddlewareFactory(limiter *ipRateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				_, _ = w.Write([]byte(err.Error()))
+
+				return
+			}
+
+			if !limiter.allow(limiter.clientIP(r), limiter.requestCost(data), serverHTTP) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write(rateLimitExceededResponse())
+
+				return
+			}
+
+			// the body is read again by the handler
+			r.Body = io.NopCloser(bytes.NewReader(data))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
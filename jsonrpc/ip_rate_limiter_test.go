@@ -0,0 +1,166 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	l, err := newIPRateLimiter(nil)
+	require.NoError(t, err)
+	require.Nil(t, l)
+
+	l, err = newIPRateLimiter(&IPRateLimit{Burst: 10})
+	require.NoError(t, err)
+	require.Nil(t, l)
+
+	_, err = newIPRateLimiter(&IPRateLimit{RequestsPerSecond: 1, TrustedProxies: []string{"proxy"}})
+	require.Error(t, err)
+}
+
+func TestIPRateLimiter_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	l, err := newIPRateLimiter(&IPRateLimit{
+		RequestsPerSecond: 1,
+		TrustedProxies:    []string{"10.0.0.0/8", "192.168.1.1"},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		expected  string
+	}{
+		{"direct", "1.2.3.4:5000", nil, "1.2.3.4"},
+		{"untrusted proxy", "1.2.3.4:5000", []string{"5.6.7.8"}, "1.2.3.4"},
+		{"trusted proxy", "10.0.0.1:5000", []string{"5.6.7.8"}, "5.6.7.8"},
+		{"spoofed hop", "10.0.0.1:5000", []string{"9.9.9.9, 5.6.7.8"}, "5.6.7.8"},
+		{"chain of proxies", "10.0.0.1:5000", []string{"5.6.7.8, 192.168.1.1", "10.0.0.2"}, "5.6.7.8"},
+		{"only proxies", "10.0.0.1:5000", []string{"10.0.0.2"}, "10.0.0.2"},
+		{"malformed hop", "10.0.0.1:5000", []string{"garbage, 10.0.0.2"}, "10.0.0.2"},
+		{"no header", "10.0.0.1:5000", nil, "10.0.0.1"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.RemoteAddr = c.remote
+
+		for _, value := range c.forwarded {
+			req.Header.Add("X-Forwarded-For", value)
+		}
+
+		assert.Equal(t, c.expected, l.clientIP(req), c.name)
+	}
+}
+
+func TestIPRateLimiter_RequestCost(t *testing.T) {
+	t.Parallel()
+
+	l, err := newIPRateLimiter(&IPRateLimit{
+		RequestsPerSecond: 1,
+		MethodCosts: map[string]uint64{
+			"debug":          50,
+			"debug_getRawTx": 2,
+			"eth_getLogs":    10,
+			"web3":           0,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), l.methodCost("eth_blockNumber"))
+	assert.Equal(t, uint64(10), l.methodCost("eth_getLogs"))
+	assert.Equal(t, uint64(50), l.methodCost("debug_traceBlock"))
+	assert.Equal(t, uint64(2), l.methodCost("debug_getRawTx"))
+	assert.Equal(t, uint64(0), l.methodCost("web3_clientVersion"))
+
+	assert.Equal(t, uint64(10), l.requestCost([]byte(`{"method":"eth_getLogs"}`)))
+	assert.Equal(t, uint64(61), l.requestCost([]byte(` [{"method":"eth_getLogs"},{"method":"debug_traceBlock"},{"method":"net_version"}]`)))
+	assert.Equal(t, uint64(1), l.requestCost([]byte(`{"method":`)))
+}
+
+func TestIPRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	l, err := newIPRateLimiter(&IPRateLimit{RequestsPerSecond: 10, Burst: 20})
+	require.NoError(t, err)
+
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.allow("1.1.1.1", 15, serverHTTP))
+	require.False(t, l.allow("1.1.1.1", 10, serverHTTP))
+
+	// the buckets are separate
+	require.True(t, l.allow("2.2.2.2", 20, serverHTTP))
+
+	// the bucket is refilled at the given rate
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.allow("1.1.1.1", 10, serverHTTP))
+
+	// the refilled buckets are dropped
+	now = now.Add(sweepInterval)
+	require.True(t, l.allow("3.3.3.3", 1, serverHTTP))
+	require.Len(t, l.buckets, 1)
+}
+
+func TestIPRateLimiter_Middleware(t *testing.T) {
+	t.Parallel()
+
+	l, err := newIPRateLimiter(&IPRateLimit{
+		RequestsPerSecond: 1,
+		Burst:             5,
+		MethodCosts:       map[string]uint64{"eth_getLogs": 4},
+	})
+	require.NoError(t, err)
+
+	var handled []string
+
+	handler := rateLimitMiddlewareFactory(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		handled = append(handled, string(body))
+	}))
+
+	request := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5000"
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		return w
+	}
+
+	getLogs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[{}]}`
+
+	w := request(getLogs)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{getLogs}, handled)
+
+	w = request(getLogs)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Len(t, handled, 1)
+
+	resp := &ErrorResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32005, resp.Error.Code)
+
+	// the cheaper request still fits into the bucket
+	w = request(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, handled, 2)
+}
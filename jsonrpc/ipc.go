@@ -0,0 +1,132 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+)
+
+// ipcWrapper is a wrapping object for the IPC connection, it's used
+// as the WS connection so the subscriptions work the same way
+type ipcWrapper struct {
+	sync.Mutex
+
+	conn     net.Conn     // the actual IPC connection
+	logger   hclog.Logger // module logger
+	filterID string       // filter ID
+}
+
+func (w *ipcWrapper) SetFilterID(filterID string) {
+	w.filterID = filterID
+}
+
+func (w *ipcWrapper) GetFilterID() string {
+	return w.filterID
+}
+
+// WriteMessage writes out the newline-delimited message to the IPC peer,
+// the message type is ignored as all the messages are JSON
+func (w *ipcWrapper) WriteMessage(_ int, data []byte) error {
+	// the messages are written on a single line, as some of them are indented
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+1))
+	if err := json.Compact(buf, data); err != nil {
+		buf.Reset()
+		buf.Write(data)
+	}
+
+	buf.WriteByte('\n')
+
+	w.Lock()
+	defer w.Unlock()
+
+	_, writeErr := w.conn.Write(buf.Bytes())
+	if writeErr != nil {
+		w.logger.Error(
+			fmt.Sprintf("Unable to write IPC message, %s", writeErr.Error()),
+		)
+	}
+
+	return writeErr
+}
+
+func (j *JSONRPC) setupIPC() error {
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	j.ipcListener = lis
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	// Defer IPC closure
+	defer func() {
+		if err := conn.Close(); err != nil {
+			j.logger.Error(
+				fmt.Sprintf("Unable to gracefully close IPC connection, %s", err.Error()),
+			)
+		}
+	}()
+
+	wrapConn := &ipcWrapper{conn: conn, logger: j.logger}
+
+	j.logger.Debug("IPC connection established")
+
+	// the messages are separated by the newlines,
+	// but any whitespace between the JSON values is accepted
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				j.logger.Debug("Closing IPC connection gracefully")
+			} else {
+				j.logger.Error(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+			}
+
+			j.dispatcher.RemoveFilterByWs(wrapConn)
+
+			return
+		}
+
+		go func() {
+			resp, handleErr := j.dispatcher.HandleIPC(message, wrapConn)
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				resp = []byte(fmt.Sprintf("IPC Handle error: %s", handleErr.Error()))
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
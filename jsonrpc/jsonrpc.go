@@ -37,15 +37,19 @@ func (s serverType) String() string {
 
 // JSONRPC is an API consensus
 type JSONRPC struct {
-	logger     hclog.Logger
-	config     *Config
-	dispatcher dispatcher
+	logger      hclog.Logger
+	config      *Config
+	dispatcher  dispatcher
+	ipcListener net.Listener
+	ipLimiter   *ipRateLimiter
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleIPC(reqBody []byte, conn wsConn) ([]byte, error)
+	handleWsWithKey(reqBody []byte, conn wsConn, key *authKey) ([]byte, error)
+	handleWithKey(reqBody []byte, key *authKey) ([]byte, error)
+	openRPCDocument() *OpenRPCDocument
 }
 
 // JSONRPCStore defines all the methods required
@@ -62,6 +66,7 @@ type JSONRPCStore interface {
 type Config struct {
 	Store                    JSONRPCStore
 	Addr                     *net.TCPAddr
+	IPCPath                  string
 	ChainID                  uint64
 	ChainName                string
 	AccessControlAllowOrigin []string
@@ -71,6 +76,29 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64
 	WebSocketReadLimit      uint64
+
+	// HTTPPolicy and WSPolicy restrict the methods served on the transports
+	HTTPPolicy *MethodPolicy
+	WSPolicy   *MethodPolicy
+
+	// KeyStore enables the authentication of the HTTP and WS requests if it's set
+	KeyStore *KeyStore
+
+	// IPRateLimit limits the cost of the HTTP and WS requests of each client IP
+	IPRateLimit *IPRateLimit
+
+	// SlowRequestThreshold is the duration of the requests which are logged, zero disables the logging
+	SlowRequestThreshold time.Duration
+
+	// ResponseCacheSize is the maximum size in bytes of the cached results
+	// of the historical queries, zero disables the cache
+	ResponseCacheSize uint64
+
+	// EnableAdmin serves the admin namespace on IPC, and also on HTTP and WS if Addr is a loopback address
+	EnableAdmin bool
+
+	// LocalSigner enables the signing by the local accounts and the personal namespace, which is served as the admin one
+	LocalSigner *LocalSigner
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -85,6 +113,13 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			httpPolicy:              config.HTTPPolicy,
+			wsPolicy:                config.WSPolicy,
+			slowRequestThreshold:    config.SlowRequestThreshold,
+			responseCacheSize:       config.ResponseCacheSize,
+			enableAdmin:             config.EnableAdmin,
+			localOnLoopback:         config.Addr != nil && config.Addr.IP.IsLoopback(),
+			signer:                  config.LocalSigner,
 		},
 	)
 
@@ -92,10 +127,26 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	ipLimiter, err := newIPRateLimiter(config.IPRateLimit)
+	if err != nil {
+		return nil, err
+	}
+
 	srv := &JSONRPC{
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
 		dispatcher: d,
+		ipLimiter:  ipLimiter,
+	}
+
+	if config.IPCPath == "" && !d.params.localOnLoopback {
+		if config.EnableAdmin {
+			srv.logger.Warn("the admin namespace is served only on IPC, which is disabled")
+		}
+
+		if config.LocalSigner != nil {
+			srv.logger.Warn("the personal namespace is served only on IPC, which is disabled")
+		}
 	}
 
 	// start http server
@@ -103,9 +154,25 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	// start ipc server if the socket path is set
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
+// Close stops the IPC server and removes its socket
+func (j *JSONRPC) Close() error {
+	if j.ipcListener == nil {
+		return nil
+	}
+
+	return j.ipcListener.Close()
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server started", "addr", j.config.Addr.String())
 
@@ -121,10 +188,12 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.config)(rateLimitMiddlewareFactory(j.ipLimiter)(jsonRPCHandler)))
 
 	mux.HandleFunc("/ws", j.handleWs)
 
+	mux.Handle(openRPCPath, middlewareFactory(j.config)(http.HandlerFunc(j.handleOpenRPC)))
+
 	srv := http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
@@ -212,6 +281,11 @@ func isSupportedWSType(messageType int) bool {
 }
 
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
+	key, ok := j.authenticate(w, req, serverWS)
+	if !ok {
+		return
+	}
+
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -240,6 +314,11 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
 
+	clientIP := ""
+	if j.ipLimiter != nil {
+		clientIP = j.ipLimiter.clientIP(req)
+	}
+
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
 	for {
@@ -264,8 +343,14 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 
 		if isSupportedWSType(msgType) {
+			if j.ipLimiter != nil && !j.ipLimiter.allow(clientIP, j.ipLimiter.requestCost(message), serverWS) {
+				_ = wrapConn.WriteMessage(msgType, rateLimitExceededResponse())
+
+				continue
+			}
+
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.handleWsWithKey(message, wrapConn, key)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -291,7 +376,12 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 
 	switch req.Method {
 	case "POST":
-		j.handleJSONRPCRequest(w, req)
+		key, ok := j.authenticate(w, req, serverHTTP)
+		if !ok {
+			return
+		}
+
+		j.handleJSONRPCRequest(w, req, key)
 	case "GET":
 		j.handleGetRequest(w)
 	case "OPTIONS":
@@ -301,7 +391,31 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request) {
+// authenticate returns the key of the request if the authentication is enabled,
+// otherwise it rejects the request with the JSON-RPC error
+func (j *JSONRPC) authenticate(w http.ResponseWriter, req *http.Request, transport serverType) (*authKey, bool) {
+	if j.config.KeyStore == nil {
+		return nil, true
+	}
+
+	key, err := j.config.KeyStore.authenticateRequest(req, transport == serverWS)
+	if err == nil {
+		return key, true
+	}
+
+	authRejected(transport, err)
+	j.logger.Debug("request rejected", "transport", transport, "err", err)
+
+	resp, _ := NewRPCResponse(nil, "2.0", nil, NewUnauthorizedError(err.Error())).Bytes()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write(resp)
+
+	return nil, false
+}
+
+func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request, key *authKey) {
 	data, err := io.ReadAll(req.Body)
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -312,7 +426,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.handleWithKey(data, key)
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
 	} else {
@@ -322,6 +436,36 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Debug("handle", "response", string(resp))
 }
 
+// handleOpenRPC serves the same OpenRPC document as rpc_discover, for the tools which fetch it over HTTP GET
+func (j *JSONRPC) handleOpenRPC(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !j.config.HTTPPolicy.isAllowed(openRPCMethod) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	if _, ok := j.authenticate(w, req, serverHTTP); !ok {
+		return
+	}
+
+	resp, err := json.Marshal(j.dispatcher.openRPCDocument())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(resp)
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
@@ -1,17 +1,21 @@
 package jsonrpc
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"net"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/versioning"
 )
 
@@ -90,7 +94,7 @@ func TestJSONRPC_handleJSONRPCRequest(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			j.handleJSONRPCRequest(w, req)
+			j.handleJSONRPCRequest(w, req, nil)
 
 			response := w.Body.String()
 			require.Contains(t, response, c.expectedResponse)
@@ -98,6 +102,77 @@ func TestJSONRPC_handleJSONRPCRequest(t *testing.T) {
 	}
 }
 
+func TestJSONRPC_IPC(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	port, err := tests.GetFreePort()
+	require.NoError(t, err)
+
+	ipcPath := filepath.Join(t.TempDir(), "edge.ipc")
+
+	j, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:   store,
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		IPCPath: ipcPath,
+	})
+	require.NoError(t, err)
+
+	conn, err := ipc.Dial(ipcPath)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		require.NoError(t, j.Close())
+	})
+
+	reader := bufio.NewReader(conn)
+
+	readResponse := func() *SuccessResponse {
+		t.Helper()
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		resp := &SuccessResponse{}
+		require.NoError(t, json.Unmarshal(line, resp))
+		require.Nil(t, resp.Error)
+
+		return resp
+	}
+
+	// the requests are newline-delimited
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}` + "\n"))
+	require.NoError(t, err)
+
+	resp := readResponse()
+	require.Equal(t, float64(1), resp.ID)
+	require.Equal(t, `"0x0"`, string(resp.Result))
+
+	// the subscriptions are served the same way as on the websocket
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}` + "\n"))
+	require.NoError(t, err)
+
+	resp = readResponse()
+	require.Equal(t, float64(2), resp.ID)
+
+	var subscriptionID string
+	require.NoError(t, json.Unmarshal(resp.Result, &subscriptionID))
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{header: &types.Header{Number: 1, Hash: types.StringToHash("1")}},
+		},
+	})
+
+	line, err := reader.ReadBytes('\n')
+	require.NoError(t, err)
+	require.Contains(t, string(line), `"method":"eth_subscription"`)
+	require.Contains(t, string(line), subscriptionID)
+}
+
 func newTestJSONRPC(t *testing.T) (*JSONRPC, error) {
 	t.Helper()
 
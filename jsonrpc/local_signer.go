@@ -0,0 +1,279 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/keystore"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errNoLocalSigner   = errors.New("the local signer is not enabled")
+	errUnknownAccount  = errors.New("unknown account")
+	errAccountLocked   = errors.New("account is locked")
+	errKeyAddressMatch = errors.New("the key doesn't match the address of the key file")
+)
+
+// keyFilePerm is the permission of the key files written by the signer
+const keyFilePerm = 0600
+
+// localAccount is the account whose key is stored in the key file
+type localAccount struct {
+	address types.Address
+	path    string
+
+	// key is set while the account is unlocked, the timer locks it once the unlock expires
+	key   *ecdsa.PrivateKey
+	timer *time.Timer
+}
+
+// LocalSigner signs with the keys of the encrypted key files in the keystore directory,
+// the keys are decrypted only while their accounts are unlocked
+type LocalSigner struct {
+	lock     sync.Mutex
+	dir      string
+	accounts map[types.Address]*localAccount
+
+	// scryptN and scryptP are the scrypt params of the new key files
+	scryptN int
+	scryptP int
+}
+
+// NewLocalSigner returns the signer of the key files in the directory, the directory is created if it doesn't exist
+func NewLocalSigner(dir string) (*LocalSigner, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create keystore directory (%s), %w", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read keystore directory (%s), %w", dir, err)
+	}
+
+	s := &LocalSigner{
+		dir:      dir,
+		accounts: make(map[types.Address]*localAccount, len(entries)),
+		scryptN:  1 << 18,
+		scryptP:  1,
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read key file (%s), %w", path, err)
+		}
+
+		address, err := keystore.ReadKeyAddress(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file (%s), %w", path, err)
+		}
+
+		addr := types.StringToAddress(address)
+		s.accounts[addr] = &localAccount{address: addr, path: path}
+	}
+
+	return s, nil
+}
+
+// Accounts returns the addresses of the accounts, sorted
+func (s *LocalSigner) Accounts() []types.Address {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	addresses := make([]types.Address, 0, len(s.accounts))
+	for addr := range s.accounts {
+		addresses = append(addresses, addr)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return strings.Compare(addresses[i].String(), addresses[j].String()) < 0
+	})
+
+	return addresses
+}
+
+// NewAccount generates a new key and writes it to the key file encrypted by the password
+func (s *LocalSigner) NewAccount(password string) (types.Address, error) {
+	key, err := crypto.GenerateECDSAKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	raw, err := crypto.MarshalECDSAPrivateKey(key)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	addr := crypto.PubKeyToAddress(&key.PublicKey)
+
+	content, err := keystore.EncryptKey(raw, addr.String(), password, s.scryptN, s.scryptP)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	// the same naming as the other clients, so the key files can be moved between them
+	name := fmt.Sprintf(
+		"UTC--%s--%s",
+		time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"),
+		strings.TrimPrefix(strings.ToLower(addr.String()), "0x"),
+	)
+	path := filepath.Join(s.dir, name)
+
+	if err := os.WriteFile(path, content, keyFilePerm); err != nil {
+		return types.ZeroAddress, fmt.Errorf("unable to write key file (%s), %w", path, err)
+	}
+
+	s.lock.Lock()
+	s.accounts[addr] = &localAccount{address: addr, path: path}
+	s.lock.Unlock()
+
+	return addr, nil
+}
+
+// Unlock decrypts the key of the account, which stays unlocked for the duration, or until it's locked if it's zero
+func (s *LocalSigner) Unlock(addr types.Address, password string, duration time.Duration) error {
+	s.lock.Lock()
+	account, ok := s.accounts[addr]
+	s.lock.Unlock()
+
+	if !ok {
+		return errUnknownAccount
+	}
+
+	content, err := os.ReadFile(account.path)
+	if err != nil {
+		return fmt.Errorf("unable to read key file (%s), %w", account.path, err)
+	}
+
+	// the key is decrypted without the lock, as it takes a while by design
+	raw, err := keystore.DecryptKey(content, password)
+	if err != nil {
+		return err
+	}
+
+	key, err := crypto.ParseECDSAPrivateKey(raw)
+	if err != nil {
+		return err
+	}
+
+	if crypto.PubKeyToAddress(&key.PublicKey) != addr {
+		return errKeyAddressMatch
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if account.timer != nil {
+		account.timer.Stop()
+		account.timer = nil
+	}
+
+	account.key = key
+
+	if duration > 0 {
+		var timer *time.Timer
+
+		timer = time.AfterFunc(duration, func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			// the account was unlocked again in the meantime
+			if account.timer != timer {
+				return
+			}
+
+			account.key = nil
+			account.timer = nil
+		})
+
+		account.timer = timer
+	}
+
+	return nil
+}
+
+// Lock drops the decrypted key of the account
+func (s *LocalSigner) Lock(addr types.Address) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	account, ok := s.accounts[addr]
+	if !ok {
+		return errUnknownAccount
+	}
+
+	if account.timer != nil {
+		account.timer.Stop()
+		account.timer = nil
+	}
+
+	account.key = nil
+
+	return nil
+}
+
+// unlockedKey returns the key of the unlocked account
+func (s *LocalSigner) unlockedKey(addr types.Address) (*ecdsa.PrivateKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	account, ok := s.accounts[addr]
+	if !ok {
+		return nil, errUnknownAccount
+	}
+
+	if account.key == nil {
+		return nil, errAccountLocked
+	}
+
+	return account.key, nil
+}
+
+// SignHash signs the hash by the key of the unlocked account, the signature is in the [R || S || V] form
+// where V is 27 or 28, as expected by the ecrecover of the contracts
+func (s *LocalSigner) SignHash(addr types.Address, hash []byte) ([]byte, error) {
+	key, err := s.unlockedKey(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(key, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	sig[64] += 27
+
+	return sig, nil
+}
+
+// SignTx signs the transaction by the key of the unlocked sender with the signer of the forks
+func (s *LocalSigner) SignTx(tx *types.Transaction, forks chain.ForksInTime, chainID uint64) (*types.Transaction, error) {
+	key, err := s.unlockedKey(tx.From)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Type == types.DynamicFeeTx || tx.Type == types.AccessListTx {
+		tx.ChainID = new(big.Int).SetUint64(chainID)
+	}
+
+	return crypto.NewSigner(forks, chainID).SignTx(tx, key)
+}
@@ -0,0 +1,118 @@
+package jsonrpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const testPassword = "password"
+
+// newTestLocalSigner returns the signer of the temporary directory, its key files are encrypted
+// with the light scrypt params, so the tests don't spend seconds on every unlock
+func newTestLocalSigner(t *testing.T) *LocalSigner {
+	t.Helper()
+
+	signer, err := NewLocalSigner(t.TempDir())
+	require.NoError(t, err)
+
+	signer.scryptN = 1 << 4
+
+	return signer
+}
+
+func TestLocalSigner_NewAccount(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestLocalSigner(t)
+	assert.Empty(t, signer.Accounts())
+
+	addr, err := signer.NewAccount(testPassword)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{addr}, signer.Accounts())
+
+	// the key file is loaded by the new signer of the directory
+	reloaded, err := NewLocalSigner(signer.dir)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{addr}, reloaded.Accounts())
+
+	require.NoError(t, reloaded.Unlock(addr, testPassword, 0))
+
+	// the invalid key file fails the loading
+	require.NoError(t, os.WriteFile(filepath.Join(signer.dir, "invalid"), []byte("{}"), keyFilePerm))
+
+	_, err = NewLocalSigner(signer.dir)
+	require.Error(t, err)
+}
+
+func TestLocalSigner_UnlockLock(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestLocalSigner(t)
+
+	addr, err := signer.NewAccount(testPassword)
+	require.NoError(t, err)
+
+	hash := crypto.Keccak256([]byte("message"))
+
+	_, err = signer.SignHash(addr, hash)
+	require.ErrorIs(t, err, errAccountLocked)
+
+	require.Error(t, signer.Unlock(addr, "wrong", 0))
+	require.ErrorIs(t, signer.Unlock(types.StringToAddress("1"), testPassword, 0), errUnknownAccount)
+
+	require.NoError(t, signer.Unlock(addr, testPassword, 0))
+
+	sig, err := signer.SignHash(addr, hash)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	// the recovery id is expected without the offset
+	sig[64] -= 27
+
+	pub, err := crypto.Ecrecover(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, addr, types.BytesToAddress(crypto.Keccak256(pub[1:])[12:]))
+
+	require.NoError(t, signer.Lock(addr))
+
+	_, err = signer.SignHash(addr, hash)
+	require.ErrorIs(t, err, errAccountLocked)
+}
+
+func TestLocalSigner_UnlockExpiry(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestLocalSigner(t)
+
+	addr, err := signer.NewAccount(testPassword)
+	require.NoError(t, err)
+
+	require.NoError(t, signer.Unlock(addr, testPassword, 50*time.Millisecond))
+
+	_, err = signer.unlockedKey(addr)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := signer.unlockedKey(addr)
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// the unlock without the duration replaces the expiring one
+	require.NoError(t, signer.Unlock(addr, testPassword, 50*time.Millisecond))
+	require.NoError(t, signer.Unlock(addr, testPassword, 0))
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = signer.unlockedKey(addr)
+	require.NoError(t, err)
+}
@@ -0,0 +1,72 @@
+package jsonrpc
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+// slowRequestParamsLimit is the maximum length of the params logged for the slow requests
+const slowRequestParamsLimit = 256
+
+// unknownMethod labels the requests of the methods which don't exist,
+// so the arbitrary method names don't blow up the number of the series
+const unknownMethod = "unknown"
+
+// observeRequest records the metrics of the handled request and logs it if it's slow
+func (d *Dispatcher) observeRequest(
+	req Request,
+	transport serverType,
+	duration time.Duration,
+	response []byte,
+	err Error,
+) {
+	method := req.Method
+	if _, ok := err.(*methodNotFoundError); ok {
+		method = unknownMethod
+	}
+
+	namespace, _, _ := strings.Cut(method, "_")
+	labels := []metrics.Label{
+		{Name: "transport", Value: transport.String()},
+		{Name: "namespace", Value: namespace},
+		{Name: "method", Value: method},
+	}
+
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetric, "requests"}, 1, labels)
+	metrics.AddSampleWithLabels([]string{jsonRPCMetric, "request_duration"}, float32(duration.Seconds()), labels)
+	metrics.AddSampleWithLabels([]string{jsonRPCMetric, "response_size"}, float32(len(response)), labels)
+
+	if err != nil {
+		metrics.IncrCounterWithLabels([]string{jsonRPCMetric, "errors"}, 1,
+			append(labels, metrics.Label{Name: "code", Value: strconv.Itoa(err.ErrorCode())}))
+	}
+
+	if threshold := d.params.slowRequestThreshold; threshold != 0 && duration >= threshold {
+		params := string(req.Params)
+		if len(params) > slowRequestParamsLimit {
+			params = params[:slowRequestParamsLimit] + "..."
+		}
+
+		d.logger.Warn("slow request",
+			"method", req.Method,
+			"params", params,
+			"duration", duration,
+			"transport", transport,
+		)
+	}
+}
+
+// observeCacheLookup counts the lookup of the request in the response cache
+func observeCacheLookup(method string, hit bool) {
+	name := "cache_misses"
+	if hit {
+		name = "cache_hits"
+	}
+
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetric, name}, 1, []metrics.Label{
+		{Name: "method", Value: method},
+	})
+}
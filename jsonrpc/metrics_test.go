@@ -0,0 +1,146 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMetricsSink replaces the global metrics with the ones collected in memory
+func newTestMetricsSink(t *testing.T) *metrics.InmemSink {
+	t.Helper()
+
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+
+	conf := metrics.DefaultConfig("")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+
+	_, err := metrics.NewGlobal(conf, sink)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, err := metrics.NewGlobal(conf, &metrics.BlackholeSink{})
+		require.NoError(t, err)
+	})
+
+	return sink
+}
+
+// the test is not parallel, as the metrics are global
+func TestDispatcher_RequestMetrics(t *testing.T) {
+	sink := newTestMetricsSink(t)
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			httpPolicy:              &MethodPolicy{Deny: []string{"txpool"}},
+		},
+	)
+	mockConn := &mockWsConn{
+		SetFilterIDFn:  func(s string) {},
+		GetFilterIDFn:  func() string { return "" },
+		WriteMessageFn: func(i int, b []byte) error { return nil },
+	}
+
+	key := func(name, transport, method string) string {
+		namespace, _, _ := strings.Cut(method, "_")
+
+		return fmt.Sprintf("json_rpc.%s;transport=%s;namespace=%s;method=%s", name, transport, namespace, method)
+	}
+
+	request := func(method string) []byte {
+		return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, method))
+	}
+
+	_, err := dispatcher.Handle(request("net_version"))
+	require.NoError(t, err)
+
+	_, err = dispatcher.Handle([]byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"net_version","params":[]},
+		{"jsonrpc":"2.0","id":2,"method":"eth_random1","params":[]},
+		{"jsonrpc":"2.0","id":3,"method":"eth_random2","params":[]}
+	]`))
+	require.NoError(t, err)
+
+	_, err = dispatcher.Handle(request("txpool_status"))
+	require.NoError(t, err)
+
+	_, err = dispatcher.HandleWs(request("net_version"), mockConn)
+	require.NoError(t, err)
+
+	_, err = dispatcher.HandleWs([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`), mockConn)
+	require.NoError(t, err)
+
+	data := sink.Data()
+	require.Len(t, data, 1)
+
+	counters, samples, gauges := data[0].Counters, data[0].Samples, data[0].Gauges
+
+	count := func(key string) int {
+		return counters[key].Count
+	}
+
+	assert.Equal(t, 2, count(key("requests", "http", "net_version")))
+	assert.Equal(t, 1, count(key("requests", "ws", "net_version")))
+	assert.Equal(t, 1, count(key("requests", "ws", "eth_subscribe")))
+
+	// the unknown methods share the same series
+	assert.Equal(t, 2, count(key("requests", "http", unknownMethod)))
+	assert.Equal(t, 2, count(key("errors", "http", unknownMethod)+";code=-32601"))
+	assert.Equal(t, 1, count(key("errors", "http", "txpool_status")+";code=-32601"))
+
+	// the duration and the size are sampled for every request
+	assert.Equal(t, 2, samples[key("request_duration", "http", "net_version")].Count)
+	assert.Equal(t, 1, samples[key("response_size", "ws", "eth_subscribe")].Count)
+
+	// the time of the endpoint functions is still measured per method
+	assert.Contains(t, gauges, "json_rpc.net_version_time")
+}
+
+func TestDispatcher_SlowRequestLogging(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Output: &buf,
+		Level:  hclog.Warn,
+	})
+
+	dispatcher := newTestDispatcher(t,
+		logger,
+		newMockStore(),
+		&dispatcherParams{
+			slowRequestThreshold: time.Hour,
+		},
+	)
+
+	params := fmt.Sprintf("[%s]", strings.Repeat("1", 2*slowRequestParamsLimit))
+	req := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"web3_sha3","params":%s}`, params))
+
+	// the fast requests are not logged
+	_, err := dispatcher.Handle(req)
+	require.NoError(t, err)
+	require.Empty(t, buf.String())
+
+	dispatcher.params.slowRequestThreshold = time.Nanosecond
+
+	_, err = dispatcher.Handle(req)
+	require.NoError(t, err)
+
+	logged := buf.String()
+	assert.Contains(t, logged, "slow request")
+	assert.Contains(t, logged, "method=web3_sha3")
+	assert.Contains(t, logged, params[:slowRequestParamsLimit]+"...")
+	assert.NotContains(t, logged, params[:slowRequestParamsLimit+1])
+}
@@ -136,6 +136,10 @@ func (m *mockStore) Header() *types.Header {
 	return m.header
 }
 
+func (m *mockStore) FinalizedHeader() (*types.Header, error) {
+	return m.header, nil
+}
+
 func (m *mockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
 	m.receiptsLock.Lock()
 	defer m.receiptsLock.Unlock()
@@ -157,6 +161,10 @@ func (m *mockStore) TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *pr
 	return m.txPoolChannel, txPoolUnsubscribe, nil
 }
 
+func (m *mockStore) GetLogCandidates(from, to uint64, filters [][][]byte) ([]uint64, uint64, error) {
+	return nil, from, nil
+}
+
 func (m *mockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	header := m.headerLoop(func(header *types.Header) bool {
 		return header.Number == num
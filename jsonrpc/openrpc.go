@@ -0,0 +1,321 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
+)
+
+// openRPCVersion is the version of the OpenRPC specification the document follows
+const openRPCVersion = "1.2.6"
+
+const (
+	// openRPCMethod is the method returning the OpenRPC document
+	openRPCMethod = "rpc_discover"
+
+	// openRPCPath is the HTTP path serving the OpenRPC document
+	openRPCPath = "/openrpc.json"
+)
+
+// schema is a JSON schema of a param or a result
+type schema map[string]interface{}
+
+// OpenRPCDocument describes the methods served by the node, see https://spec.open-rpc.org
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []*OpenRPCMethod  `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenRPCMethod struct {
+	Name   string                      `json:"name"`
+	Params []*OpenRPCContentDescriptor `json:"params"`
+	Result *OpenRPCContentDescriptor   `json:"result"`
+}
+
+// OpenRPCContentDescriptor describes a param or a result of the method
+type OpenRPCContentDescriptor struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Schema   schema `json:"schema"`
+}
+
+type OpenRPCComponents struct {
+	Schemas map[string]schema `json:"schemas"`
+}
+
+var (
+	hexPattern      = "^0x[0-9a-fA-F]*$"
+	quantityPattern = "^0x([1-9a-fA-F][0-9a-fA-F]*|0)$"
+
+	quantitySchema = schema{"type": "string", "pattern": quantityPattern}
+	hashSchema     = schema{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+	addressSchema  = schema{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	bytesSchema    = schema{"type": "string", "pattern": hexPattern}
+
+	blockNumberSchema = schema{"oneOf": []schema{
+		quantitySchema,
+		{"type": "string", "enum": []string{earliest, latest, pending, finalized, safe}},
+	}}
+
+	// knownSchemas are the schemas of the types which are decoded by their own unmarshalers
+	knownSchemas = map[reflect.Type]schema{
+		reflect.TypeOf(types.Hash{}):      hashSchema,
+		reflect.TypeOf(types.Address{}):   addressSchema,
+		reflect.TypeOf(argBytes{}):        bytesSchema,
+		reflect.TypeOf(argUint64(0)):      quantitySchema,
+		reflect.TypeOf(argBig{}):          quantitySchema,
+		reflect.TypeOf(json.RawMessage{}): {},
+		reflect.TypeOf(BlockNumber(0)):    blockNumberSchema,
+		reflect.TypeOf(BlockNumberOrHash{}): {"oneOf": []schema{
+			blockNumberSchema,
+			hashSchema,
+			{
+				"type": "object",
+				"properties": schema{
+					"blockNumber": blockNumberSchema,
+					"blockHash":   hashSchema,
+				},
+			},
+		}},
+		reflect.TypeOf(LogQuery{}): {
+			"type": "object",
+			"properties": schema{
+				"fromBlock": blockNumberSchema,
+				"toBlock":   blockNumberSchema,
+				"blockHash": hashSchema,
+				"address": schema{"oneOf": []schema{
+					addressSchema,
+					{"type": "array", "items": addressSchema},
+				}},
+				"topics": schema{"type": "array", "items": schema{"oneOf": []schema{
+					{"type": "null"},
+					hashSchema,
+					{"type": "array", "items": hashSchema},
+				}}},
+			},
+		},
+	}
+
+	// paramNames are the names of the params of the types whose names don't describe the param
+	paramNames = map[reflect.Type]string{
+		reflect.TypeOf(types.Hash{}):    "hash",
+		reflect.TypeOf(types.Address{}): "address",
+		reflect.TypeOf(argBytes{}):      "data",
+		reflect.TypeOf(argUint64(0)):    "quantity",
+		reflect.TypeOf(argBig{}):        "quantity",
+	}
+)
+
+// openRPCDocument returns the OpenRPC document of the methods served by the dispatcher
+func (d *Dispatcher) openRPCDocument() *OpenRPCDocument {
+	return d.endpoints.RPC.document
+}
+
+// generateOpenRPCDocument generates the OpenRPC document of the registered methods
+// from the types of their params and results
+func (d *Dispatcher) generateOpenRPCDocument() *OpenRPCDocument {
+	g := &schemaGenerator{
+		schemas: make(map[string]schema),
+		names:   make(map[reflect.Type]string),
+	}
+
+	doc := &OpenRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: OpenRPCInfo{
+			Title:   "Polygon Edge JSON-RPC API",
+			Version: versioning.Version,
+		},
+		Methods:    make([]*OpenRPCMethod, 0),
+		Components: OpenRPCComponents{Schemas: g.schemas},
+	}
+
+	for serviceName, service := range d.serviceMap {
+		for funcName, fd := range service.funcMap {
+			doc.Methods = append(doc.Methods, g.method(serviceName+"_"+funcName, fd))
+		}
+	}
+
+	sort.Slice(doc.Methods, func(i, j int) bool {
+		return doc.Methods[i].Name < doc.Methods[j].Name
+	})
+
+	return doc
+}
+
+// schemaGenerator generates the schemas of the types, the schemas of the structs
+// are put into the components and referenced, so the recursive types are supported
+type schemaGenerator struct {
+	schemas map[string]schema
+	names   map[reflect.Type]string
+}
+
+func (g *schemaGenerator) method(name string, fd *funcData) *OpenRPCMethod {
+	m := &OpenRPCMethod{
+		Name:   name,
+		Params: make([]*OpenRPCContentDescriptor, 0, fd.numParams()),
+		Result: &OpenRPCContentDescriptor{
+			Name:   "result",
+			Schema: g.schema(fd.fv.Type().Out(0)),
+		},
+	}
+
+	// the first argument is the receiver
+	params := fd.reqt[1:]
+
+	// the trailing pointer params may be omitted, they are left nil
+	required := len(params)
+	for required > 0 && params[required-1].Kind() == reflect.Ptr {
+		required--
+	}
+
+	used := make(map[string]int)
+
+	for i, typ := range params {
+		paramName := typeParamName(typ)
+
+		// the params of the same type are numbered
+		if used[paramName]++; used[paramName] > 1 {
+			paramName = fmt.Sprintf("%s%d", paramName, used[paramName])
+		}
+
+		m.Params = append(m.Params, &OpenRPCContentDescriptor{
+			Name:     paramName,
+			Required: i < required,
+			Schema:   g.schema(typ),
+		})
+	}
+
+	return m
+}
+
+func (g *schemaGenerator) schema(typ reflect.Type) schema {
+	if typ.Kind() == reflect.Ptr {
+		return g.schema(typ.Elem())
+	}
+
+	if s, ok := knownSchemas[typ]; ok {
+		return s
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return bytesSchema
+		}
+
+		return schema{"type": "array", "items": g.schema(typ.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": g.schema(typ.Elem())}
+	case reflect.Struct:
+		return g.structSchema(typ)
+	default:
+		// the interfaces are encoded as any value
+		return schema{}
+	}
+}
+
+// structSchema puts the schema of the struct into the components and returns the reference to it
+func (g *schemaGenerator) structSchema(typ reflect.Type) schema {
+	if typ.Name() == "" {
+		return g.objectSchema(typ)
+	}
+
+	name, ok := g.names[typ]
+	if !ok {
+		name = typ.Name()
+		if _, taken := g.schemas[name]; taken {
+			name = path.Base(typ.PkgPath()) + name
+		}
+
+		g.names[typ] = name
+
+		// the placeholder stops the recursion of the self-referencing types
+		g.schemas[name] = schema{}
+		g.schemas[name] = g.objectSchema(typ)
+	}
+
+	return schema{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *schemaGenerator) objectSchema(typ reflect.Type) schema {
+	properties := schema{}
+
+	g.addProperties(typ, properties)
+
+	return schema{"type": "object", "properties": properties}
+}
+
+// addProperties adds the fields of the struct as they are encoded to JSON, the fields
+// of the embedded structs are promoted and the fields without the tag keep their names
+func (g *schemaGenerator) addProperties(typ reflect.Type, properties schema) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addProperties(field.Type, properties)
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			// the field names are matched case-insensitively, the clients use the lower camel case
+			name = lowerCaseFirst(field.Name)
+		}
+
+		properties[name] = g.schema(field.Type)
+	}
+}
+
+// typeParamName returns the name of the param of the given type, as the names are not known by the reflection
+func typeParamName(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if name, ok := paramNames[typ]; ok {
+		return name
+	}
+
+	if typ.Name() != "" {
+		return lowerCaseFirst(typ.Name())
+	}
+
+	if typ.Kind() == reflect.Slice {
+		return typeParamName(typ.Elem()) + "List"
+	}
+
+	return typ.Kind().String()
+}
@@ -0,0 +1,99 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Discover(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	res, err := dispatcher.Handle([]byte(`{"jsonrpc":"2.0","id":1,"method":"rpc_discover","params":[]}`))
+	require.NoError(t, err)
+
+	resp := &SuccessResponse{}
+	require.NoError(t, json.Unmarshal(res, resp))
+	require.Nil(t, resp.Error)
+
+	doc := &OpenRPCDocument{}
+	require.NoError(t, json.Unmarshal(resp.Result, doc))
+
+	assert.Equal(t, openRPCVersion, doc.OpenRPC)
+
+	methods := make(map[string]*OpenRPCMethod, len(doc.Methods))
+	for _, m := range doc.Methods {
+		methods[m.Name] = m
+	}
+
+	// every registered method is described
+	for serviceName, service := range dispatcher.serviceMap {
+		for funcName := range service.funcMap {
+			assert.Contains(t, methods, serviceName+"_"+funcName)
+		}
+	}
+
+	require.Contains(t, methods, "bridge_generateExitProof")
+	require.Contains(t, methods, "rpc_discover")
+
+	exitProof := methods["bridge_generateExitProof"]
+	require.Len(t, exitProof.Params, 1)
+	assert.Equal(t, "quantity", exitProof.Params[0].Name)
+	assert.True(t, exitProof.Params[0].Required)
+	assert.Equal(t, quantityPattern, exitProof.Params[0].Schema["pattern"])
+
+	getBlock := methods["eth_getBlockByNumber"]
+	require.Len(t, getBlock.Params, 2)
+	assert.Equal(t, "blockNumber", getBlock.Params[0].Name)
+	assert.True(t, getBlock.Params[0].Required)
+	assert.Equal(t, "bool", getBlock.Params[1].Name)
+
+	// only the trailing pointer params are optional
+	call := methods["eth_call"]
+	require.Len(t, call.Params, 4)
+	assert.True(t, call.Params[0].Required)
+	assert.True(t, call.Params[1].Required)
+	assert.False(t, call.Params[2].Required)
+	assert.False(t, call.Params[3].Required)
+	assert.Equal(t, "#/components/schemas/txnArgs", call.Params[0].Schema["$ref"])
+
+	// the structs are described by the components, the untagged fields use the lower camel case
+	txnArgs, ok := doc.Components.Schemas["txnArgs"]
+	require.True(t, ok)
+	assert.Contains(t, txnArgs["properties"], "gasPrice")
+	assert.Contains(t, txnArgs["properties"], "accessList")
+}
+
+func TestJSONRPC_OpenRPCRoute(t *testing.T) {
+	t.Parallel()
+
+	j, err := newTestJSONRPC(t)
+	require.NoError(t, err)
+
+	request := func(method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		j.handleOpenRPC(w, httptest.NewRequest(method, openRPCPath, nil))
+
+		return w
+	}
+
+	w := request(http.MethodGet)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	doc := &OpenRPCDocument{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+	assert.NotEmpty(t, doc.Methods)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, request(http.MethodPost).Code)
+
+	// the route follows the policy of rpc_discover
+	j.config.HTTPPolicy = &MethodPolicy{Deny: []string{"rpc"}}
+	assert.Equal(t, http.StatusNotFound, request(http.MethodGet).Code)
+}
@@ -0,0 +1,981 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// otsAPILevel is the level of the Otterscan API served by the ots namespace
+const otsAPILevel = 8
+
+// otsSearchMaxBlocks is the default maximum number of the blocks scanned by a single search call
+// if the address index isn't available, the search is resumed from the returned next block
+const otsSearchMaxBlocks = 10000
+
+// types of the internal operations returned by ots_getInternalOperations
+const (
+	otsOpTransfer = iota
+	otsOpSelfDestruct
+	otsOpCreate
+	otsOpCreate2
+)
+
+var (
+	// ErrInvalidPageSize is an error returned when the requested page size is zero
+	ErrInvalidPageSize = errors.New("invalid page size")
+)
+
+// otsStore provides the methods needed by the ots namespace, the transactions
+// are replayed by the flat call tracer the same way as by the trace namespace
+type otsStore interface {
+	debugStore
+
+	// GetCode returns the code of the account at the given state root
+	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+}
+
+// addressIndexStore is implemented by the stores maintaining the index of the transactions
+// by the addresses they touched, the transactions of an address are ordered by the blocks
+type addressIndexStore interface {
+	// AddressIndexHead returns the number of the last indexed block, false if the index is disabled
+	AddressIndexHead() (uint64, bool)
+
+	// GetAddressTxsCount returns the number of the indexed transactions of the address
+	GetAddressTxsCount(addr types.Address) uint64
+
+	// GetAddressTx returns the indexed transaction of the address at the given position
+	GetAddressTx(addr types.Address, index uint64) (*storage.AddressTx, error)
+}
+
+// Ots is the Otterscan jsonrpc endpoint
+type Ots struct {
+	store      otsStore
+	index      addressIndexStore
+	throttling *Throttling
+
+	// searchMaxBlocks is the maximum number of the blocks scanned by a single search call
+	searchMaxBlocks int
+}
+
+func NewOts(store otsStore, requestsPerSecond uint64) *Ots {
+	// the transactions are searched by scanning the blocks if the store doesn't maintain the index
+	index, _ := store.(addressIndexStore)
+
+	return &Ots{
+		store:           store,
+		index:           index,
+		throttling:      NewThrottling(requestsPerSecond, time.Second),
+		searchMaxBlocks: otsSearchMaxBlocks,
+	}
+}
+
+// otsInternalOperation is an internal value transfer, self-destruct or contract creation of a transaction
+type otsInternalOperation struct {
+	Type  int           `json:"type"`
+	From  types.Address `json:"from"`
+	To    types.Address `json:"to"`
+	Value string        `json:"value"`
+}
+
+// otsTrace is a call of a transaction returned by ots_traceTransaction
+type otsTrace struct {
+	Type   string        `json:"type"`
+	Depth  int           `json:"depth"`
+	From   types.Address `json:"from"`
+	To     types.Address `json:"to"`
+	Value  *string       `json:"value"`
+	Input  string        `json:"input"`
+	Output string        `json:"output"`
+}
+
+// otsBlock is the block returned by the ots namespace together with the number of its transactions
+type otsBlock struct {
+	*block
+	LogsBloom        *types.Bloom `json:"logsBloom"`
+	TransactionCount argUint64    `json:"transactionCount"`
+}
+
+// otsIssuance is the issuance of the block, the native token isn't issued by the block rewards
+type otsIssuance struct {
+	BlockReward argBig `json:"blockReward"`
+	UncleReward argBig `json:"uncleReward"`
+	Issuance    argBig `json:"issuance"`
+}
+
+// otsBlockDetails is the result of ots_getBlockDetails
+type otsBlockDetails struct {
+	Block     *otsBlock   `json:"block"`
+	Issuance  otsIssuance `json:"issuance"`
+	TotalFees argBig      `json:"totalFees"`
+}
+
+// otsBlockTransactions is the result of ots_getBlockTransactions
+type otsBlockTransactions struct {
+	FullBlock *otsBlock     `json:"fullblock"`
+	Receipts  []*otsReceipt `json:"receipts"`
+}
+
+// otsReceipt is the receipt returned by the ots namespace, without the logs
+// and with the effective gas price of the transaction and the timestamp of the block
+type otsReceipt struct {
+	*receipt
+	Logs              []*Log       `json:"logs"`
+	LogsBloom         *types.Bloom `json:"logsBloom"`
+	EffectiveGasPrice argBig       `json:"effectiveGasPrice"`
+	Timestamp         argUint64    `json:"timestamp"`
+}
+
+// otsSearchResult is the result of ots_searchTransactionsBefore and ots_searchTransactionsAfter,
+// the transactions are ordered from the most recent one
+type otsSearchResult struct {
+	Txs       []*transaction `json:"txs"`
+	Receipts  []*otsReceipt  `json:"receipts"`
+	FirstPage bool           `json:"firstPage"`
+	LastPage  bool           `json:"lastPage"`
+
+	// NextBlock is the block number to resume the search from, if the scan of the blocks
+	// is stopped by otsSearchMaxBlocks before the page is full
+	NextBlock *argUint64 `json:"nextBlock,omitempty"`
+}
+
+// otsContractCreator is the result of ots_getContractCreator
+type otsContractCreator struct {
+	Hash    types.Hash    `json:"hash"`
+	Creator types.Address `json:"creator"`
+}
+
+// GetApiLevel returns the level of the Otterscan API supported by the node (ots_getApiLevel)
+//
+//nolint:stylecheck
+func (o *Ots) GetApiLevel() (interface{}, error) {
+	return otsAPILevel, nil
+}
+
+// HasCode returns true if the address has code at the given block (ots_hasCode)
+func (o *Ots) HasCode(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, o.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.hasCode(header, address)
+}
+
+// GetInternalOperations returns the value transfers, the self-destructs and the contract creations
+// made by the contracts called by the transaction, the reverted ones are skipped (ots_getInternalOperations)
+func (o *Ots) GetInternalOperations(txHash types.Hash) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			traces, _, err := o.traceTransaction(txHash)
+			if err != nil {
+				return nil, err
+			}
+
+			var (
+				ops      = make([]*otsInternalOperation, 0)
+				reverted = revertedTraces(traces)
+			)
+
+			for idx, trace := range traces {
+				// the top-level call is the transaction itself
+				if len(trace.TraceAddress) == 0 || reverted[idx] {
+					continue
+				}
+
+				if op := toInternalOperation(trace); op != nil {
+					ops = append(ops, op)
+				}
+			}
+
+			return ops, nil
+		},
+	)
+}
+
+// TraceTransaction returns all the calls of the transaction in the order they are made (ots_traceTransaction)
+func (o *Ots) TraceTransaction(txHash types.Hash) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			traces, _, err := o.traceTransaction(txHash)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make([]*otsTrace, 0, len(traces))
+			for _, trace := range traces {
+				res = append(res, toOtsTrace(trace))
+			}
+
+			return res, nil
+		},
+	)
+}
+
+// GetTransactionError returns the revert data of the transaction,
+// it's empty if the transaction isn't reverted (ots_getTransactionError)
+func (o *Ots) GetTransactionError(txHash types.Hash) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			traces, output, err := o.traceTransaction(txHash)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(traces) == 0 || traces[0].Error == "" {
+				return argBytes{}, nil
+			}
+
+			return argBytes(output), nil
+		},
+	)
+}
+
+// GetBlockDetails returns the block without its transactions, together with the number
+// of the transactions and the fees paid by them (ots_getBlockDetails)
+func (o *Ots) GetBlockDetails(number BlockNumber) (interface{}, error) {
+	block, err := o.getBlock(number)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.getBlockDetails(block)
+}
+
+// GetBlockDetailsByHash is the same as ots_getBlockDetails for the block given by its hash (ots_getBlockDetailsByHash)
+func (o *Ots) GetBlockDetailsByHash(hash types.Hash) (interface{}, error) {
+	block, ok := o.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", hash)
+	}
+
+	return o.getBlockDetails(block)
+}
+
+// GetBlockTransactions returns the page of the transactions of the block together with their receipts,
+// the pages are counted from the last transaction of the block (ots_getBlockTransactions)
+func (o *Ots) GetBlockTransactions(number BlockNumber, pageNumber argUint64, pageSize argUint64) (interface{}, error) {
+	if pageSize == 0 {
+		return nil, ErrInvalidPageSize
+	}
+
+	block, err := o.getBlock(number)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := o.getReceipts(block)
+	if err != nil {
+		return nil, err
+	}
+
+	count := uint64(len(block.Transactions))
+	end := uint64(0)
+
+	if offset := uint64(pageNumber) * uint64(pageSize); offset < count {
+		end = count - offset
+	}
+
+	start := uint64(0)
+	if end > uint64(pageSize) {
+		start = end - uint64(pageSize)
+	}
+
+	fullBlock := newOtsBlock(block, true)
+	fullBlock.Transactions = fullBlock.Transactions[start:end]
+
+	res := &otsBlockTransactions{
+		FullBlock: fullBlock,
+		Receipts:  make([]*otsReceipt, 0, end-start),
+	}
+
+	for idx := start; idx < end; idx++ {
+		res.Receipts = append(res.Receipts, toOtsReceipt(receipts[idx], block.Transactions[idx], idx, block.Header))
+	}
+
+	return res, nil
+}
+
+// SearchTransactionsBefore returns the transactions sent by, sent to or creating the address in the blocks
+// before the given one, or before the head if it's zero. The whole blocks are searched, so the page may
+// have more transactions than the page size. If the address index is caught up with the chain,
+// the transactions touching the address by the internal calls are returned as well, otherwise at most
+// otsSearchMaxBlocks blocks are scanned and the search continues before nextBlock (ots_searchTransactionsBefore)
+func (o *Ots) SearchTransactionsBefore(
+	address types.Address,
+	blockNumber argUint64,
+	pageSize argUint64,
+) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			if pageSize == 0 {
+				return nil, ErrInvalidPageSize
+			}
+
+			head := o.store.Header().Number
+
+			next := head + 1
+			if blockNumber != 0 && uint64(blockNumber) < next {
+				next = uint64(blockNumber)
+			}
+
+			res := newOtsSearchResult(blockNumber == 0)
+
+			if o.isIndexed(head) {
+				lastPage, err := o.searchIndexBefore(address, next, uint64(pageSize), res)
+				if err != nil {
+					return nil, err
+				}
+
+				res.LastPage = lastPage
+
+				return res, nil
+			}
+
+			for scanned := 0; next > 0 && uint64(len(res.Txs)) < uint64(pageSize); next-- {
+				if scanned == o.searchMaxBlocks {
+					res.NextBlock = argUintPtr(next)
+
+					break
+				}
+
+				if err := o.searchBlock(address, next-1, res, true); err != nil {
+					return nil, err
+				}
+
+				scanned++
+			}
+
+			res.LastPage = next == 0
+
+			return res, nil
+		},
+	)
+}
+
+// SearchTransactionsAfter returns the transactions sent by, sent to or creating the address in the blocks
+// after the given one, or after the genesis if it's zero. The whole blocks are searched, so the page may
+// have more transactions than the page size. If the address index is caught up with the chain,
+// the transactions touching the address by the internal calls are returned as well, otherwise at most
+// otsSearchMaxBlocks blocks are scanned and the search continues after nextBlock (ots_searchTransactionsAfter)
+func (o *Ots) SearchTransactionsAfter(
+	address types.Address,
+	blockNumber argUint64,
+	pageSize argUint64,
+) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			if pageSize == 0 {
+				return nil, ErrInvalidPageSize
+			}
+
+			var (
+				head = o.store.Header().Number
+				next = uint64(blockNumber) + 1
+				res  = newOtsSearchResult(false)
+			)
+
+			if o.isIndexed(head) {
+				firstPage, err := o.searchIndexAfter(address, next, uint64(pageSize), res)
+				if err != nil {
+					return nil, err
+				}
+
+				res.FirstPage = firstPage
+			} else {
+				for scanned := 0; next <= head && uint64(len(res.Txs)) < uint64(pageSize); next++ {
+					if scanned == o.searchMaxBlocks {
+						res.NextBlock = argUintPtr(next - 1)
+
+						break
+					}
+
+					if err := o.searchBlock(address, next, res, false); err != nil {
+						return nil, err
+					}
+
+					scanned++
+				}
+
+				res.FirstPage = next > head
+			}
+
+			res.LastPage = blockNumber == 0
+
+			// the most recent transactions go first
+			for i, j := 0, len(res.Txs)-1; i < j; i, j = i+1, j-1 {
+				res.Txs[i], res.Txs[j] = res.Txs[j], res.Txs[i]
+				res.Receipts[i], res.Receipts[j] = res.Receipts[j], res.Receipts[i]
+			}
+
+			return res, nil
+		},
+	)
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the transaction sent by the address
+// with the given nonce, or null if it isn't mined (ots_getTransactionBySenderAndNonce)
+func (o *Ots) GetTransactionBySenderAndNonce(address types.Address, nonce argUint64) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			head := o.store.Header()
+
+			headNonce, err := o.getNonce(head, address)
+			if err != nil || headNonce <= uint64(nonce) {
+				return nil, err
+			}
+
+			// find the block whose state is the first one with the greater nonce,
+			// the transaction is in the block
+			number, err := o.searchBlockNumber(head.Number, func(header *types.Header) (bool, error) {
+				accountNonce, err := o.getNonce(header, address)
+
+				return accountNonce > uint64(nonce), err
+			})
+			if err != nil || number == 0 {
+				return nil, err
+			}
+
+			block, ok := o.store.GetBlockByNumber(number, true)
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", number)
+			}
+
+			for _, tx := range block.Transactions {
+				if tx.From == address && tx.Nonce == uint64(nonce) {
+					return tx.Hash, nil
+				}
+			}
+
+			return nil, nil
+		},
+	)
+}
+
+// GetContractCreator returns the hash of the transaction which created the contract and the address
+// of its creator, or null if the address isn't a contract or it's created in the genesis (ots_getContractCreator)
+func (o *Ots) GetContractCreator(address types.Address) (interface{}, error) {
+	return o.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			head := o.store.Header()
+
+			ok, err := o.hasCode(head, address)
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			// find the block whose state is the first one with the code, the contract is created in the block
+			number, err := o.searchBlockNumber(head.Number, func(header *types.Header) (bool, error) {
+				return o.hasCode(header, address)
+			})
+			if err != nil || number == 0 {
+				return nil, err
+			}
+
+			block, ok := o.store.GetBlockByNumber(number, true)
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", number)
+			}
+
+			tracer, cancel := newFlatTracer()
+			defer cancel()
+
+			res, err := o.store.TraceBlock(block, tracer)
+			if err != nil {
+				return nil, err
+			}
+
+			for idx, txRes := range res {
+				traces, err := toFlatTraces(txRes)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, trace := range traces {
+					action, ok := trace.Action.(*flattracer.CreateAction)
+					if !ok {
+						continue
+					}
+
+					if result, ok := trace.Result.(*flattracer.CreateResult); ok && result.Address == address {
+						return &otsContractCreator{
+							Hash:    block.Transactions[idx].Hash,
+							Creator: action.From,
+						}, nil
+					}
+				}
+			}
+
+			return nil, nil
+		},
+	)
+}
+
+// traceTransaction returns the flat traces of the transaction and the output of its top-level call
+func (o *Ots) traceTransaction(txHash types.Hash) ([]*flattracer.Trace, []byte, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, o.store)
+	if tx == nil {
+		return nil, nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, nil, ErrTraceGenesisBlock
+	}
+
+	tracer, cancel := newFlatTracer()
+	defer cancel()
+
+	res, err := o.store.TraceTxn(block, tx.Hash, tracer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	traces, err := toFlatTraces(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return traces, tracer.Output(), nil
+}
+
+func (o *Ots) getBlock(number BlockNumber) (*types.Block, error) {
+	num, err := GetNumericBlockNumber(number, o.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := o.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	return block, nil
+}
+
+// getReceipts returns the receipts of the block, the receipts aren't stored for the blocks without transactions
+func (o *Ots) getReceipts(block *types.Block) ([]*types.Receipt, error) {
+	if len(block.Transactions) == 0 {
+		return []*types.Receipt{}, nil
+	}
+
+	receipts, err := o.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts for block with hash [%s] not found", block.Hash())
+	}
+
+	return receipts, nil
+}
+
+func (o *Ots) getBlockDetails(block *types.Block) (interface{}, error) {
+	receipts, err := o.getReceipts(block)
+	if err != nil {
+		return nil, err
+	}
+
+	totalFees := new(big.Int)
+
+	for idx, receipt := range receipts {
+		fee := block.Transactions[idx].GetGasPrice(block.Header.BaseFee)
+		totalFees.Add(totalFees, fee.Mul(fee, new(big.Int).SetUint64(receipt.GasUsed)))
+	}
+
+	details := &otsBlockDetails{
+		Block:     newOtsBlock(block, false),
+		TotalFees: argBig(*totalFees),
+	}
+
+	// the transactions are returned by ots_getBlockTransactions
+	details.Block.Transactions = []transactionOrHash{}
+
+	return details, nil
+}
+
+// searchBlock appends the transactions of the block which are sent by, sent to
+// or creating the address to the result, in the reverse order if requested
+func (o *Ots) searchBlock(address types.Address, number uint64, res *otsSearchResult, reverse bool) error {
+	block, ok := o.store.GetBlockByNumber(number, true)
+	if !ok {
+		return fmt.Errorf("block %d not found", number)
+	}
+
+	var receipts []*types.Receipt
+
+	for i := range block.Transactions {
+		idx := i
+		if reverse {
+			idx = len(block.Transactions) - 1 - i
+		}
+
+		tx := block.Transactions[idx]
+
+		// the address of the created contract is known from the receipt
+		if tx.From != address && tx.To != nil && *tx.To != address {
+			continue
+		}
+
+		if receipts == nil {
+			var err error
+
+			if receipts, err = o.getReceipts(block); err != nil {
+				return err
+			}
+		}
+
+		receipt := receipts[idx]
+		if tx.From != address && tx.To == nil &&
+			(receipt.ContractAddress == nil || *receipt.ContractAddress != address) {
+			continue
+		}
+
+		blockNumber, blockIdx := argUint64(block.Number()), idx
+
+		res.Txs = append(res.Txs, toTransaction(tx, &blockNumber, &block.Header.Hash, &blockIdx))
+		res.Receipts = append(res.Receipts, toOtsReceipt(receipt, tx, uint64(idx), block.Header))
+	}
+
+	return nil
+}
+
+// isIndexed returns true if the address index is enabled and caught up with the given head
+func (o *Ots) isIndexed(head uint64) bool {
+	if o.index == nil {
+		return false
+	}
+
+	indexHead, ok := o.index.AddressIndexHead()
+
+	return ok && indexHead >= head
+}
+
+// searchIndexBefore appends the indexed transactions of the address in the blocks before the given one
+// to the result, from the most recent one. It returns true if there are no more transactions left
+func (o *Ots) searchIndexBefore(address types.Address, number, pageSize uint64, res *otsSearchResult) (bool, error) {
+	end, err := o.searchAddressTx(address, func(addressTx *storage.AddressTx) bool {
+		return addressTx.BlockNumber >= number
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for end > 0 && uint64(len(res.Txs)) < pageSize {
+		addressTxs, err := o.readIndexedBlock(address, end-1, false)
+		if err != nil {
+			return false, err
+		}
+
+		end -= uint64(len(addressTxs))
+
+		if err := o.appendIndexedBlock(addressTxs, res); err != nil {
+			return false, err
+		}
+	}
+
+	return end == 0, nil
+}
+
+// searchIndexAfter appends the indexed transactions of the address in the blocks from the given one
+// to the result, from the oldest one. It returns true if there are no more transactions left
+func (o *Ots) searchIndexAfter(address types.Address, number, pageSize uint64, res *otsSearchResult) (bool, error) {
+	start, err := o.searchAddressTx(address, func(addressTx *storage.AddressTx) bool {
+		return addressTx.BlockNumber >= number
+	})
+	if err != nil {
+		return false, err
+	}
+
+	count := o.index.GetAddressTxsCount(address)
+
+	for start < count && uint64(len(res.Txs)) < pageSize {
+		addressTxs, err := o.readIndexedBlock(address, start, true)
+		if err != nil {
+			return false, err
+		}
+
+		start += uint64(len(addressTxs))
+
+		if err := o.appendIndexedBlock(addressTxs, res); err != nil {
+			return false, err
+		}
+	}
+
+	return start >= count, nil
+}
+
+// searchAddressTx returns the position of the first indexed transaction of the address which satisfies
+// the condition, the condition has to be satisfied by all the following transactions as well
+func (o *Ots) searchAddressTx(address types.Address, cond func(*storage.AddressTx) bool) (uint64, error) {
+	var searchErr error
+
+	pos := sort.Search(int(o.index.GetAddressTxsCount(address)), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		addressTx, err := o.index.GetAddressTx(address, uint64(i))
+		if err != nil {
+			searchErr = err
+
+			return true
+		}
+
+		return cond(addressTx)
+	})
+
+	return uint64(pos), searchErr
+}
+
+// readIndexedBlock reads the indexed transactions of the address in the same block as the one
+// at the given position, walking forward or backward from it. They are returned in the walking order
+func (o *Ots) readIndexedBlock(address types.Address, pos uint64, forward bool) ([]*storage.AddressTx, error) {
+	first, err := o.index.GetAddressTx(address, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	addressTxs := []*storage.AddressTx{first}
+	count := o.index.GetAddressTxsCount(address)
+
+	for {
+		if forward {
+			pos++
+		} else {
+			pos--
+		}
+
+		// pos wraps around below zero
+		if pos >= count {
+			return addressTxs, nil
+		}
+
+		addressTx, err := o.index.GetAddressTx(address, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if addressTx.BlockNumber != first.BlockNumber {
+			return addressTxs, nil
+		}
+
+		addressTxs = append(addressTxs, addressTx)
+	}
+}
+
+// appendIndexedBlock appends the indexed transactions of the same block to the result
+func (o *Ots) appendIndexedBlock(addressTxs []*storage.AddressTx, res *otsSearchResult) error {
+	block, ok := o.store.GetBlockByNumber(addressTxs[0].BlockNumber, true)
+	if !ok {
+		return fmt.Errorf("block %d not found", addressTxs[0].BlockNumber)
+	}
+
+	receipts, err := o.getReceipts(block)
+	if err != nil {
+		return err
+	}
+
+	for _, addressTx := range addressTxs {
+		idx := -1
+
+		for i, tx := range block.Transactions {
+			if tx.Hash == addressTx.TxHash {
+				idx = i
+
+				break
+			}
+		}
+
+		if idx < 0 {
+			return fmt.Errorf("transaction %s not found in block %d", addressTx.TxHash, block.Number())
+		}
+
+		tx := block.Transactions[idx]
+		blockNumber, blockIdx := argUint64(block.Number()), idx
+
+		res.Txs = append(res.Txs, toTransaction(tx, &blockNumber, &block.Header.Hash, &blockIdx))
+		res.Receipts = append(res.Receipts, toOtsReceipt(receipts[idx], tx, uint64(idx), block.Header))
+	}
+
+	return nil
+}
+
+// searchBlockNumber returns the number of the first block up to the head whose state satisfies the condition,
+// the condition has to be satisfied by the states of all the following blocks as well
+func (o *Ots) searchBlockNumber(head uint64, cond func(*types.Header) (bool, error)) (uint64, error) {
+	var searchErr error
+
+	number := sort.Search(int(head)+1, func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		header, ok := o.store.GetHeaderByNumber(uint64(i))
+		if !ok {
+			searchErr = fmt.Errorf("header %d not found", i)
+
+			return true
+		}
+
+		ok, searchErr = cond(header)
+
+		return ok
+	})
+
+	return uint64(number), searchErr
+}
+
+func (o *Ots) hasCode(header *types.Header, address types.Address) (bool, error) {
+	code, err := o.store.GetCode(header.StateRoot, address)
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return len(code) > 0, nil
+}
+
+func (o *Ots) getNonce(header *types.Header, address types.Address) (uint64, error) {
+	account, err := o.store.GetAccount(header.StateRoot, address)
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return account.Nonce, nil
+}
+
+func newOtsBlock(b *types.Block, fullTx bool) *otsBlock {
+	return &otsBlock{
+		block:            toBlock(b, fullTx),
+		TransactionCount: argUint64(len(b.Transactions)),
+	}
+}
+
+func newOtsSearchResult(firstPage bool) *otsSearchResult {
+	return &otsSearchResult{
+		Txs:       []*transaction{},
+		Receipts:  []*otsReceipt{},
+		FirstPage: firstPage,
+	}
+}
+
+func toOtsReceipt(src *types.Receipt, tx *types.Transaction, txIndex uint64, header *types.Header) *otsReceipt {
+	return &otsReceipt{
+		receipt:           toReceipt(src, tx, txIndex, header, nil),
+		EffectiveGasPrice: argBig(*tx.GetGasPrice(header.BaseFee)),
+		Timestamp:         argUint64(header.Timestamp),
+	}
+}
+
+// revertedTraces marks the traces which are reverted, by themselves or by one of their parents
+func revertedTraces(traces []*flattracer.Trace) []bool {
+	var (
+		reverted = make([]bool, len(traces))
+		// revertedAt holds the state of the last trace at each depth,
+		// the parent of a trace is the last one at the previous depth
+		revertedAt = make([]bool, 0)
+	)
+
+	for idx, trace := range traces {
+		depth := len(trace.TraceAddress)
+
+		reverted[idx] = trace.Error != "" || (depth > 0 && depth <= len(revertedAt) && revertedAt[depth-1])
+		if depth < len(revertedAt) {
+			revertedAt = revertedAt[:depth]
+		}
+
+		revertedAt = append(revertedAt, reverted[idx])
+	}
+
+	return reverted
+}
+
+func toInternalOperation(trace *flattracer.Trace) *otsInternalOperation {
+	switch action := trace.Action.(type) {
+	case *flattracer.CallAction:
+		if action.CallType != "call" || action.Value == "0x0" {
+			return nil
+		}
+
+		return &otsInternalOperation{Type: otsOpTransfer, From: action.From, To: action.To, Value: action.Value}
+	case *flattracer.CreateAction:
+		op := &otsInternalOperation{Type: otsOpCreate, From: action.From, Value: action.Value}
+
+		if action.IsCreate2() {
+			op.Type = otsOpCreate2
+		}
+
+		if result, ok := trace.Result.(*flattracer.CreateResult); ok {
+			op.To = result.Address
+		}
+
+		return op
+	case *flattracer.SuicideAction:
+		return &otsInternalOperation{
+			Type:  otsOpSelfDestruct,
+			From:  action.Address,
+			To:    action.RefundAddress,
+			Value: action.Balance,
+		}
+	}
+
+	return nil
+}
+
+func toOtsTrace(trace *flattracer.Trace) *otsTrace {
+	res := &otsTrace{
+		Depth:  len(trace.TraceAddress),
+		Input:  "0x",
+		Output: "0x",
+	}
+
+	switch action := trace.Action.(type) {
+	case *flattracer.CallAction:
+		res.Type = strings.ToUpper(action.CallType)
+		res.From, res.To, res.Input = action.From, action.To, action.Input
+
+		// the delegated and the static calls don't transfer any value
+		if action.CallType != "delegatecall" && action.CallType != "staticcall" {
+			res.Value = &action.Value
+		}
+
+		if result, ok := trace.Result.(*flattracer.CallResult); ok {
+			res.Output = result.Output
+		}
+	case *flattracer.CreateAction:
+		res.Type = strings.ToUpper(action.CreationMethod)
+		res.From, res.Input, res.Value = action.From, action.Init, &action.Value
+
+		if result, ok := trace.Result.(*flattracer.CreateResult); ok {
+			res.To, res.Output = result.Address, result.Code
+		}
+	case *flattracer.SuicideAction:
+		res.Type = "SELFDESTRUCT"
+		res.From, res.To, res.Value = action.Address, action.RefundAddress, &action.Balance
+	}
+
+	return res
+}
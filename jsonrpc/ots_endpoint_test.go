@@ -0,0 +1,684 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type otsMockStore struct {
+	*debugEndpointMockStore
+
+	getCodeFn func(types.Hash, types.Address) ([]byte, error)
+}
+
+func (s *otsMockStore) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
+	return s.getCodeFn(root, addr)
+}
+
+// otsIndexedMockStore is the store maintaining the address index
+type otsIndexedMockStore struct {
+	*otsMockStore
+
+	indexHead uint64
+	addresses map[types.Address][]*storage.AddressTx
+}
+
+func (s *otsIndexedMockStore) AddressIndexHead() (uint64, bool) {
+	return s.indexHead, true
+}
+
+func (s *otsIndexedMockStore) GetAddressTxsCount(addr types.Address) uint64 {
+	return uint64(len(s.addresses[addr]))
+}
+
+func (s *otsIndexedMockStore) GetAddressTx(addr types.Address, index uint64) (*storage.AddressTx, error) {
+	if index >= uint64(len(s.addresses[addr])) {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.addresses[addr][index], nil
+}
+
+var (
+	testOtsSender   = types.StringToAddress("11")
+	testOtsOther    = types.StringToAddress("12")
+	testOtsContract = types.StringToAddress("13")
+	testOtsInternal = types.StringToAddress("14")
+)
+
+// otsTestChain is a chain of four blocks, the sender sends a transfer in the block 1,
+// creates the contract in the block 2 and receives a transfer in the block 3
+type otsTestChain struct {
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func newOtsTestChain() *otsTestChain {
+	newTx := func(hash byte, from types.Address, to *types.Address, nonce uint64) *types.Transaction {
+		tx := createTestTransaction(types.BytesToHash([]byte{hash}))
+		tx.From, tx.To, tx.Nonce = from, to, nonce
+
+		return tx
+	}
+
+	chain := &otsTestChain{
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+
+	txs := [][]*types.Transaction{
+		{},
+		{newTx(1, testOtsSender, &testOtsOther, 0)},
+		{newTx(2, testOtsOther, &testOtsOther, 0), newTx(3, testOtsSender, nil, 1)},
+		{newTx(4, testOtsOther, &testOtsSender, 1)},
+	}
+
+	for number, blockTxs := range txs {
+		block := &types.Block{
+			Header: createTestHeader(uint64(number), func(h *types.Header) {
+				h.StateRoot = types.BytesToHash([]byte{byte(number)})
+			}),
+			Transactions: blockTxs,
+		}
+
+		receipts := make([]*types.Receipt, 0, len(blockTxs))
+
+		for _, tx := range blockTxs {
+			receipt := createTestReceipt(nil, 10, 10, tx.Hash)
+			if tx.To == nil {
+				receipt.SetContractAddress(testOtsContract)
+			}
+
+			receipts = append(receipts, receipt)
+		}
+
+		chain.blocks = append(chain.blocks, block)
+		chain.receipts[block.Hash()] = receipts
+	}
+
+	return chain
+}
+
+// number returns the number of the block by its state root
+func (c *otsTestChain) number(root types.Hash) uint64 {
+	return uint64(root[len(root)-1])
+}
+
+func (c *otsTestChain) store() *otsMockStore {
+	return &otsMockStore{
+		debugEndpointMockStore: &debugEndpointMockStore{
+			headerFn: func() *types.Header {
+				return c.blocks[len(c.blocks)-1].Header
+			},
+			getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+				if num >= uint64(len(c.blocks)) {
+					return nil, false
+				}
+
+				return c.blocks[num].Header, true
+			},
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				if num >= uint64(len(c.blocks)) {
+					return nil, false
+				}
+
+				return c.blocks[num], true
+			},
+			getReceiptsFn: func(hash types.Hash) ([]*types.Receipt, error) {
+				return c.receipts[hash], nil
+			},
+			getAccountFn: func(root types.Hash, addr types.Address) (*Account, error) {
+				if addr != testOtsSender {
+					return nil, ErrStateNotFound
+				}
+
+				// the sender sends a transaction in the blocks 1 and 2
+				return &Account{Nonce: common.Min(c.number(root), 2)}, nil
+			},
+		},
+		getCodeFn: func(root types.Hash, addr types.Address) ([]byte, error) {
+			if addr == testOtsContract && c.number(root) >= 2 {
+				return []byte{0x1}, nil
+			}
+
+			return []byte{}, nil
+		},
+	}
+}
+
+func TestOts_GetApiLevel(t *testing.T) {
+	t.Parallel()
+
+	res, err := NewOts(newOtsTestChain().store(), 1).GetApiLevel()
+	require.NoError(t, err)
+	assert.Equal(t, otsAPILevel, res)
+}
+
+func TestOts_HasCode(t *testing.T) {
+	t.Parallel()
+
+	endpoint := NewOts(newOtsTestChain().store(), 1)
+
+	blockNumber := func(n BlockNumber) BlockNumberOrHash {
+		return BlockNumberOrHash{BlockNumber: &n}
+	}
+
+	res, err := endpoint.HasCode(testOtsContract, blockNumber(LatestBlockNumber))
+	require.NoError(t, err)
+	assert.Equal(t, true, res)
+
+	res, err = endpoint.HasCode(testOtsContract, blockNumber(1))
+	require.NoError(t, err)
+	assert.Equal(t, false, res)
+
+	res, err = endpoint.HasCode(testOtsSender, blockNumber(LatestBlockNumber))
+	require.NoError(t, err)
+	assert.Equal(t, false, res)
+}
+
+func TestOts_TransactionTraces(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock(10, testTxHash1)
+
+	// the transaction calls the first contract, which transfers the value and creates a contract,
+	// then calls the second contract, which transfers the value and reverts
+	traces := []*flattracer.Trace{
+		{
+			Action:       &flattracer.CallAction{CallType: "call", From: testTraceFrom, To: testTraceTo, Input: "0x01", Value: "0x0"},
+			Result:       &flattracer.CallResult{Output: "0x02"},
+			TraceAddress: []int{},
+		},
+		{
+			Action:       &flattracer.CallAction{CallType: "delegatecall", From: testTraceTo, To: testTraceTo2, Input: "0x", Value: "0x0"},
+			Result:       &flattracer.CallResult{Output: "0x"},
+			TraceAddress: []int{0},
+		},
+		{
+			Action:       &flattracer.CallAction{CallType: "call", From: testTraceTo, To: testOtsOther, Input: "0x", Value: "0x5"},
+			Result:       &flattracer.CallResult{Output: "0x"},
+			TraceAddress: []int{0, 0},
+		},
+		{
+			Action:       &flattracer.CreateAction{From: testTraceTo, Init: "0x60", Value: "0x0", CreationMethod: "create2"},
+			Result:       &flattracer.CreateResult{Address: testOtsContract, Code: "0x61"},
+			TraceAddress: []int{0, 1},
+		},
+		{
+			Action:       &flattracer.CallAction{CallType: "call", From: testTraceTo, To: testTraceTo2, Input: "0x", Value: "0x0"},
+			TraceAddress: []int{1},
+			Error:        "execution reverted",
+		},
+		{
+			Action:       &flattracer.CallAction{CallType: "call", From: testTraceTo2, To: testOtsOther, Input: "0x", Value: "0x6"},
+			Result:       &flattracer.CallResult{Output: "0x"},
+			TraceAddress: []int{1, 0},
+		},
+	}
+
+	store := &otsMockStore{
+		debugEndpointMockStore: &debugEndpointMockStore{
+			readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+				return block.Hash(), hash == testTxHash1
+			},
+			getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+				return block, true
+			},
+			traceTxnFn: func(b *types.Block, hash types.Hash, tr tracer.Tracer) (interface{}, error) {
+				assert.Equal(t, testTxHash1, hash)
+				assert.IsType(t, &flattracer.FlatTracer{}, tr)
+
+				return traces, nil
+			},
+		},
+	}
+
+	endpoint := NewOts(store, 1)
+
+	t.Run("internal operations", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetInternalOperations(testTxHash1)
+		require.NoError(t, err)
+		assert.Equal(t, []*otsInternalOperation{
+			{Type: otsOpTransfer, From: testTraceTo, To: testOtsOther, Value: "0x5"},
+			{Type: otsOpCreate2, From: testTraceTo, To: testOtsContract, Value: "0x0"},
+		}, res)
+	})
+
+	t.Run("trace transaction", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.TraceTransaction(testTxHash1)
+		require.NoError(t, err)
+
+		otsTraces, ok := res.([]*otsTrace)
+		require.True(t, ok)
+		require.Len(t, otsTraces, len(traces))
+
+		assert.Equal(t, "CALL", otsTraces[0].Type)
+		assert.Equal(t, "0x02", otsTraces[0].Output)
+		assert.Equal(t, "DELEGATECALL", otsTraces[1].Type)
+		assert.Nil(t, otsTraces[1].Value)
+		assert.Equal(t, 2, otsTraces[2].Depth)
+		assert.Equal(t, "0x5", *otsTraces[2].Value)
+		assert.Equal(t, &otsTrace{
+			Type:   "CREATE2",
+			Depth:  2,
+			From:   testTraceTo,
+			To:     testOtsContract,
+			Value:  &traces[3].Action.(*flattracer.CreateAction).Value, //nolint:forcetypeassert
+			Input:  "0x60",
+			Output: "0x61",
+		}, otsTraces[3])
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := endpoint.TraceTransaction(types.BytesToHash([]byte{2}))
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestOts_GetTransactionError(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock(10, testTxHash1)
+	reverted := true
+
+	store := &otsMockStore{
+		debugEndpointMockStore: &debugEndpointMockStore{
+			readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+				return block.Hash(), true
+			},
+			getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+				return block, true
+			},
+			traceTxnFn: func(b *types.Block, hash types.Hash, tr tracer.Tracer) (interface{}, error) {
+				flatTracer, ok := tr.(*flattracer.FlatTracer)
+				require.True(t, ok)
+
+				// the output of the top-level call is collected by the tracer
+				flatTracer.CallFrameStart(1, testTraceFrom, testTraceTo, int(runtime.Call), 100, big.NewInt(0), nil)
+
+				if reverted {
+					flatTracer.CallEnd(1, []byte{0xde, 0xad}, runtime.ErrExecutionReverted)
+				} else {
+					flatTracer.CallEnd(1, []byte{0x1}, nil)
+				}
+
+				return flatTracer.GetResult()
+			},
+		},
+	}
+
+	endpoint := NewOts(store, 1)
+
+	res, err := endpoint.GetTransactionError(testTxHash1)
+	require.NoError(t, err)
+	assert.Equal(t, argBytes{0xde, 0xad}, res)
+
+	reverted = false
+
+	res, err = endpoint.GetTransactionError(testTxHash1)
+	require.NoError(t, err)
+	assert.Equal(t, argBytes{}, res)
+}
+
+func TestOts_BlockDetails(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+	endpoint := NewOts(chain.store(), 1)
+
+	res, err := endpoint.GetBlockDetails(BlockNumber(2))
+	require.NoError(t, err)
+
+	details, ok := res.(*otsBlockDetails)
+	require.True(t, ok)
+	assert.Equal(t, chain.blocks[2].Hash(), details.Block.Hash)
+	assert.Equal(t, argUint64(2), details.Block.TransactionCount)
+	assert.Empty(t, details.Block.Transactions)
+	// two transactions using 10 gas each with the gas price 400
+	assert.Equal(t, argBig(*big.NewInt(8000)), details.TotalFees)
+
+	res, err = endpoint.GetBlockDetails(BlockNumber(0))
+	require.NoError(t, err)
+
+	details, ok = res.(*otsBlockDetails)
+	require.True(t, ok)
+	assert.Equal(t, argUint64(0), details.Block.TransactionCount)
+	assert.Equal(t, argBig(*big.NewInt(0)), details.TotalFees)
+
+	_, err = endpoint.GetBlockDetails(BlockNumber(10))
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestOts_GetBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+	endpoint := NewOts(chain.store(), 1)
+
+	cases := []struct {
+		pageNumber argUint64
+		pageSize   argUint64
+		expected   []types.Hash
+	}{
+		{0, 10, []types.Hash{chain.blocks[2].Transactions[0].Hash, chain.blocks[2].Transactions[1].Hash}},
+		// the pages are counted from the last transaction
+		{0, 1, []types.Hash{chain.blocks[2].Transactions[1].Hash}},
+		{1, 1, []types.Hash{chain.blocks[2].Transactions[0].Hash}},
+		{2, 1, []types.Hash{}},
+	}
+
+	for _, c := range cases {
+		res, err := endpoint.GetBlockTransactions(BlockNumber(2), c.pageNumber, c.pageSize)
+		require.NoError(t, err)
+
+		blockTxs, ok := res.(*otsBlockTransactions)
+		require.True(t, ok)
+		assert.Equal(t, argUint64(2), blockTxs.FullBlock.TransactionCount)
+		require.Len(t, blockTxs.FullBlock.Transactions, len(c.expected))
+		require.Len(t, blockTxs.Receipts, len(c.expected))
+
+		for idx, hash := range c.expected {
+			tx, ok := blockTxs.FullBlock.Transactions[idx].(*transaction)
+			require.True(t, ok)
+			assert.Equal(t, hash, tx.Hash)
+			assert.Equal(t, hash, blockTxs.Receipts[idx].TxHash)
+		}
+	}
+
+	_, err := endpoint.GetBlockTransactions(BlockNumber(2), 0, 0)
+	require.ErrorIs(t, err, ErrInvalidPageSize)
+}
+
+func TestOts_SearchTransactions(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+	endpoint := NewOts(chain.store(), 1)
+
+	hashes := func(res interface{}) []types.Hash {
+		t.Helper()
+
+		result, ok := res.(*otsSearchResult)
+		require.True(t, ok)
+		require.Len(t, result.Receipts, len(result.Txs))
+
+		hashes := make([]types.Hash, 0, len(result.Txs))
+
+		for idx, tx := range result.Txs {
+			assert.Equal(t, tx.Hash, result.Receipts[idx].TxHash)
+
+			hashes = append(hashes, tx.Hash)
+		}
+
+		return hashes
+	}
+
+	var (
+		transferHash = chain.blocks[1].Transactions[0].Hash
+		createHash   = chain.blocks[2].Transactions[1].Hash
+		receiveHash  = chain.blocks[3].Transactions[0].Hash
+	)
+
+	t.Run("before", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.SearchTransactionsBefore(testOtsSender, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{receiveHash, createHash, transferHash}, hashes(res))
+		assert.True(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+		assert.True(t, res.(*otsSearchResult).LastPage)  //nolint:forcetypeassert
+
+		res, err = endpoint.SearchTransactionsBefore(testOtsSender, 0, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{receiveHash}, hashes(res))
+		assert.False(t, res.(*otsSearchResult).LastPage) //nolint:forcetypeassert
+
+		res, err = endpoint.SearchTransactionsBefore(testOtsSender, 3, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{createHash}, hashes(res))
+		assert.False(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+
+		// the created contract is matched by the receipt
+		res, err = endpoint.SearchTransactionsBefore(testOtsContract, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{createHash}, hashes(res))
+	})
+
+	t.Run("after", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.SearchTransactionsAfter(testOtsSender, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{createHash, transferHash}, hashes(res))
+		assert.False(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+		assert.True(t, res.(*otsSearchResult).LastPage)   //nolint:forcetypeassert
+
+		res, err = endpoint.SearchTransactionsAfter(testOtsSender, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{receiveHash}, hashes(res))
+		assert.True(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+		assert.False(t, res.(*otsSearchResult).LastPage) //nolint:forcetypeassert
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := endpoint.SearchTransactionsBefore(testOtsSender, 0, 0)
+		require.ErrorIs(t, err, ErrInvalidPageSize)
+	})
+
+	t.Run("scanned blocks limit", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := NewOts(chain.store(), 1)
+		endpoint.searchMaxBlocks = 1
+
+		// the partial page is returned together with the block the search continues from
+		res, err := endpoint.SearchTransactionsBefore(testOtsSender, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{receiveHash}, hashes(res))
+		assert.False(t, res.(*otsSearchResult).LastPage)                 //nolint:forcetypeassert
+		assert.Equal(t, argUintPtr(3), res.(*otsSearchResult).NextBlock) //nolint:forcetypeassert
+
+		res, err = endpoint.SearchTransactionsBefore(testOtsSender, 3, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{createHash}, hashes(res))
+		assert.Equal(t, argUintPtr(2), res.(*otsSearchResult).NextBlock) //nolint:forcetypeassert
+
+		res, err = endpoint.SearchTransactionsAfter(testOtsSender, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{transferHash}, hashes(res))
+		assert.False(t, res.(*otsSearchResult).FirstPage)                //nolint:forcetypeassert
+		assert.Equal(t, argUintPtr(1), res.(*otsSearchResult).NextBlock) //nolint:forcetypeassert
+
+		// the full page doesn't need to be continued
+		res, err = endpoint.SearchTransactionsBefore(testOtsSender, 0, 1)
+		require.NoError(t, err)
+		assert.Nil(t, res.(*otsSearchResult).NextBlock) //nolint:forcetypeassert
+	})
+}
+
+func TestOts_SearchTransactions_AddressIndex(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+
+	// the index of the test chain, both transactions in the block 2 call the internal address
+	store := &otsIndexedMockStore{
+		otsMockStore: chain.store(),
+		indexHead:    3,
+		addresses:    map[types.Address][]*storage.AddressTx{},
+	}
+
+	for _, block := range chain.blocks {
+		for idx, tx := range block.Transactions {
+			addresses := []types.Address{tx.From}
+			if tx.To != nil {
+				addresses = append(addresses, *tx.To)
+			} else {
+				addresses = append(addresses, *chain.receipts[block.Hash()][idx].ContractAddress)
+			}
+
+			if block.Number() == 2 {
+				addresses = append(addresses, testOtsInternal)
+			}
+
+			indexed := map[types.Address]bool{}
+
+			for _, addr := range addresses {
+				if indexed[addr] {
+					continue
+				}
+
+				indexed[addr] = true
+				store.addresses[addr] = append(store.addresses[addr], &storage.AddressTx{
+					BlockNumber: block.Number(),
+					TxHash:      tx.Hash,
+				})
+			}
+		}
+	}
+
+	endpoint := NewOts(store, 1)
+
+	hashes := func(res interface{}) []types.Hash {
+		t.Helper()
+
+		result, ok := res.(*otsSearchResult)
+		require.True(t, ok)
+		require.Len(t, result.Receipts, len(result.Txs))
+
+		hashes := make([]types.Hash, 0, len(result.Txs))
+
+		for idx, tx := range result.Txs {
+			assert.Equal(t, tx.Hash, result.Receipts[idx].TxHash)
+
+			hashes = append(hashes, tx.Hash)
+		}
+
+		return hashes
+	}
+
+	var (
+		transferHash = chain.blocks[1].Transactions[0].Hash
+		internalHash = chain.blocks[2].Transactions[0].Hash
+		createHash   = chain.blocks[2].Transactions[1].Hash
+		receiveHash  = chain.blocks[3].Transactions[0].Hash
+	)
+
+	// the same transactions are found by the index and by scanning the blocks
+	res, err := endpoint.SearchTransactionsBefore(testOtsSender, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{receiveHash, createHash, transferHash}, hashes(res))
+	assert.True(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+	assert.True(t, res.(*otsSearchResult).LastPage)  //nolint:forcetypeassert
+
+	res, err = endpoint.SearchTransactionsBefore(testOtsSender, 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{createHash}, hashes(res))
+	assert.False(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+	assert.False(t, res.(*otsSearchResult).LastPage)  //nolint:forcetypeassert
+
+	res, err = endpoint.SearchTransactionsAfter(testOtsSender, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{createHash, transferHash}, hashes(res))
+	assert.False(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+	assert.True(t, res.(*otsSearchResult).LastPage)   //nolint:forcetypeassert
+
+	res, err = endpoint.SearchTransactionsAfter(testOtsSender, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{receiveHash}, hashes(res))
+	assert.True(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+	assert.False(t, res.(*otsSearchResult).LastPage) //nolint:forcetypeassert
+
+	// the internal calls are found only by the index, the whole blocks are returned
+	res, err = endpoint.SearchTransactionsBefore(testOtsInternal, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{createHash, internalHash}, hashes(res))
+	assert.True(t, res.(*otsSearchResult).LastPage) //nolint:forcetypeassert
+
+	res, err = endpoint.SearchTransactionsAfter(testOtsInternal, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{createHash, internalHash}, hashes(res))
+	assert.True(t, res.(*otsSearchResult).FirstPage) //nolint:forcetypeassert
+
+	// the blocks are scanned while the index isn't caught up with the chain
+	store.indexHead = 2
+
+	res, err = endpoint.SearchTransactionsBefore(testOtsInternal, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, hashes(res))
+}
+
+func TestOts_GetTransactionBySenderAndNonce(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+	endpoint := NewOts(chain.store(), 1)
+
+	res, err := endpoint.GetTransactionBySenderAndNonce(testOtsSender, 0)
+	require.NoError(t, err)
+	assert.Equal(t, chain.blocks[1].Transactions[0].Hash, res)
+
+	res, err = endpoint.GetTransactionBySenderAndNonce(testOtsSender, 1)
+	require.NoError(t, err)
+	assert.Equal(t, chain.blocks[2].Transactions[1].Hash, res)
+
+	res, err = endpoint.GetTransactionBySenderAndNonce(testOtsSender, 2)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = endpoint.GetTransactionBySenderAndNonce(testOtsOther, 0)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestOts_GetContractCreator(t *testing.T) {
+	t.Parallel()
+
+	chain := newOtsTestChain()
+	store := chain.store()
+	store.traceBlockFn = func(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+		assert.Equal(t, chain.blocks[2], block)
+
+		return []interface{}{
+			newTestFlatTraces(testOtsOther, testOtsOther, "0x"),
+			[]*flattracer.Trace{
+				{
+					Action:       &flattracer.CreateAction{From: testOtsSender, Init: "0x60", Value: "0x0", CreationMethod: "create"},
+					Result:       &flattracer.CreateResult{Address: testOtsContract, Code: "0x61"},
+					TraceAddress: []int{},
+					Type:         flattracer.TraceTypeCreate,
+				},
+			},
+		}, nil
+	}
+
+	endpoint := NewOts(store, 1)
+
+	res, err := endpoint.GetContractCreator(testOtsContract)
+	require.NoError(t, err)
+	assert.Equal(t, &otsContractCreator{
+		Hash:    chain.blocks[2].Transactions[1].Hash,
+		Creator: testOtsSender,
+	}, res)
+
+	res, err = endpoint.GetContractCreator(testOtsOther)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
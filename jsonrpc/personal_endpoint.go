@@ -0,0 +1,49 @@
+package jsonrpc
+
+import (
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// defaultUnlockDuration is the duration of the unlock if it's not given
+const defaultUnlockDuration = 300 * time.Second
+
+// Personal is the personal jsonrpc endpoint, it manages the accounts of the local signer
+type Personal struct {
+	signer *LocalSigner
+}
+
+// ListAccounts returns the addresses of the local accounts (personal_listAccounts)
+func (p *Personal) ListAccounts() (interface{}, error) {
+	return p.signer.Accounts(), nil
+}
+
+// NewAccount generates the new account whose key file is encrypted by the password (personal_newAccount)
+func (p *Personal) NewAccount(password string) (interface{}, error) {
+	return p.signer.NewAccount(password)
+}
+
+// UnlockAccount decrypts the key of the account for the duration in seconds (personal_unlockAccount),
+// the account is unlocked for 300 seconds if the duration is not given, and until it's locked if it's zero
+func (p *Personal) UnlockAccount(address types.Address, password string, duration *uint64) (interface{}, error) {
+	unlockDuration := defaultUnlockDuration
+	if duration != nil {
+		unlockDuration = time.Duration(*duration) * time.Second
+	}
+
+	if err := p.signer.Unlock(address, password, unlockDuration); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LockAccount drops the decrypted key of the account (personal_lockAccount)
+func (p *Personal) LockAccount(address types.Address) (interface{}, error) {
+	if err := p.signer.Lock(address); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
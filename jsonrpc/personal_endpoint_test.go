@@ -0,0 +1,254 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockSignerStore struct {
+	*mockStore
+
+	forks chain.ForksInTime
+	nonce uint64
+	txn   *types.Transaction
+}
+
+func (m *mockSignerStore) GetForksInTime(uint64) chain.ForksInTime {
+	return m.forks
+}
+
+func (m *mockSignerStore) GetNonce(types.Address) uint64 {
+	return m.nonce
+}
+
+func (m *mockSignerStore) GetBaseFee() uint64 {
+	return 10
+}
+
+func (m *mockSignerStore) MaxPriorityFeePerGas() (*big.Int, error) {
+	return big.NewInt(2), nil
+}
+
+func (m *mockSignerStore) AddTx(tx *types.Transaction) error {
+	m.txn = tx
+
+	return nil
+}
+
+func newTestSignerDispatcher(t *testing.T) (*Dispatcher, *mockSignerStore, types.Address) {
+	t.Helper()
+
+	signer := newTestLocalSigner(t)
+
+	addr, err := signer.NewAccount(testPassword)
+	require.NoError(t, err)
+
+	store := &mockSignerStore{mockStore: newMockStore(), forks: chain.AllForksEnabled.At(0), nonce: 5}
+	store.header = &types.Header{Number: 10}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID: 100,
+			signer:  signer,
+		},
+	)
+
+	return dispatcher, store, addr
+}
+
+func handleIPC(t *testing.T, dispatcher *Dispatcher, method string, params ...interface{}) (json.RawMessage, error) {
+	t.Helper()
+
+	req, err := json.Marshal(map[string]interface{}{"method": method, "params": params})
+	require.NoError(t, err)
+
+	resp, err := dispatcher.HandleIPC(req, &mockWsConn{})
+	require.NoError(t, err)
+
+	var res json.RawMessage
+
+	return res, expectJSONResult(resp, &res)
+}
+
+func TestPersonalEndpoint_Accounts(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, _, addr := newTestSignerDispatcher(t)
+
+	var accounts []types.Address
+
+	res, err := handleIPC(t, dispatcher, "eth_accounts")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res, &accounts))
+	assert.Equal(t, []types.Address{addr}, accounts)
+
+	_, err = handleIPC(t, dispatcher, "personal_newAccount", testPassword)
+	require.NoError(t, err)
+
+	res, err = handleIPC(t, dispatcher, "personal_listAccounts")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res, &accounts))
+	assert.Len(t, accounts, 2)
+
+	// the accounts are empty and the personal namespace isn't served without the signer
+	dispatcher = newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	res, err = handleIPC(t, dispatcher, "eth_accounts")
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(res))
+
+	_, err = handleIPC(t, dispatcher, "personal_listAccounts")
+
+	var rpcErr *ObjectError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestPersonalEndpoint_Transports(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, _, _ := newTestSignerDispatcher(t)
+
+	// the personal namespace and the signing methods are served as the admin namespace,
+	// not on HTTP beyond the loopback address
+	for _, method := range []string{"personal_listAccounts", "eth_sendTransaction", "eth_signTransaction",
+		"eth_sign", "eth_signTypedData_v4"} {
+		resp, err := dispatcher.Handle([]byte(`{"method": "` + method + `", "params": []}`))
+		require.NoError(t, err)
+
+		var res interface{}
+
+		var rpcErr *ObjectError
+		require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr, method)
+		assert.Equal(t, -32601, rpcErr.Code, method)
+		assert.Contains(t, rpcErr.Message, "not allowed", method)
+	}
+
+	// the signing methods are served on the loopback address
+	dispatcher.params.localOnLoopback = true
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "eth_sendTransaction", "params": [{}]}`))
+	require.NoError(t, err)
+
+	var res interface{}
+
+	var rpcErr *ObjectError
+	require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+	assert.NotContains(t, rpcErr.Message, "not allowed")
+
+	// without the signer, the methods aren't local
+	dispatcher = newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err = dispatcher.Handle([]byte(`{"method": "eth_sendTransaction", "params": [{}]}`))
+	require.NoError(t, err)
+	require.ErrorAs(t, expectJSONResult(resp, &res), &rpcErr)
+	assert.NotContains(t, rpcErr.Message, "not allowed")
+}
+
+func TestPersonalEndpoint_Sign(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, _, addr := newTestSignerDispatcher(t)
+
+	message := []byte("hello")
+
+	_, err := handleIPC(t, dispatcher, "eth_sign", addr, argBytes(message))
+	require.ErrorContains(t, err, errAccountLocked.Error())
+
+	_, err = handleIPC(t, dispatcher, "personal_unlockAccount", addr, "wrong")
+	require.Error(t, err)
+
+	_, err = handleIPC(t, dispatcher, "personal_unlockAccount", addr, testPassword, 0)
+	require.NoError(t, err)
+
+	var sig argBytes
+
+	res, err := handleIPC(t, dispatcher, "eth_sign", addr, argBytes(message))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res, &sig))
+	require.Len(t, sig, 65)
+
+	hash := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))), message)
+	sig[64] -= 27
+
+	pub, err := crypto.Ecrecover(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, addr, types.BytesToAddress(crypto.Keccak256(pub[1:])[12:]))
+
+	// the typed data is signed as well
+	res, err = handleIPC(t, dispatcher, "eth_signTypedData_v4", addr, json.RawMessage(mailTypedData))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res, &sig))
+	require.Len(t, sig, 65)
+
+	_, err = handleIPC(t, dispatcher, "personal_lockAccount", addr)
+	require.NoError(t, err)
+
+	_, err = handleIPC(t, dispatcher, "eth_sign", addr, argBytes(message))
+	require.ErrorContains(t, err, errAccountLocked.Error())
+}
+
+func TestPersonalEndpoint_SendTransaction(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, store, addr := newTestSignerDispatcher(t)
+
+	_, err := handleIPC(t, dispatcher, "personal_unlockAccount", addr, testPassword)
+	require.NoError(t, err)
+
+	to := types.StringToAddress("2")
+	value := argBytes(big.NewInt(1000).Bytes())
+
+	// the nonce, the gas and the dynamic fees are filled
+	res, err := handleIPC(t, dispatcher, "eth_sendTransaction", &txnArgs{From: &addr, To: &to, Value: &value})
+	require.NoError(t, err)
+
+	require.NotNil(t, store.txn)
+	assert.Equal(t, fmt.Sprintf("%q", store.txn.Hash), string(res))
+	assert.Equal(t, types.DynamicFeeTx, store.txn.Type)
+	assert.Equal(t, uint64(5), store.txn.Nonce)
+	assert.Equal(t, uint64(21000), store.txn.Gas)
+	assert.Equal(t, big.NewInt(2), store.txn.GasTipCap)
+	assert.Equal(t, big.NewInt(22), store.txn.GasFeeCap)
+
+	sender, err := crypto.NewSigner(store.forks, 100).Sender(store.txn)
+	require.NoError(t, err)
+	assert.Equal(t, addr, sender)
+
+	// the legacy transaction is signed, but not sent, if the gas price is given
+	gasPrice := argBytes(big.NewInt(7).Bytes())
+	gas := argUint64(30000)
+
+	var signed struct {
+		Raw argBytes     `json:"raw"`
+		Tx  *transaction `json:"tx"`
+	}
+
+	res, err = handleIPC(t, dispatcher, "eth_signTransaction",
+		&txnArgs{From: &addr, To: &to, Value: &value, GasPrice: &gasPrice, Gas: &gas})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res, &signed))
+
+	tx := &types.Transaction{}
+	require.NoError(t, tx.UnmarshalRLP(signed.Raw))
+	assert.Equal(t, types.LegacyTx, tx.Type)
+	assert.Equal(t, big.NewInt(7), tx.GasPrice)
+	assert.Equal(t, uint64(30000), tx.Gas)
+
+	sender, err = crypto.NewSigner(store.forks, 100).Sender(tx)
+	require.NoError(t, err)
+	assert.Equal(t, addr, sender)
+	assert.Equal(t, addr, signed.Tx.From)
+}
@@ -0,0 +1,42 @@
+package jsonrpc
+
+import (
+	"strings"
+)
+
+// MethodPolicy restricts the methods served on a transport, the entries are
+// either the namespaces (e.g. "debug") or the full method names (e.g. "txpool_content")
+type MethodPolicy struct {
+	// Allow lists the only namespaces and methods which are served,
+	// all of them are served if it's empty
+	Allow []string
+
+	// Deny lists the namespaces and methods which are never served,
+	// it takes precedence over the Allow list
+	Deny []string
+}
+
+// isAllowed checks if the method is served by the policy, everything is served without the policy
+func (p *MethodPolicy) isAllowed(method string) bool {
+	if p == nil {
+		return true
+	}
+
+	namespace, _, _ := strings.Cut(method, "_")
+
+	matches := func(entries []string) bool {
+		for _, entry := range entries {
+			if entry == method || entry == namespace {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if matches(p.Deny) {
+		return false
+	}
+
+	return len(p.Allow) == 0 || matches(p.Allow)
+}
@@ -0,0 +1,60 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodPolicy_isAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  *MethodPolicy
+		allowed []string
+		denied  []string
+	}{
+		{
+			name:    "no policy",
+			allowed: []string{"eth_call", "debug_traceBlockByNumber"},
+		},
+		{
+			name:    "deny namespace and method",
+			policy:  &MethodPolicy{Deny: []string{"debug", "txpool_content"}},
+			allowed: []string{"eth_call", "txpool_status"},
+			denied:  []string{"debug_traceBlockByNumber", "txpool_content"},
+		},
+		{
+			name:    "allow namespace and method",
+			policy:  &MethodPolicy{Allow: []string{"eth", "net_version"}},
+			allowed: []string{"eth_call", "net_version"},
+			denied:  []string{"net_peerCount", "debug_traceCall"},
+		},
+		{
+			name: "deny takes precedence",
+			policy: &MethodPolicy{
+				Allow: []string{"eth", "txpool"},
+				Deny:  []string{"eth_sendRawTransaction", "txpool"},
+			},
+			allowed: []string{"eth_call"},
+			denied:  []string{"eth_sendRawTransaction", "txpool_status"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, method := range test.allowed {
+				assert.True(t, test.policy.isAllowed(method), method)
+			}
+
+			for _, method := range test.denied {
+				assert.False(t, test.policy.isAllowed(method), method)
+			}
+		})
+	}
+}
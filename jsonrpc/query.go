@@ -167,6 +167,30 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// bloomFilters returns the values of the query in the form of the bloom-bits index filters,
+// the addresses are the first filter and every topic position is the following one
+func (q *LogQuery) bloomFilters() [][][]byte {
+	filters := make([][][]byte, 0, len(q.Topics)+1)
+
+	addresses := make([][]byte, len(q.Addresses))
+	for i, addr := range q.Addresses {
+		addresses[i] = addr.Bytes()
+	}
+
+	filters = append(filters, addresses)
+
+	for _, sub := range q.Topics {
+		topics := make([][]byte, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Bytes()
+		}
+
+		filters = append(filters, topics)
+	}
+
+	return filters
+}
+
 // Match returns whether the receipt includes topics for this filter
 func (q *LogQuery) Match(log *types.Log) bool {
 	// check addresses
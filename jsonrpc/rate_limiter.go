@@ -0,0 +1,69 @@
+package jsonrpc
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket which refills at the given rate up to the burst
+type rateLimiter struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate, burst uint64) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// allow takes a token from the bucket, it returns false if the bucket is empty
+func (l *rateLimiter) allow() bool {
+	return l.allowN(1)
+}
+
+// allowN takes n tokens from the bucket, it returns false if there are not enough of them
+func (l *rateLimiter) allowN(n uint64) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+
+	if l.tokens < float64(n) {
+		return false
+	}
+
+	l.tokens -= float64(n)
+
+	return true
+}
+
+// isFull checks if the bucket is refilled, so it's in the same state as the new one
+func (l *rateLimiter) isFull() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+
+	return l.tokens >= l.burst
+}
+
+func (l *rateLimiter) refill() {
+	now := l.now()
+
+	// the clock going backwards doesn't drain the bucket
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+	}
+
+	l.last = now
+}
@@ -0,0 +1,209 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// cacheableMethods maps the methods whose results are cached to the check of their params,
+// the check returns true if the params reference a concrete block below the head, or a hash
+var cacheableMethods = map[string]func(params []json.RawMessage, head uint64) bool{
+	"eth_getBlockByNumber":      firstParamIsHistoricalNumber,
+	"eth_getBlockByHash":        firstParamIsHash,
+	"eth_getTransactionReceipt": firstParamIsHash,
+	"eth_getBlockReceipts":      firstParamIsHistoricalNumberOrHash,
+	"eth_getLogs":               logQueryIsHistorical,
+	"debug_traceTransaction":    firstParamIsHash,
+	"debug_traceBlockByNumber":  firstParamIsHistoricalNumber,
+	"debug_traceBlockByHash":    firstParamIsHash,
+}
+
+// responseCache is a size-bounded LRU cache of the results of the requests
+// which always return the same result, as the blocks are final once they are written.
+// The cache is purged on the reorgs and the rewinds of the chain
+type responseCache struct {
+	lock    sync.Mutex
+	entries *simplelru.LRU
+	size    uint64
+	maxSize uint64
+
+	// generation is increased by each purge, so the results computed before the purge are not added
+	generation uint64
+}
+
+// newResponseCache returns the cache bounded by the total size of the results, it returns nil if the size is zero
+func newResponseCache(maxSize uint64) *responseCache {
+	if maxSize == 0 {
+		return nil
+	}
+
+	c := &responseCache{maxSize: maxSize}
+
+	// the number of the entries is bounded by their size
+	c.entries, _ = simplelru.NewLRU(int(^uint(0)>>1), func(_, value interface{}) {
+		c.size -= uint64(len(value.([]byte))) //nolint:forcetypeassert
+	})
+
+	return c
+}
+
+// cacheKey returns the key of the request if its result can be cached
+func (c *responseCache) cacheKey(req Request, head uint64) (string, bool) {
+	check, ok := cacheableMethods[req.Method]
+	if !ok {
+		return "", false
+	}
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(req.Params, &params); err != nil || !check(params, head) {
+		return "", false
+	}
+
+	canonical, err := canonicalizeParams(req.Params)
+	if err != nil {
+		return "", false
+	}
+
+	return req.Method + "\x00" + string(canonical), true
+}
+
+// run purges the cache on the events of the subscription which change the canonical chain,
+// the reorgs and the rewinds, until the subscription is closed
+func (c *responseCache) run(subscription blockchain.Subscription) {
+	for {
+		evnt := subscription.GetEvent()
+		if evnt == nil {
+			return
+		}
+
+		if evnt.Type == blockchain.EventReorg {
+			c.purge()
+		}
+	}
+}
+
+// purge removes all the cached results, as they may no longer be valid
+func (c *responseCache) purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries.Purge()
+	c.generation++
+}
+
+// get returns the cached result of the key and the generation of the cache,
+// which is passed to add together with the result computed on a miss
+func (c *responseCache) get(key string) ([]byte, uint64, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	value, ok := c.entries.Get(key)
+	if !ok {
+		return nil, c.generation, false
+	}
+
+	return value.([]byte), c.generation, true //nolint:forcetypeassert
+}
+
+// add caches the result computed in the given generation, unless the cache was purged since
+func (c *responseCache) add(key string, value []byte, generation uint64) {
+	// the empty results, like the blocks which are not found yet, are not final
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) || uint64(len(value)) > c.maxSize {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	c.entries.Add(key, value)
+	c.size += uint64(len(value))
+
+	for c.size > c.maxSize {
+		c.entries.RemoveOldest()
+	}
+}
+
+// canonicalizeParams returns the params in the form which doesn't depend on the formatting,
+// the object keys are sorted and the hex strings are lowercased
+func canonicalizeParams(params json.RawMessage) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(canonicalizeValue(value))
+}
+
+func canonicalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return strings.ToLower(v)
+		}
+
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = canonicalizeValue(v[i])
+		}
+
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = canonicalizeValue(v[key])
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func isHistoricalNumber(number BlockNumber, head uint64) bool {
+	return number >= 0 && uint64(number) < head
+}
+
+func firstParamIsHistoricalNumber(params []json.RawMessage, head uint64) bool {
+	var number BlockNumber
+
+	return len(params) > 0 && json.Unmarshal(params[0], &number) == nil && isHistoricalNumber(number, head)
+}
+
+func firstParamIsHash(params []json.RawMessage, _ uint64) bool {
+	var hash types.Hash
+
+	return len(params) > 0 && json.Unmarshal(params[0], &hash) == nil
+}
+
+func firstParamIsHistoricalNumberOrHash(params []json.RawMessage, head uint64) bool {
+	var filter BlockNumberOrHash
+	if len(params) == 0 || json.Unmarshal(params[0], &filter) != nil {
+		return false
+	}
+
+	return filter.BlockHash != nil || isHistoricalNumber(*filter.BlockNumber, head)
+}
+
+func logQueryIsHistorical(params []json.RawMessage, head uint64) bool {
+	var query LogQuery
+	if len(params) == 0 || json.Unmarshal(params[0], &query) != nil {
+		return false
+	}
+
+	if query.BlockHash != nil {
+		return true
+	}
+
+	return isHistoricalNumber(query.fromBlock, head) && isHistoricalNumber(query.toBlock, head)
+}
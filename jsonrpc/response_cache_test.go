@@ -0,0 +1,238 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestResponseCache_CacheKey(t *testing.T) {
+	t.Parallel()
+
+	c := newResponseCache(1024)
+	hash := types.StringToHash("1").String()
+
+	cases := []struct {
+		method    string
+		params    string
+		cacheable bool
+	}{
+		{"eth_getBlockByNumber", `["0x5", true]`, true},
+		{"eth_getBlockByNumber", `["0x64", true]`, false},
+		{"eth_getBlockByNumber", `["latest", true]`, false},
+		{"eth_getBlockByNumber", `["earliest", true]`, false},
+		{"eth_getBlockByHash", `["` + hash + `", false]`, true},
+		{"eth_getTransactionReceipt", `["` + hash + `"]`, true},
+		{"eth_getBlockReceipts", `["0x5"]`, true},
+		{"eth_getBlockReceipts", `["pending"]`, false},
+		{"eth_getBlockReceipts", `[{"blockHash":"` + hash + `"}]`, true},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0x63"}]`, true},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0x64"}]`, false},
+		{"eth_getLogs", `[{"fromBlock":"0x1"}]`, false},
+		{"eth_getLogs", `[{"blockHash":"` + hash + `"}]`, true},
+		{"debug_traceTransaction", `["` + hash + `", {"tracer":"callTracer"}]`, true},
+		{"debug_traceBlockByNumber", `["0x5"]`, true},
+		{"eth_getBalance", `["0x0000000000000000000000000000000000000001", "0x5"]`, false},
+		{"eth_getBlockByNumber", `{"malformed"`, false},
+		{"eth_getBlockByNumber", `[]`, false},
+	}
+
+	for _, tc := range cases {
+		_, ok := c.cacheKey(Request{Method: tc.method, Params: json.RawMessage(tc.params)}, 100)
+		assert.Equal(t, tc.cacheable, ok, "%s %s", tc.method, tc.params)
+	}
+
+	// the formatting of the params doesn't change the key
+	key1, ok := c.cacheKey(Request{
+		Method: "eth_getLogs",
+		Params: json.RawMessage(`[{"toBlock":"0xA","fromBlock":"0x1"}]`),
+	}, 100)
+	require.True(t, ok)
+
+	key2, ok := c.cacheKey(Request{
+		Method: "eth_getLogs",
+		Params: json.RawMessage(`[ { "fromBlock": "0x1", "toBlock": "0xa" } ]`),
+	}, 100)
+	require.True(t, ok)
+	assert.Equal(t, key1, key2)
+
+	key3, ok := c.cacheKey(Request{
+		Method: "eth_getLogs",
+		Params: json.RawMessage(`[{"fromBlock":"0x1","toBlock":"0xb"}]`),
+	}, 100)
+	require.True(t, ok)
+	assert.NotEqual(t, key1, key3)
+}
+
+func TestResponseCache_Size(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newResponseCache(0))
+
+	c := newResponseCache(10)
+
+	c.add("a", []byte("1234"), 0)
+	c.add("b", []byte("5678"), 0)
+
+	// the empty and the oversized results are not cached
+	c.add("null", []byte("null"), 0)
+	c.add("big", []byte("12345678901"), 0)
+
+	_, _, ok := c.get("null")
+	require.False(t, ok)
+
+	_, _, ok = c.get("big")
+	require.False(t, ok)
+
+	// "a" is used recently, so "b" is evicted
+	value, _, ok := c.get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("1234"), value)
+
+	c.add("c", []byte("9012"), 0)
+	assert.Equal(t, uint64(8), c.size)
+
+	_, _, ok = c.get("b")
+	require.False(t, ok)
+
+	_, _, ok = c.get("c")
+	require.True(t, ok)
+}
+
+func TestResponseCache_Purge(t *testing.T) {
+	t.Parallel()
+
+	c := newResponseCache(1024)
+	subscription := blockchain.NewMockSubscription()
+
+	go c.run(subscription)
+
+	c.add("a", []byte("1234"), 0)
+
+	// the new head and the forks don't purge the cache
+	subscription.Push(&blockchain.Event{Type: blockchain.EventHead})
+	subscription.Push(&blockchain.Event{Type: blockchain.EventFork})
+
+	_, generation, ok := c.get("a")
+	require.True(t, ok)
+
+	// the result missed before the reorg is not added after it
+	_, missGeneration, ok := c.get("b")
+	require.False(t, ok)
+	assert.Equal(t, generation, missGeneration)
+
+	subscription.Push(&blockchain.Event{Type: blockchain.EventReorg})
+
+	require.Eventually(t, func() bool {
+		_, _, ok := c.get("a")
+
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), c.size)
+
+	c.add("b", []byte("5678"), missGeneration)
+
+	_, _, ok = c.get("b")
+	require.False(t, ok)
+}
+
+// responseCacheTestStore returns a new subscription to each subscriber, so all of them receive the events
+type responseCacheTestStore struct {
+	*mockStore
+
+	lock          sync.Mutex
+	subscriptions []*blockchain.MockSubscription
+}
+
+func (s *responseCacheTestStore) SubscribeEvents() blockchain.Subscription {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	subscription := blockchain.NewMockSubscription()
+	s.subscriptions = append(s.subscriptions, subscription)
+
+	return subscription
+}
+
+func (s *responseCacheTestStore) pushEvent(evnt *blockchain.Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, subscription := range s.subscriptions {
+		subscription.Push(evnt)
+	}
+}
+
+func TestDispatcher_ResponseCache(t *testing.T) {
+	t.Parallel()
+
+	store := &responseCacheTestStore{mockStore: newMockStore()}
+	for i := uint64(1); i <= 10; i++ {
+		store.addHeader(&types.Header{Number: i, GasLimit: 100})
+	}
+
+	store.header = &types.Header{Number: 10}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			responseCacheSize: 1 << 20,
+		},
+	)
+
+	type gasLimitOnly struct {
+		GasLimit argUint64 `json:"gasLimit"`
+	}
+
+	getBlock := func(number string) *gasLimitOnly {
+		t.Helper()
+
+		res, err := dispatcher.Handle([]byte(
+			`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["` + number + `", false]}`,
+		))
+		require.NoError(t, err)
+
+		resp := &SuccessResponse{}
+		require.NoError(t, json.Unmarshal(res, resp))
+		require.Nil(t, resp.Error)
+
+		b := &gasLimitOnly{}
+		require.NoError(t, json.Unmarshal(resp.Result, b))
+
+		return b
+	}
+
+	assert.Equal(t, argUint64(100), getBlock("0x5").GasLimit)
+	assert.Equal(t, argUint64(100), getBlock("0x9").GasLimit)
+
+	for _, header := range store.historicalHeaders {
+		header.GasLimit = 200
+	}
+
+	// the historical block is served from the cache
+	assert.Equal(t, argUint64(100), getBlock("0x5").GasLimit)
+
+	// the head is not cached
+	store.header = store.historicalHeaders[len(store.historicalHeaders)-1]
+	assert.Equal(t, argUint64(200), getBlock("latest").GasLimit)
+
+	// the lower head alone doesn't purge the cache
+	store.header = &types.Header{Number: 9}
+	assert.Equal(t, argUint64(100), getBlock("0x5").GasLimit)
+
+	// the cache is purged once the chain is reorganized or rewound
+	store.pushEvent(&blockchain.Event{Type: blockchain.EventReorg})
+
+	require.Eventually(t, func() bool {
+		return getBlock("0x5").GasLimit == argUint64(200)
+	}, 5*time.Second, 10*time.Millisecond)
+}
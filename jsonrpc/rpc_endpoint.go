@@ -0,0 +1,12 @@
+package jsonrpc
+
+// RPC is the rpc jsonrpc endpoint, it describes the API served by the node
+type RPC struct {
+	document *OpenRPCDocument
+}
+
+// Discover returns the OpenRPC document of the served methods (rpc_discover)
+// Spec: https://spec.open-rpc.org/#service-discovery-method
+func (r *RPC) Discover() (interface{}, error) {
+	return r.document, nil
+}
@@ -0,0 +1,464 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const traceTypeTrace = "trace"
+
+var (
+	// ErrUnexpectedTraceResult is an error returned when the tracer returns unknown result
+	ErrUnexpectedTraceResult = errors.New("unexpected trace result")
+
+	// ErrUnsupportedTraceType is an error returned when the requested trace type isn't "trace",
+	// the "stateDiff" and "vmTrace" trace types are not supported
+	ErrUnsupportedTraceType = errors.New("unsupported trace type")
+)
+
+// traceStore provides the methods needed to replay the transactions,
+// which are the same ones the debug endpoint relies on
+type traceStore interface {
+	debugStore
+}
+
+// Trace is the trace jsonrpc endpoint, which returns parity-style flat traces
+type Trace struct {
+	store           traceStore
+	throttling      *Throttling
+	blockRangeLimit uint64
+}
+
+func NewTrace(store traceStore, requestsPerSecond uint64, blockRangeLimit uint64) *Trace {
+	return &Trace{
+		store:           store,
+		throttling:      NewThrottling(requestsPerSecond, time.Second),
+		blockRangeLimit: blockRangeLimit,
+	}
+}
+
+// TraceResult is a flat trace with the information about the transaction it belongs to
+type TraceResult struct {
+	*flattracer.Trace
+	BlockHash           types.Hash  `json:"blockHash"`
+	BlockNumber         uint64      `json:"blockNumber"`
+	TransactionHash     *types.Hash `json:"transactionHash"`
+	TransactionPosition *uint64     `json:"transactionPosition"`
+}
+
+// TraceReplayResult is the result of a replayed transaction or call,
+// only the "trace" trace type is supported, so it has no state diff and no VM trace
+type TraceReplayResult struct {
+	Output          string              `json:"output"`
+	Trace           []*flattracer.Trace `json:"trace"`
+	TransactionHash *types.Hash         `json:"transactionHash,omitempty"`
+}
+
+// TraceFilterRequest is the filter of trace_filter request
+type TraceFilterRequest struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *uint64         `json:"after"`
+	Count       *uint64         `json:"count"`
+}
+
+// Block returns the traces of all transactions in the given block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			block, err := t.getBlock(number)
+			if err != nil {
+				return nil, err
+			}
+
+			return t.traceBlock(block)
+		},
+	)
+}
+
+// Transaction returns the traces of the given transaction
+func (t *Trace) Transaction(hash types.Hash) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			tx, block := GetTxAndBlockByTxHash(hash, t.store)
+			if tx == nil {
+				return nil, fmt.Errorf("tx %s not found", hash.String())
+			}
+
+			if block.Number() == 0 {
+				return nil, ErrTraceGenesisBlock
+			}
+
+			tracer, cancel := newFlatTracer()
+			defer cancel()
+
+			res, err := t.store.TraceTxn(block, tx.Hash, tracer)
+			if err != nil {
+				return nil, err
+			}
+
+			traces, err := toFlatTraces(res)
+			if err != nil {
+				return nil, err
+			}
+
+			position := uint64(0)
+
+			for idx, blockTx := range block.Transactions {
+				if blockTx.Hash == tx.Hash {
+					position = uint64(idx)
+
+					break
+				}
+			}
+
+			return newTraceResults(block, tx.Hash, position, traces), nil
+		},
+	)
+}
+
+// Filter returns the traces matching the given filter
+func (t *Trace) Filter(filter TraceFilterRequest) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			from, to, err := t.getFilterRange(filter)
+			if err != nil {
+				return nil, err
+			}
+
+			var (
+				fromAddresses = toAddressSet(filter.FromAddress)
+				toAddresses   = toAddressSet(filter.ToAddress)
+				skipped       = uint64(0)
+				results       = make([]*TraceResult, 0)
+			)
+
+			for i := from; i <= to; i++ {
+				block, ok := t.store.GetBlockByNumber(i, true)
+				if !ok {
+					break
+				}
+
+				if len(block.Transactions) == 0 {
+					continue
+				}
+
+				blockResults, err := t.traceBlock(block)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, res := range blockResults {
+					if !res.matches(fromAddresses, toAddresses) {
+						continue
+					}
+
+					if filter.After != nil && skipped < *filter.After {
+						skipped++
+
+						continue
+					}
+
+					results = append(results, res)
+
+					if filter.Count != nil && uint64(len(results)) >= *filter.Count {
+						return results, nil
+					}
+				}
+			}
+
+			return results, nil
+		},
+	)
+}
+
+// Call executes the given call and returns its traces
+func (t *Trace) Call(
+	arg *txnArgs,
+	traceTypes []string,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			if err := validateTraceTypes(traceTypes); err != nil {
+				return nil, err
+			}
+
+			header, err := GetHeaderFromBlockNumberOrHash(filter, t.store)
+			if err != nil {
+				return nil, ErrHeaderNotFound
+			}
+
+			tx, err := DecodeTxn(arg, header.Number, t.store, true)
+			if err != nil {
+				return nil, err
+			}
+
+			// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+			if tx.Gas == 0 {
+				tx.Gas = header.GasLimit
+			}
+
+			tracer, cancel := newFlatTracer()
+			defer cancel()
+
+			res, err := t.store.TraceCall(tx, header, nil, nil, tracer)
+			if err != nil {
+				return nil, err
+			}
+
+			traces, err := toFlatTraces(res)
+			if err != nil {
+				return nil, err
+			}
+
+			return newTraceReplayResult(traces, nil), nil
+		},
+	)
+}
+
+// ReplayBlockTransactions replays all transactions in the given block and returns their traces
+func (t *Trace) ReplayBlockTransactions(number BlockNumber, traceTypes []string) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			if err := validateTraceTypes(traceTypes); err != nil {
+				return nil, err
+			}
+
+			block, err := t.getBlock(number)
+			if err != nil {
+				return nil, err
+			}
+
+			blockTraces, err := t.replayBlock(block)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]*TraceReplayResult, len(blockTraces))
+
+			for idx, traces := range blockTraces {
+				results[idx] = newTraceReplayResult(traces, &block.Transactions[idx].Hash)
+			}
+
+			return results, nil
+		},
+	)
+}
+
+func (t *Trace) getBlock(number BlockNumber) (*types.Block, error) {
+	num, err := GetNumericBlockNumber(number, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	return block, nil
+}
+
+// getFilterRange resolves the block range of the filter and checks it against the block range limit
+func (t *Trace) getFilterRange(filter TraceFilterRequest) (uint64, uint64, error) {
+	fromBlock, toBlock := EarliestBlockNumber, LatestBlockNumber
+
+	if filter.FromBlock != nil {
+		fromBlock = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		toBlock = *filter.ToBlock
+	}
+
+	from, err := GetNumericBlockNumber(fromBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if to < from {
+		return 0, 0, ErrIncorrectBlockRange
+	}
+
+	// genesis block has no transactions to trace
+	if from == 0 {
+		from = 1
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+		return 0, 0, ErrBlockRangeTooHigh
+	}
+
+	return from, to, nil
+}
+
+// replayBlock returns the flat traces of each transaction in the block
+func (t *Trace) replayBlock(block *types.Block) ([][]*flattracer.Trace, error) {
+	tracer, cancel := newFlatTracer()
+	defer cancel()
+
+	res, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTraces := make([][]*flattracer.Trace, len(res))
+
+	for idx, txRes := range res {
+		if blockTraces[idx], err = toFlatTraces(txRes); err != nil {
+			return nil, err
+		}
+	}
+
+	return blockTraces, nil
+}
+
+func (t *Trace) traceBlock(block *types.Block) ([]*TraceResult, error) {
+	blockTraces, err := t.replayBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*TraceResult, 0)
+
+	for idx, traces := range blockTraces {
+		results = append(
+			results,
+			newTraceResults(block, block.Transactions[idx].Hash, uint64(idx), traces)...,
+		)
+	}
+
+	return results, nil
+}
+
+// matches returns true if the trace matches the given sets of addresses,
+// an empty set matches all the traces
+func (r *TraceResult) matches(fromAddresses, toAddresses map[types.Address]struct{}) bool {
+	var from, to types.Address
+
+	switch action := r.Action.(type) {
+	case *flattracer.CallAction:
+		from, to = action.From, action.To
+	case *flattracer.CreateAction:
+		from = action.From
+
+		if result, ok := r.Result.(*flattracer.CreateResult); ok {
+			to = result.Address
+		}
+	case *flattracer.SuicideAction:
+		from, to = action.Address, action.RefundAddress
+	}
+
+	return containsAddress(fromAddresses, from) && containsAddress(toAddresses, to)
+}
+
+func containsAddress(set map[types.Address]struct{}, addr types.Address) bool {
+	if len(set) == 0 {
+		return true
+	}
+
+	_, ok := set[addr]
+
+	return ok
+}
+
+func toAddressSet(addresses []types.Address) map[types.Address]struct{} {
+	set := make(map[types.Address]struct{}, len(addresses))
+
+	for _, addr := range addresses {
+		set[addr] = struct{}{}
+	}
+
+	return set
+}
+
+func validateTraceTypes(traceTypes []string) error {
+	for _, typ := range traceTypes {
+		if typ != traceTypeTrace {
+			return fmt.Errorf("%w: %s", ErrUnsupportedTraceType, typ)
+		}
+	}
+
+	return nil
+}
+
+func newTraceResults(
+	block *types.Block,
+	txHash types.Hash,
+	position uint64,
+	traces []*flattracer.Trace,
+) []*TraceResult {
+	results := make([]*TraceResult, len(traces))
+
+	for idx, trace := range traces {
+		hash, pos := txHash, position
+
+		results[idx] = &TraceResult{
+			Trace:               trace,
+			BlockHash:           block.Hash(),
+			BlockNumber:         block.Number(),
+			TransactionHash:     &hash,
+			TransactionPosition: &pos,
+		}
+	}
+
+	return results
+}
+
+func newTraceReplayResult(traces []*flattracer.Trace, txHash *types.Hash) *TraceReplayResult {
+	output := "0x"
+
+	if len(traces) > 0 {
+		switch result := traces[0].Result.(type) {
+		case *flattracer.CallResult:
+			output = result.Output
+		case *flattracer.CreateResult:
+			output = result.Code
+		}
+	}
+
+	return &TraceReplayResult{
+		Output:          output,
+		Trace:           traces,
+		TransactionHash: txHash,
+	}
+}
+
+// newFlatTracer creates a flat tracer which is cancelled after the default trace timeout
+func newFlatTracer() (*flattracer.FlatTracer, context.CancelFunc) {
+	tracer := &flattracer.FlatTracer{}
+
+	return tracer, cancelTracerOnTimeout(tracer, defaultTraceTimeout)
+}
+
+func toFlatTraces(res interface{}) ([]*flattracer.Trace, error) {
+	traces, ok := res.([]*flattracer.Trace)
+	if !ok {
+		return nil, ErrUnexpectedTraceResult
+	}
+
+	return traces, nil
+}
@@ -0,0 +1,329 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testTraceFrom = types.StringToAddress("1")
+	testTraceTo   = types.StringToAddress("2")
+	testTraceTo2  = types.StringToAddress("3")
+)
+
+func newTestFlatTraces(from, to types.Address, output string) []*flattracer.Trace {
+	return []*flattracer.Trace{
+		{
+			Action: &flattracer.CallAction{
+				CallType: "call",
+				From:     from,
+				To:       to,
+				Gas:      "0x64",
+				Input:    "0x",
+				Value:    "0x0",
+			},
+			Result:       &flattracer.CallResult{GasUsed: "0x1", Output: output},
+			TraceAddress: []int{},
+			Type:         flattracer.TraceTypeCall,
+		},
+	}
+}
+
+func newTestTraceBlock(number uint64, txHashes ...types.Hash) *types.Block {
+	block := &types.Block{
+		Header: createTestHeader(number, nil),
+	}
+
+	for _, hash := range txHashes {
+		block.Transactions = append(block.Transactions, createTestTransaction(hash))
+	}
+
+	return block
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock(10, testTxHash1)
+	traces := newTestFlatTraces(testTraceFrom, testTraceTo, "0x01")
+
+	store := &debugEndpointMockStore{
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			assert.Equal(t, uint64(10), num)
+			assert.True(t, full)
+
+			return block, true
+		},
+		traceBlockFn: func(b *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+			assert.Equal(t, block, b)
+			assert.IsType(t, &flattracer.FlatTracer{}, tr)
+
+			return []interface{}{traces}, nil
+		},
+	}
+
+	endpoint := NewTrace(store, 1, 0)
+
+	res, err := endpoint.Block(BlockNumber(10))
+	require.NoError(t, err)
+
+	position := uint64(0)
+
+	require.Equal(t, []*TraceResult{
+		{
+			Trace:               traces[0],
+			BlockHash:           block.Hash(),
+			BlockNumber:         10,
+			TransactionHash:     &testTxHash1,
+			TransactionPosition: &position,
+		},
+	}, res)
+
+	_, err = endpoint.Block(BlockNumber(0))
+	require.ErrorIs(t, err, ErrTraceGenesisBlock)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	txHash2 := types.BytesToHash([]byte{2})
+	block := newTestTraceBlock(10, testTxHash1, txHash2)
+	traces := newTestFlatTraces(testTraceFrom, testTraceTo, "0x01")
+
+	store := &debugEndpointMockStore{
+		readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+			return block.Hash(), hash == txHash2
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			assert.Equal(t, block.Hash(), hash)
+
+			return block, true
+		},
+		traceTxnFn: func(b *types.Block, hash types.Hash, tr tracer.Tracer) (interface{}, error) {
+			assert.Equal(t, txHash2, hash)
+
+			return traces, nil
+		},
+	}
+
+	endpoint := NewTrace(store, 1, 0)
+
+	res, err := endpoint.Transaction(txHash2)
+	require.NoError(t, err)
+
+	results, ok := res.([]*TraceResult)
+	require.True(t, ok)
+	require.Len(t, results, 1)
+	require.Equal(t, txHash2, *results[0].TransactionHash)
+	require.Equal(t, uint64(1), *results[0].TransactionPosition)
+
+	_, err = endpoint.Transaction(testTxHash1)
+	require.Error(t, err)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	txHash2 := types.BytesToHash([]byte{2})
+	blocks := map[uint64]*types.Block{
+		1: newTestTraceBlock(1, testTxHash1),
+		2: newTestTraceBlock(2),
+		3: newTestTraceBlock(3, txHash2),
+	}
+	blockTraces := map[uint64][]*flattracer.Trace{
+		1: newTestFlatTraces(testTraceFrom, testTraceTo, "0x01"),
+		3: newTestFlatTraces(testTraceFrom, testTraceTo2, "0x02"),
+	}
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[3].Header
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			block, ok := blocks[num]
+
+			return block, ok
+		},
+		traceBlockFn: func(b *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+			require.NotEqual(t, uint64(2), b.Number(), "block without transactions should not be traced")
+
+			return []interface{}{blockTraces[b.Number()]}, nil
+		},
+	}
+
+	blockNumber := func(n int64) *BlockNumber {
+		num := BlockNumber(n)
+
+		return &num
+	}
+
+	uintPtr := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name     string
+		limit    uint64
+		filter   TraceFilterRequest
+		expected []types.Hash
+		err      error
+	}{
+		{
+			name:     "should return all traces by default",
+			filter:   TraceFilterRequest{},
+			expected: []types.Hash{testTxHash1, txHash2},
+		},
+		{
+			name: "should filter by to address",
+			filter: TraceFilterRequest{
+				ToAddress: []types.Address{testTraceTo2},
+			},
+			expected: []types.Hash{txHash2},
+		},
+		{
+			name: "should filter by from address",
+			filter: TraceFilterRequest{
+				FromAddress: []types.Address{testTraceTo},
+			},
+			expected: []types.Hash{},
+		},
+		{
+			name: "should apply after and count",
+			filter: TraceFilterRequest{
+				After: uintPtr(1),
+				Count: uintPtr(1),
+			},
+			expected: []types.Hash{txHash2},
+		},
+		{
+			name: "should filter by block range",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumber(1),
+				ToBlock:   blockNumber(2),
+			},
+			expected: []types.Hash{testTxHash1},
+		},
+		{
+			name: "should return error if block range is too high",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumber(1),
+				ToBlock:   blockNumber(3),
+			},
+			limit: 1,
+			err:   ErrBlockRangeTooHigh,
+		},
+		{
+			name: "should return error if block range is incorrect",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumber(3),
+				ToBlock:   blockNumber(1),
+			},
+			err: ErrIncorrectBlockRange,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewTrace(store, 1, test.limit).Filter(test.filter)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			results, ok := res.([]*TraceResult)
+			require.True(t, ok)
+
+			hashes := make([]types.Hash, len(results))
+			for idx, result := range results {
+				hashes[idx] = *result.TransactionHash
+			}
+
+			require.Equal(t, test.expected, hashes)
+		})
+	}
+}
+
+func TestTrace_Call(t *testing.T) {
+	t.Parallel()
+
+	traces := newTestFlatTraces(testTraceFrom, testTraceTo, "0x01")
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return testLatestHeader
+		},
+		getAccountFn: func(types.Hash, types.Address) (*Account, error) {
+			return &Account{Nonce: 1}, nil
+		},
+		traceCallFn: func(
+			tx *types.Transaction,
+			header *types.Header,
+			_ types.StateOverride,
+			_ *types.BlockOverride,
+			tr tracer.Tracer,
+		) (interface{}, error) {
+			assert.Equal(t, testLatestHeader, header)
+			assert.Equal(t, testLatestHeader.GasLimit, tx.Gas)
+
+			return traces, nil
+		},
+	}
+
+	endpoint := NewTrace(store, 1, 0)
+	arg := &txnArgs{
+		From: &testTraceFrom,
+		To:   &testTraceTo,
+	}
+
+	res, err := endpoint.Call(arg, []string{"trace"}, BlockNumberOrHash{})
+	require.NoError(t, err)
+	require.Equal(t, &TraceReplayResult{
+		Output: "0x01",
+		Trace:  traces,
+	}, res)
+
+	_, err = endpoint.Call(arg, []string{"vmTrace"}, BlockNumberOrHash{})
+	require.ErrorIs(t, err, ErrUnsupportedTraceType)
+
+	_, err = endpoint.Call(arg, []string{"trace", "stateDiff"}, BlockNumberOrHash{})
+	require.ErrorIs(t, err, ErrUnsupportedTraceType)
+}
+
+func TestTrace_ReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock(10, testTxHash1)
+	traces := newTestFlatTraces(testTraceFrom, testTraceTo, "0x01")
+
+	store := &debugEndpointMockStore{
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			return block, true
+		},
+		traceBlockFn: func(b *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+			return []interface{}{traces}, nil
+		},
+	}
+
+	res, err := NewTrace(store, 1, 0).ReplayBlockTransactions(BlockNumber(10), []string{"trace"})
+	require.NoError(t, err)
+	require.Equal(t, []*TraceReplayResult{
+		{
+			Output:          "0x01",
+			Trace:           traces,
+			TransactionHash: &block.Transactions[0].Hash,
+		},
+	}, res)
+}
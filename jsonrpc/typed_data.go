@@ -0,0 +1,327 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// eip712Domain is the type of the domain of the typed data
+const eip712Domain = "EIP712Domain"
+
+var (
+	errMissingDomainType = errors.New("the typed data has no EIP712Domain type")
+	errInvalidInteger    = errors.New("invalid integer")
+)
+
+// typedDataField is the field of the struct type of the typed data
+type typedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// typedData is the EIP-712 typed data signed by eth_signTypedData_v4,
+// see https://eips.ethereum.org/EIPS/eip-712
+type typedData struct {
+	Types       map[string][]typedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// decodeTypedData decodes the typed data, which the clients send either as the object or as its JSON string
+func decodeTypedData(raw json.RawMessage) (*typedData, error) {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		raw = json.RawMessage(encoded)
+	}
+
+	// the numbers are kept as they are, as they may not fit into float64
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	data := &typedData{}
+	if err := decoder.Decode(data); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %w", err)
+	}
+
+	return data, nil
+}
+
+// hash returns the hash of the typed data which is signed: keccak256(0x19 0x01 || domainSeparator || hashStruct(message))
+func (t *typedData) hash() ([]byte, error) {
+	if _, ok := t.Types[eip712Domain]; !ok {
+		return nil, errMissingDomainType
+	}
+
+	domainSeparator, err := t.hashStruct(eip712Domain, t.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain: %w", err)
+	}
+
+	// the message is omitted if only the domain is signed
+	if t.PrimaryType == eip712Domain {
+		return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator), nil
+	}
+
+	if _, ok := t.Types[t.PrimaryType]; !ok {
+		return nil, fmt.Errorf("unknown primary type %s", t.PrimaryType)
+	}
+
+	messageHash, err := t.hashStruct(t.PrimaryType, t.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+func (t *typedData) hashStruct(typ string, data map[string]interface{}) ([]byte, error) {
+	encoded, err := t.encodeData(typ, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(crypto.Keccak256([]byte(t.encodeType(typ))), encoded), nil
+}
+
+// encodeType returns the type with its fields followed by the referenced struct types sorted by name,
+// e.g. Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (t *typedData) encodeType(primary string) string {
+	deps := make(map[string]struct{})
+	t.dependencies(primary, deps)
+	delete(deps, primary)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+
+	sort.Strings(sorted)
+
+	var b strings.Builder
+
+	for _, typ := range append([]string{primary}, sorted...) {
+		fields := make([]string, 0, len(t.Types[typ]))
+		for _, field := range t.Types[typ] {
+			fields = append(fields, field.Type+" "+field.Name)
+		}
+
+		b.WriteString(typ + "(" + strings.Join(fields, ",") + ")")
+	}
+
+	return b.String()
+}
+
+// dependencies collects the struct types referenced by the type, including itself
+func (t *typedData) dependencies(typ string, deps map[string]struct{}) {
+	typ, _, _ = strings.Cut(typ, "[")
+
+	if _, ok := deps[typ]; ok {
+		return
+	}
+
+	fields, ok := t.Types[typ]
+	if !ok {
+		return
+	}
+
+	deps[typ] = struct{}{}
+
+	for _, field := range fields {
+		t.dependencies(field.Type, deps)
+	}
+}
+
+func (t *typedData) encodeData(typ string, data map[string]interface{}) ([]byte, error) {
+	fields := t.Types[typ]
+	encoded := make([]byte, 0, 32*len(fields))
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing value of the field %s", field.Name)
+		}
+
+		item, err := t.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of the field %s: %w", field.Name, err)
+		}
+
+		encoded = append(encoded, item...)
+	}
+
+	return encoded, nil
+}
+
+// encodeValue returns the 32 bytes encoding of the value, the dynamic
+// values, the structs and the arrays are encoded by their hashes
+func (t *typedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array of %s", typ)
+		}
+
+		elemType := typ[:strings.LastIndex(typ, "[")]
+		encoded := make([]byte, 0, 32*len(items))
+
+		for _, item := range items {
+			itemEncoded, err := t.encodeValue(elemType, item)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded = append(encoded, itemEncoded...)
+		}
+
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if _, ok := t.Types[typ]; ok {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object of %s", typ)
+		}
+
+		return t.hashStruct(typ, fields)
+	}
+
+	switch {
+	case typ == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.New("expected string")
+		}
+
+		return crypto.Keccak256([]byte(str)), nil
+	case typ == "bytes":
+		buf, err := decodeTypedBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return crypto.Keccak256(buf), nil
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("expected bool")
+		}
+
+		encoded := make([]byte, 32)
+		if b {
+			encoded[31] = 1
+		}
+
+		return encoded, nil
+	case typ == "address":
+		buf, err := decodeTypedBytes(value)
+		if err != nil || len(buf) != types.AddressLength {
+			return nil, errors.New("expected address")
+		}
+
+		return common32(buf, true), nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+
+		buf, err := decodeTypedBytes(value)
+		if err != nil || len(buf) > size {
+			return nil, fmt.Errorf("expected %s", typ)
+		}
+
+		return common32(buf, false), nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		return encodeTypedInteger(typ, value)
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+}
+
+// common32 pads the bytes to 32 bytes, the numbers and the addresses are padded on the left
+func common32(buf []byte, left bool) []byte {
+	encoded := make([]byte, 32)
+	if left {
+		copy(encoded[32-len(buf):], buf)
+	} else {
+		copy(encoded, buf)
+	}
+
+	return encoded
+}
+
+func decodeTypedBytes(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok || !strings.HasPrefix(str, "0x") {
+		return nil, errors.New("expected hex string")
+	}
+
+	return hex.DecodeString(str[2:])
+}
+
+// encodeTypedInteger encodes the integer given as the number, the decimal or the hex string,
+// the negative integers are encoded in the two's complement
+func encodeTypedInteger(typ string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typ, "int")
+
+	bits := 256
+	if size := strings.TrimLeft(typ, "uint"); size != "" {
+		var err error
+		if bits, err = strconv.Atoi(size); err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+	}
+
+	var (
+		n  *big.Int
+		ok bool
+	)
+
+	switch v := value.(type) {
+	case json.Number:
+		n, ok = new(big.Int).SetString(v.String(), 10)
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			n, ok = new(big.Int).SetString(v[2:], 16)
+		} else {
+			n, ok = new(big.Int).SetString(v, 10)
+		}
+	case float64:
+		var accuracy big.Accuracy
+
+		n, accuracy = big.NewFloat(v).Int(nil)
+		ok = accuracy == big.Exact
+	}
+
+	if !ok || n == nil {
+		return nil, errInvalidInteger
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		limit.Rsh(limit, 1)
+
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%s out of range of %s", n, typ)
+		}
+	} else if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%s out of range of %s", n, typ)
+	}
+
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return n.FillBytes(make([]byte, 32)), nil
+}
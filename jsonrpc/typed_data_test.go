@@ -0,0 +1,166 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// mailTypedData is the example of EIP-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedData_Hash(t *testing.T) {
+	t.Parallel()
+
+	data, err := decodeTypedData(json.RawMessage(mailTypedData))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		data.encodeType("Mail"),
+	)
+
+	hash, err := data.hash()
+	require.NoError(t, err)
+	assert.Equal(t,
+		types.StringToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"),
+		types.BytesToHash(hash),
+	)
+
+	// the typed data can also be sent as the JSON string
+	encoded, err := json.Marshal(mailTypedData)
+	require.NoError(t, err)
+
+	data, err = decodeTypedData(encoded)
+	require.NoError(t, err)
+
+	encodedHash, err := data.hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, encodedHash)
+}
+
+func TestTypedData_HashErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		modify func(data *typedData)
+	}{
+		{
+			"missing domain type",
+			func(data *typedData) { delete(data.Types, eip712Domain) },
+		},
+		{
+			"unknown primary type",
+			func(data *typedData) { data.PrimaryType = "Unknown" },
+		},
+		{
+			"missing field",
+			func(data *typedData) { delete(data.Message, "contents") },
+		},
+		{
+			"invalid address",
+			func(data *typedData) { data.Domain["verifyingContract"] = "0x01" },
+		},
+		{
+			"integer out of range",
+			func(data *typedData) { data.Types[eip712Domain][2].Type = "uint8"; data.Domain["chainId"] = "256" },
+		},
+		{
+			"unknown type",
+			func(data *typedData) { data.Types["Mail"][2].Type = "text" },
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := decodeTypedData(json.RawMessage(mailTypedData))
+			require.NoError(t, err)
+
+			c.modify(data)
+
+			_, err = data.hash()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTypedData_EncodeInteger(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := encodeTypedInteger("int8", json.Number("-1"))
+	require.NoError(t, err)
+	assert.Equal(t, types.StringToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff").Bytes(), encoded)
+
+	encoded, err = encodeTypedInteger("uint256", "0x0100")
+	require.NoError(t, err)
+	assert.Equal(t, types.StringToHash("0x100").Bytes(), encoded)
+
+	_, err = encodeTypedInteger("int8", json.Number("128"))
+	require.Error(t, err)
+
+	_, err = encodeTypedInteger("uint", json.Number("-1"))
+	require.Error(t, err)
+
+	_, err = encodeTypedInteger("uint7", json.Number("1"))
+	require.Error(t, err)
+}
+
+func TestTypedData_Sign(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.ParseECDSAPrivateKey(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+
+	data, err := decodeTypedData(json.RawMessage(mailTypedData))
+	require.NoError(t, err)
+
+	hash, err := data.hash()
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(key, hash)
+	require.NoError(t, err)
+
+	assert.Equal(t, types.StringToHash("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d").Bytes(), sig[:32])
+	assert.Equal(t, types.StringToHash("0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562").Bytes(), sig[32:64])
+	assert.Equal(t, byte(28), sig[64]+27)
+}
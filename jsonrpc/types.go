@@ -7,6 +7,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/transfertracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -18,24 +20,25 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    *argBig        `json:"gasPrice,omitempty"`
-	GasTipCap   *argBig        `json:"maxPriorityFeePerGas,omitempty"`
-	GasFeeCap   *argBig        `json:"maxFeePerGas,omitempty"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
-	ChainID     *argBig        `json:"chainId,omitempty"`
-	Type        argUint64      `json:"type"`
+	Nonce       argUint64           `json:"nonce"`
+	GasPrice    *argBig             `json:"gasPrice,omitempty"`
+	GasTipCap   *argBig             `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap   *argBig             `json:"maxFeePerGas,omitempty"`
+	Gas         argUint64           `json:"gas"`
+	To          *types.Address      `json:"to"`
+	Value       argBig              `json:"value"`
+	Input       argBytes            `json:"input"`
+	V           argBig              `json:"v"`
+	R           argBig              `json:"r"`
+	S           argBig              `json:"s"`
+	Hash        types.Hash          `json:"hash"`
+	From        types.Address       `json:"from"`
+	BlockHash   *types.Hash         `json:"blockHash"`
+	BlockNumber *argUint64          `json:"blockNumber"`
+	TxIndex     *argUint64          `json:"transactionIndex"`
+	ChainID     *argBig             `json:"chainId,omitempty"`
+	Type        argUint64           `json:"type"`
+	AccessList  *types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -95,6 +98,15 @@ func toTransaction(
 		res.ChainID = &chainID
 	}
 
+	if t.Type == types.AccessListTx || t.Type == types.DynamicFeeTx {
+		accessList := t.AccessList
+		if accessList == nil {
+			accessList = types.TxAccessList{}
+		}
+
+		res.AccessList = &accessList
+	}
+
 	if txIndex != nil {
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
@@ -237,6 +249,30 @@ type Log struct {
 	BlockHash   types.Hash    `json:"blockHash"`
 	LogIndex    argUint64     `json:"logIndex"`
 	Removed     bool          `json:"removed"`
+
+	// Transfer is the decoded ERC-20 Transfer event, if requested
+	Transfer *transferLog `json:"transfer,omitempty"`
+}
+
+// transferLog is the decoded ERC-20 Transfer event
+type transferLog struct {
+	From  types.Address `json:"from"`
+	To    types.Address `json:"to"`
+	Value argBig        `json:"value"`
+}
+
+// decodeTransferLog decodes the log if it's an ERC-20 Transfer event, otherwise it returns nil.
+// The ERC-721 Transfer events are not decoded since their token id is indexed
+func decodeTransferLog(log *Log) *transferLog {
+	if len(log.Topics) != 3 || log.Topics[0] != transfertracer.TransferEventTopic || len(log.Data) != types.HashLength {
+		return nil
+	}
+
+	return &transferLog{
+		From:  types.BytesToAddress(log.Topics[1].Bytes()),
+		To:    types.BytesToAddress(log.Topics[2].Bytes()),
+		Value: argBig(*new(big.Int).SetBytes(log.Data)),
+	}
 }
 
 func toLogs(srcLogs []*types.Log, baseIdx, txIdx uint64, header *types.Header, txHash types.Hash) []*Log {
@@ -376,17 +412,70 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From      *types.Address
-	To        *types.Address
-	Gas       *argUint64
-	GasPrice  *argBytes
-	GasTipCap *argBytes
-	GasFeeCap *argBytes
-	Value     *argBytes
-	Data      *argBytes
-	Input     *argBytes
-	Nonce     *argUint64
-	Type      *argUint64
+	From       *types.Address
+	To         *types.Address
+	Gas        *argUint64
+	GasPrice   *argBytes
+	GasTipCap  *argBytes
+	GasFeeCap  *argBytes
+	Value      *argBytes
+	Data       *argBytes
+	Input      *argBytes
+	Nonce      *argUint64
+	Type       *argUint64
+	AccessList *types.TxAccessList
+}
+
+// accessListResult is the result of the eth_createAccessList call
+type accessListResult struct {
+	AccessList types.TxAccessList `json:"accessList"`
+	Error      string             `json:"error,omitempty"`
+	GasUsed    argUint64          `json:"gasUsed"`
+}
+
+// signTransactionResult is the result of the eth_signTransaction call
+type signTransactionResult struct {
+	Raw argBytes     `json:"raw"`
+	Tx  *transaction `json:"tx"`
+}
+
+// storageRangeResult is the result of the debug_storageRangeAt call, the slots are keyed by
+// their hashes, and their keys are always null, as the node doesn't store the preimages of the trie keys
+type storageRangeResult struct {
+	Storage map[types.Hash]storageEntry `json:"storage"`
+	NextKey *types.Hash                 `json:"nextKey"`
+}
+
+type storageEntry struct {
+	Key   *types.Hash `json:"key"`
+	Value types.Hash  `json:"value"`
+}
+
+// stateDump is the result of the debug_accountRange and debug_dumpBlock calls,
+// the accounts are keyed by the hashes of their addresses
+type stateDump struct {
+	Root     types.Hash                  `json:"root"`
+	Accounts map[types.Hash]*dumpAccount `json:"accounts"`
+	Next     *types.Hash                 `json:"next,omitempty"`
+}
+
+type dumpAccount struct {
+	Balance  string                    `json:"balance"`
+	Nonce    uint64                    `json:"nonce"`
+	Root     types.Hash                `json:"root"`
+	CodeHash types.Hash                `json:"codeHash"`
+	Code     argBytes                  `json:"code,omitempty"`
+	Storage  map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// badBlock is an entry of the debug_getBadBlocks result
+type badBlock struct {
+	Hash      types.Hash `json:"hash"`
+	Block     *block     `json:"block"`
+	RLP       argBytes   `json:"rlp"`
+	Reason    string     `json:"reason"`
+	Source    string     `json:"source"`
+	Timestamp argUint64  `json:"timestamp"`
 }
 
 type progression struct {
@@ -420,3 +509,55 @@ func convertToArgUint64SliceSlice(slice [][]uint64) [][]argUint64 {
 
 	return argSlice
 }
+
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+func toAccountProof(address types.Address, proof *itrie.AccountProof) *accountProof {
+	res := &accountProof{
+		Address:      address,
+		AccountProof: toProofNodes(proof.Proof),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]*storageProof, len(proof.StorageProofs)),
+	}
+
+	if proof.Account != nil {
+		res.Balance = argBig(*proof.Account.Balance)
+		res.CodeHash = types.BytesToHash(proof.Account.CodeHash)
+		res.Nonce = argUint64(proof.Account.Nonce)
+		res.StorageHash = proof.Account.Root
+	}
+
+	for i, sp := range proof.StorageProofs {
+		res.StorageProof[i] = &storageProof{
+			Key:   sp.Key,
+			Value: argBig(*new(big.Int).SetBytes(sp.Value.Bytes())),
+			Proof: toProofNodes(sp.Proof),
+		}
+	}
+
+	return res
+}
+
+func toProofNodes(nodes [][]byte) []argBytes {
+	res := make([]argBytes, len(nodes))
+	for i, node := range nodes {
+		res[i] = node
+	}
+
+	return res
+}
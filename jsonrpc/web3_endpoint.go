@@ -19,6 +19,11 @@ var clientVersionTemplate = "%s/%s/%s-%s/%s"
 // Example: "polygon-edge-53105/v1.1.0/linux-amd64/go1.20.0"
 // Spec: https://ethereum.org/en/developers/docs/apis/json-rpc/#web3_clientversion
 func (w *Web3) ClientVersion() (interface{}, error) {
+	return clientVersion(w.chainName), nil
+}
+
+// clientVersion returns the version of the client running the chain
+func clientVersion(chainName string) string {
 	var version string
 	if versioning.Version != "" {
 		version = versioning.Version
@@ -28,12 +33,12 @@ func (w *Web3) ClientVersion() (interface{}, error) {
 
 	return fmt.Sprintf(
 		clientVersionTemplate,
-		w.chainName,
+		chainName,
 		version,
 		runtime.GOOS,
 		runtime.GOARCH,
 		runtime.Version(),
-	), nil
+	)
 }
 
 // Sha3 returns Keccak-256 (not the standardized SHA3-256) of the given data
@@ -41,6 +41,9 @@ const (
 
 	// networkMetrics is a prefix used for network-related metrics
 	networkMetrics = "network"
+
+	// trustedPeerTag is the tag protecting the connections of the trusted peers in the connection manager
+	trustedPeerTag = "trusted"
 )
 
 const (
@@ -87,6 +90,12 @@ type Server struct {
 
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
+	trustedPeers sync.Map // map of trusted peers; peerID -> bool
+
+	dialSlots Slots // the slots of the outbound dials
+
+	dialSlotPeers sync.Map // map of peers dialed with a slot; peerID -> bool
+
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
 }
 
@@ -154,6 +163,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		dialSlots: NewSlots(config.MaxOutboundPeers),
 	}
 
 	// start gossip protocol
@@ -354,19 +364,21 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
 func (s *Server) runDial() {
-	slots := NewSlots(s.connectionCounts.maxOutboundConnectionCount)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
 
 	if err := s.Subscribe(ctx, func(event *peerEvent.PeerEvent) {
-		// Return back slot on PeerFailedToConnect or PeerDisconnected
+		// Return back slot on PeerFailedToConnect or PeerDisconnected,
+		// only if the peer was dialed with a slot
 		switch event.Type {
 		case
 			peerEvent.PeerFailedToConnect,
 			peerEvent.PeerDisconnected:
-			slots.Release()
-			s.logger.Debug("slot released", "event", event.Type, "peerID", event.PeerID)
+			if _, ok := s.dialSlotPeers.LoadAndDelete(event.PeerID); ok {
+				s.dialSlots.Release()
+				s.logger.Debug("slot released", "event", event.Type, "peerID", event.PeerID)
+			}
 		}
 	}); err != nil {
 		s.logger.Error(
@@ -398,10 +410,20 @@ func (s *Server) runDial() {
 				continue
 			}
 
-			s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
+			// the peer is already being dialed with a slot
+			if _, ok := s.dialSlotPeers.Load(peerInfo.ID); ok {
+				continue
+			}
+
+			// the trusted peers are dialed regardless of the free slots
+			if !s.IsTrustedPeer(peerInfo.ID) {
+				s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
 
-			if closed := slots.Take(ctx); closed {
-				return
+				if closed := s.dialSlots.Take(ctx); closed {
+					return
+				}
+
+				s.dialSlotPeers.Store(peerInfo.ID, true)
 			}
 
 			// the connection process is async because it involves connection (here) +
@@ -579,6 +601,37 @@ func (s *Server) joinPeer(peerInfo *peer.AddrInfo) {
 	s.addToDialQueue(peerInfo, common.PriorityRequestedDial)
 }
 
+// AddTrustedPeer marks the peer as trusted and attempts to add it to the networking server.
+// The trusted peers are connected regardless of the free connection slots,
+// and their connections are protected from being pruned
+func (s *Server) AddTrustedPeer(rawPeerMultiaddr string) error {
+	peerInfo, err := common.StringToAddrInfo(rawPeerMultiaddr)
+	if err != nil {
+		return err
+	}
+
+	s.trustedPeers.Store(peerInfo.ID, true)
+	s.host.ConnManager().Protect(peerInfo.ID, trustedPeerTag)
+
+	s.joinPeer(peerInfo)
+
+	return nil
+}
+
+// RemoveTrustedPeer removes the peer from the trusted peers, its connection is no longer protected
+// and it is dialed as any other peer
+func (s *Server) RemoveTrustedPeer(peerID peer.ID) {
+	s.trustedPeers.Delete(peerID)
+	s.host.ConnManager().Unprotect(peerID, trustedPeerTag)
+}
+
+// IsTrustedPeer checks if the peer is trusted [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	_, ok := s.trustedPeers.Load(peerID)
+
+	return ok
+}
+
 func (s *Server) Close() error {
 	err := s.host.Close()
 	s.dialQueue.Close()
@@ -134,6 +134,125 @@ func TestConnLimit_Outbound(t *testing.T) {
 	}
 }
 
+func TestConnLimit_TrustedPeer(t *testing.T) {
+	// the trusted peers are connected even if there are no free slots
+	defaultConfig := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 1
+			c.MaxOutboundPeers = 1
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: defaultConfig,
+		1: defaultConfig,
+		2: defaultConfig,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// Server 0 takes its only outbound slot
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	rawAddr, err := common.AddrInfoToString(servers[2].AddrInfo())
+	if err != nil {
+		t.Fatalf("Unable to encode the peer address, %v", err)
+	}
+
+	if err := servers[0].AddTrustedPeer(rawAddr); err != nil {
+		t.Fatalf("Unable to add trusted peer, %v", err)
+	}
+
+	assert.True(t, servers[0].IsTrustedPeer(servers[2].host.ID()))
+	assert.False(t, servers[0].IsTrustedPeer(servers[1].host.ID()))
+
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancelWait()
+
+	if _, err := WaitUntilPeerConnectsTo(waitCtx, servers[0], servers[2].host.ID()); err != nil {
+		t.Fatalf("Unable to wait for peer connect, %v", err)
+	}
+
+	assert.True(t, servers[0].host.ConnManager().IsProtected(servers[2].host.ID(), trustedPeerTag))
+
+	// the removed peer is no longer trusted nor protected
+	servers[0].RemoveTrustedPeer(servers[2].host.ID())
+
+	assert.False(t, servers[0].IsTrustedPeer(servers[2].host.ID()))
+	assert.False(t, servers[0].host.ConnManager().IsProtected(servers[2].host.ID(), trustedPeerTag))
+}
+
+func TestConnLimit_TrustedPeerSlots(t *testing.T) {
+	// the failed dials of the trusted peers don't release the slots they never took
+	defaultConfig := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 1
+			c.MaxOutboundPeers = 1
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: defaultConfig,
+		1: defaultConfig,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// Server 0 takes its only outbound slot
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	assert.Len(t, servers[0].dialSlots, 0)
+
+	// the trusted peer which isn't listening
+	_, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+
+	trustedID, err := peer.IDFromPublicKey(pub)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	failed := make(chan struct{})
+
+	err = servers[0].Subscribe(ctx, func(event *peerEvent.PeerEvent) {
+		if event.PeerID == trustedID && event.Type == peerEvent.PeerFailedToConnect {
+			close(failed)
+		}
+	})
+	assert.NoError(t, err)
+
+	if err := servers[0].AddTrustedPeer("/ip4/127.0.0.1/tcp/1/p2p/" + trustedID.String()); err != nil {
+		t.Fatalf("Unable to add trusted peer, %v", err)
+	}
+
+	select {
+	case <-failed:
+	case <-ctx.Done():
+		t.Fatal("the dial of the trusted peer didn't fail")
+	}
+
+	assert.Never(t, func() bool {
+		return len(servers[0].dialSlots) != 0
+	}, time.Second, 50*time.Millisecond)
+}
+
 func TestPeerEvent_EmitAndSubscribe(t *testing.T) {
 	server, createErr := CreateServer(&CreateServerParams{ConfigCallback: func(c *Config) {
 		c.NoDiscover = true
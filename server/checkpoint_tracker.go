@@ -0,0 +1,93 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+)
+
+// checkpointRefreshInterval is the interval of the queries of the last block checkpointed on the rootchain
+const checkpointRefreshInterval = 5 * time.Second
+
+var errCheckpointNotFetched = errors.New("the last checkpointed block is not fetched from the rootchain yet")
+
+// checkpointTracker keeps the number of the last block checkpointed on the rootchain.
+// The number is refreshed in the background, so the "finalized" and "safe" block tags
+// are served without querying the rootchain on each request
+type checkpointTracker struct {
+	logger   hclog.Logger
+	provider consensus.BridgeDataProvider
+
+	lock    sync.RWMutex
+	number  uint64
+	fetched bool
+
+	closeCh chan struct{}
+}
+
+// newCheckpointTracker returns the tracker which refreshes the last checkpointed block until it's closed
+func newCheckpointTracker(logger hclog.Logger, provider consensus.BridgeDataProvider) *checkpointTracker {
+	t := &checkpointTracker{
+		logger:   logger.Named("checkpoint_tracker"),
+		provider: provider,
+		closeCh:  make(chan struct{}),
+	}
+
+	go t.run()
+
+	return t
+}
+
+// run refreshes the last checkpointed block on start and then periodically
+func (t *checkpointTracker) run() {
+	ticker := time.NewTicker(checkpointRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		t.refresh()
+
+		select {
+		case <-ticker.C:
+		case <-t.closeCh:
+			return
+		}
+	}
+}
+
+// refresh queries the last checkpointed block, the last known block is kept if the query fails,
+// as the checkpoints never go backwards
+func (t *checkpointTracker) refresh() {
+	number, err := t.provider.GetCurrentCheckpointBlock()
+	if err != nil {
+		t.logger.Warn("failed to get the last checkpointed block", "err", err)
+
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.number = number
+	t.fetched = true
+}
+
+// CheckpointBlock returns the number of the last checkpointed block
+func (t *checkpointTracker) CheckpointBlock() (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if !t.fetched {
+		return 0, errCheckpointNotFetched
+	}
+
+	return t.number, nil
+}
+
+// Close stops the refreshing
+func (t *checkpointTracker) Close() {
+	close(t.closeCh)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -46,6 +47,8 @@ type Config struct {
 
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
+
+	AddressIndex bool
 }
 
 // Telemetry holds the config details for metric services
@@ -56,9 +59,19 @@ type Telemetry struct {
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
+	IPCPath                  string
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	CheckpointFinality       bool
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	HTTPPolicy               *jsonrpc.MethodPolicy
+	WSPolicy                 *jsonrpc.MethodPolicy
+	AuthKeysFile             string
+	IPRateLimit              *jsonrpc.IPRateLimit
+	SlowRequestThreshold     time.Duration
+	ResponseCacheSize        uint64
+	EnableAdmin              bool
+	KeystoreDir              string
 }
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
@@ -36,10 +37,13 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/transfertracer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 	"github.com/0xPolygon/polygon-edge/validate"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -48,6 +52,8 @@ import (
 var (
 	errBlockTimeMissing = errors.New("block time configuration is missing")
 	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+
+	errCheckpointFinalityNotSupported = errors.New("checkpoint finality is not supported by the consensus")
 )
 
 // Server is the central manager of the blockchain client
@@ -88,6 +94,12 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// bloomBits is the index of the logs used by the log queries
+	bloomBits *bloombits.Indexer
+
+	// checkpoints tracks the last block checkpointed on the rootchain for the checkpoint finality
+	checkpoints *checkpointTracker
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -328,6 +340,8 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	m.bloomBits = bloombits.NewIndexer(logger, db)
+
 	// here we can provide some other configuration
 	m.gasHelper, err = gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, m.blockchain)
 	if err != nil {
@@ -378,6 +392,10 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.config.AddressIndex {
+		m.blockchain.EnableAddressIndex()
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -407,6 +425,9 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// keep the index of the logs up to date with the chain
+	m.bloomBits.Start(m.blockchain)
+
 	m.txpool.SetBaseFee(m.blockchain.Header())
 	m.txpool.Start()
 
@@ -628,12 +649,86 @@ type jsonRPCHub struct {
 	consensus.Consensus
 	consensus.BridgeDataProvider
 	gasprice.GasStore
+
+	// checkpointFinality anchors the finalized block to the checkpoints submitted to the rootchain,
+	// the last checkpointed block is refreshed in the background by the checkpoint tracker
+	checkpointFinality bool
+	checkpoints        *checkpointTracker
+
+	bloomBits *bloombits.Indexer
+}
+
+// GetLogCandidates returns the blocks in the range which may contain the matching logs
+// according to the bloom-bits index, and the first block which is not indexed yet
+func (j *jsonRPCHub) GetLogCandidates(from, to uint64, filters [][][]byte) ([]uint64, uint64, error) {
+	return j.bloomBits.Match(from, to, filters)
 }
 
 func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+// GetNodeAddrs returns the p2p ID of the node and its addresses including the ID
+func (j *jsonRPCHub) GetNodeAddrs() (string, []string) {
+	info := j.Server.AddrInfo()
+
+	addrs := make([]string, 0, len(info.Addrs))
+	for _, addr := range info.Addrs {
+		addrs = append(addrs, fmt.Sprintf("%s/p2p/%s", addr, info.ID))
+	}
+
+	return info.ID.String(), addrs
+}
+
+// GetPeerInfos returns the connected peers with their protocols
+func (j *jsonRPCHub) GetPeerInfos() []*jsonrpc.PeerInfo {
+	peers := j.Server.Peers()
+	infos := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		// the peer may have been disconnected in the meantime
+		protocols, err := j.Server.GetProtocols(p.Info.ID)
+		if err != nil {
+			protocols = []string{}
+		}
+
+		addrs := make([]string, 0, len(p.Info.Addrs))
+		for _, addr := range p.Info.Addrs {
+			addrs = append(addrs, addr.String())
+		}
+
+		infos = append(infos, &jsonrpc.PeerInfo{
+			ID:    p.Info.ID.String(),
+			Addrs: addrs,
+			Caps:  protocols,
+			Network: jsonrpc.PeerNetworkInfo{
+				Trusted: j.Server.IsTrustedPeer(p.Info.ID),
+			},
+		})
+	}
+
+	return infos
+}
+
+// RemovePeer disconnects from the peer given by its p2p multiaddr or ID
+func (j *jsonRPCHub) RemovePeer(rawPeer string) error {
+	peerID, err := peer.Decode(rawPeer)
+	if err != nil {
+		info, infoErr := peer.AddrInfoFromString(rawPeer)
+		if infoErr != nil {
+			return fmt.Errorf("invalid peer %s: %w", rawPeer, infoErr)
+		}
+
+		peerID = info.ID
+	}
+
+	// the removed peer is no longer trusted, so it isn't dialed again regardless of the free slots
+	j.Server.RemoveTrustedPeer(peerID)
+	j.Server.DisconnectFromPeer(peerID, "removed by admin")
+
+	return nil
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.Account, error) {
 	acct, err := getAccountImpl(j.state, root, addr)
 	if err != nil {
@@ -683,33 +778,157 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the merkle proof of the account and its storage slots at the given state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	storageKeys []types.Hash,
+) (*itrie.AccountProof, error) {
+	st, ok := j.state.(*itrie.State)
+	if !ok {
+		return nil, errors.New("state does not support merkle proofs")
+	}
+
+	return st.GetProof(root, addr, storageKeys)
+}
+
+// GetStateAt returns the state and its root after the first txIndex transactions of the block are applied.
+// The transactions are applied on top of the overlay state, so the intermediate state isn't written to the storage
+func (j *jsonRPCHub) GetStateAt(block *types.Block, txIndex int) (*itrie.State, types.Hash, error) {
+	st, ok := j.state.(*itrie.State)
+	if !ok {
+		return nil, types.ZeroHash, errors.New("state does not support iteration")
+	}
+
+	if block.Number() == 0 || txIndex >= len(block.Transactions) {
+		return st, block.Header.StateRoot, nil
+	}
+
+	parentHeader, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, types.ZeroHash, errors.New("parent header not found")
+	}
+
+	if txIndex <= 0 {
+		return st, parentHeader.StateRoot, nil
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	overlay := itrie.NewOverlayState(st)
+
+	transition, err := j.BeginTxnOnState(overlay, parentHeader.StateRoot, block.Header, blockCreator)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	for _, tx := range block.Transactions[:txIndex] {
+		if _, err := transition.Apply(tx); err != nil {
+			return nil, types.ZeroHash, err
+		}
+	}
+
+	_, root, err := transition.Commit()
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	return overlay, root, nil
+}
+
+// SetHead rewinds the chain to the block with the given number, resets the txpool
+// to the state of the new head and rolls the consensus data back to it
+func (j *jsonRPCHub) SetHead(number uint64) error {
+	header, err := j.Blockchain.SetHead(number)
+	if err != nil {
+		return err
+	}
+
+	j.TxPool.Reset()
+
+	if rewinder, ok := j.Consensus.(consensus.Rewinder); ok {
+		if err := rewinder.Rewind(header); err != nil {
+			return fmt.Errorf("failed to rewind the consensus: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
 	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+	nonPayable bool,
+) (*runtime.ExecutionResult, error) {
+	return j.applyTxn(header, txn, override, blockOverride, nil, nonPayable)
+}
+
+// ApplyTxnWithTracer applies a non payable transaction on top of the given block while tracing its execution
+func (j *jsonRPCHub) ApplyTxnWithTracer(
+	header *types.Header,
+	txn *types.Transaction,
+	tracer tracer.Tracer,
+) (*runtime.ExecutionResult, error) {
+	return j.applyTxn(header, txn, nil, nil, tracer, true)
+}
+
+func (j *jsonRPCHub) applyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+	tracer tracer.Tracer,
 	nonPayable bool,
 ) (result *runtime.ExecutionResult, err error) {
+	transition, err := j.beginTxnWithOverrides(header, override, blockOverride)
+	if err != nil {
+		return
+	}
+
+	if tracer != nil {
+		transition.SetTracer(tracer)
+	}
+
+	transition.SetNonPayable(nonPayable)
+
+	result, err = transition.Apply(txn)
+
+	return
+}
+
+// beginTxnWithOverrides begins a transition on top of the state of the given header,
+// with the block context and the state overridden
+func (j *jsonRPCHub) beginTxnWithOverrides(
+	header *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*state.Transition, error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	if blockOverride != nil && blockOverride.Coinbase != nil {
+		blockCreator = *blockOverride.Coinbase
+	}
+
+	transition, err := j.BeginTxn(header.StateRoot, blockOverride.Apply(header), blockCreator)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	if override != nil {
-		if err = transition.WithStateOverride(override); err != nil {
-			return
+		if err := transition.WithStateOverride(override); err != nil {
+			return nil, err
 		}
 	}
 
-	transition.SetNonPayable(nonPayable)
-
-	result, err = transition.Apply(txn)
-
-	return
+	return transition, nil
 }
 
 // TraceBlock traces all transactions in the given block and returns all results
@@ -811,25 +1030,198 @@ func (j *jsonRPCHub) TraceTxn(
 func (j *jsonRPCHub) TraceCall(
 	tx *types.Transaction,
 	parentHeader *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
 	tracer tracer.Tracer,
 ) (interface{}, error) {
-	blockCreator, err := j.GetConsensus().GetBlockCreator(parentHeader)
+	transition, err := j.beginTxnWithOverrides(parentHeader, override, blockOverride)
 	if err != nil {
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
+	transition.SetTracer(tracer)
+
+	if _, err := transition.Apply(tx); err != nil {
+		return nil, err
+	}
+
+	return tracer.GetResult()
+}
+
+// SimulateBlocks applies the calls of the given blocks on top of the state of the parent header.
+// The state is never committed, so the state root of the simulated blocks is left empty
+func (j *jsonRPCHub) SimulateBlocks(
+	parent *types.Header,
+	blocks []*jsonrpc.SimulationBlock,
+	validation bool,
+	traceTransfers bool,
+) ([]*jsonrpc.SimulatedBlock, error) {
+	parentCreator, err := j.GetConsensus().GetBlockCreator(parent)
 	if err != nil {
 		return nil, err
 	}
 
-	transition.SetTracer(tracer)
+	var (
+		transition *state.Transition
+		parentHash = parent.Hash
+		res        = make([]*jsonrpc.SimulatedBlock, len(blocks))
+	)
 
-	if _, err := transition.Apply(tx); err != nil {
+	for idx, block := range blocks {
+		header := block.Header
+		header.ParentHash = parentHash
+
+		// the simulated blocks are created by the creator of the parent block by default
+		coinbase := parentCreator
+		if len(header.Miner) > 0 {
+			coinbase = types.BytesToAddress(header.Miner)
+		} else {
+			header.Miner = coinbase.Bytes()
+		}
+
+		// every block continues on top of the uncommitted state of the previous one
+		if transition == nil {
+			transition, err = j.BeginTxn(parent.StateRoot, header, coinbase)
+		} else {
+			transition, err = j.ContinueTxn(transition, header, coinbase)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := transition.WithStateOverride(block.StateOverride); err != nil {
+			return nil, fmt.Errorf("block %d: %w", idx, err)
+		}
+
+		transition.SetNonPayable(!validation)
+
+		if traceTransfers {
+			transition.SetTracer(transfertracer.NewTransferTracer(transition))
+		}
+
+		simulated, err := simulateBlockCalls(transition, header, block.Calls, validation)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", idx, err)
+		}
+
+		res[idx] = simulated
+		parentHash = header.Hash
+	}
+
+	return res, nil
+}
+
+// simulateBlockCalls applies the calls of the simulated block one by one
+// and completes its header with the results
+func simulateBlockCalls(
+	transition *state.Transition,
+	header *types.Header,
+	calls []*jsonrpc.SimulationCall,
+	validation bool,
+) (*jsonrpc.SimulatedBlock, error) {
+	var (
+		txs      = make([]*types.Transaction, len(calls))
+		receipts = make([]*types.Receipt, len(calls))
+		results  = make([]*jsonrpc.SimulatedCallResult, len(calls))
+		gasUsed  = uint64(0)
+	)
+
+	for idx, call := range calls {
+		tx := call.Tx
+
+		if !validation || !call.NonceSet {
+			tx.Nonce = transition.GetNonce(tx.From)
+		}
+
+		// If the caller didn't supply the gas limit, then the call can use all the gas left in the block
+		if tx.Gas == 0 {
+			tx.Gas = header.GasLimit - gasUsed
+		}
+
+		tx.ComputeHash(header.Number)
+
+		result, err := transition.Apply(tx)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", idx, err)
+		}
+
+		logs := transition.Txn().Logs()
+
+		// The suicided accounts are set as deleted for the next call
+		if err := transition.Txn().CleanDeleteObjects(true); err != nil {
+			return nil, fmt.Errorf("failed to clean deleted objects: %w", err)
+		}
+
+		gasUsed += result.GasUsed
+
+		receipt := &types.Receipt{
+			CumulativeGasUsed: gasUsed,
+			TransactionType:   tx.Type,
+			TxHash:            tx.Hash,
+			GasUsed:           result.GasUsed,
+			Logs:              logs,
+		}
+
+		if result.Failed() {
+			receipt.SetStatus(types.ReceiptFailed)
+		} else {
+			receipt.SetStatus(types.ReceiptSuccess)
+		}
+
+		if tx.IsContractCreation() {
+			receipt.ContractAddress = crypto.CreateAddress(tx.From, tx.Nonce).Ptr()
+		}
+
+		receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
+
+		txs[idx] = tx
+		receipts[idx] = receipt
+		results[idx] = &jsonrpc.SimulatedCallResult{
+			Result: result,
+			Logs:   logs,
+		}
+	}
+
+	header.GasUsed = gasUsed
+	header.LogsBloom = types.CreateBloom(receipts)
+	header.TxRoot = buildroot.CalculateTransactionsRoot(txs, header.Number)
+	header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+	header.Sha3Uncles = types.EmptyUncleHash
+	header.ComputeHash()
+
+	return &jsonrpc.SimulatedBlock{
+		Block: &types.Block{
+			Header:       header,
+			Transactions: txs,
+		},
+		Results: results,
+	}, nil
+}
+
+// FinalizedHeader returns the header of the last finalized block.
+// Blocks are final as soon as they are written to the chain,
+// unless the finality is anchored to the rootchain checkpoints
+func (j *jsonRPCHub) FinalizedHeader() (*types.Header, error) {
+	if !j.checkpointFinality {
+		return j.Header(), nil
+	}
+
+	if j.checkpoints == nil {
+		return nil, errCheckpointFinalityNotSupported
+	}
+
+	number, err := j.checkpoints.CheckpointBlock()
+	if err != nil {
 		return nil, err
 	}
 
-	return tracer.GetResult()
+	header, ok := j.GetHeaderByNumber(number)
+	if !ok {
+		return nil, jsonrpc.ErrFinalizedNotFound
+	}
+
+	return header, nil
 }
 
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
@@ -860,11 +1252,19 @@ func (s *Server) setupJSONRPC() error {
 		Server:             s.network,
 		BridgeDataProvider: s.consensus.GetBridgeProvider(),
 		GasStore:           s.gasHelper,
+		checkpointFinality: s.config.JSONRPC.CheckpointFinality,
+		bloomBits:          s.bloomBits,
+	}
+
+	if hub.checkpointFinality && hub.BridgeDataProvider != nil {
+		s.checkpoints = newCheckpointTracker(s.logger, hub.BridgeDataProvider)
+		hub.checkpoints = s.checkpoints
 	}
 
 	conf := &jsonrpc.Config{
 		Store:                    hub,
 		Addr:                     s.config.JSONRPC.JSONRPCAddr,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
 		ChainID:                  uint64(s.config.Chain.Params.ChainID),
 		ChainName:                s.chain.Name,
 		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
@@ -873,6 +1273,30 @@ func (s *Server) setupJSONRPC() error {
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
 		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
 		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		HTTPPolicy:               s.config.JSONRPC.HTTPPolicy,
+		WSPolicy:                 s.config.JSONRPC.WSPolicy,
+		IPRateLimit:              s.config.JSONRPC.IPRateLimit,
+		SlowRequestThreshold:     s.config.JSONRPC.SlowRequestThreshold,
+		ResponseCacheSize:        s.config.JSONRPC.ResponseCacheSize,
+		EnableAdmin:              s.config.JSONRPC.EnableAdmin,
+	}
+
+	if s.config.JSONRPC.KeystoreDir != "" {
+		signer, err := jsonrpc.NewLocalSigner(s.config.JSONRPC.KeystoreDir)
+		if err != nil {
+			return fmt.Errorf("failed to load the json-rpc keystore: %w", err)
+		}
+
+		conf.LocalSigner = signer
+	}
+
+	if s.config.JSONRPC.AuthKeysFile != "" {
+		keyStore, err := jsonrpc.LoadKeyStore(s.config.JSONRPC.AuthKeysFile)
+		if err != nil {
+			return fmt.Errorf("failed to load the json-rpc auth keys: %w", err)
+		}
+
+		conf.KeyStore = keyStore
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -918,6 +1342,18 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop indexing before the blockchain storage is closed
+	s.bloomBits.Close()
+
+	// Close the JSON-RPC IPC server
+	if err := s.jsonrpcServer.Close(); err != nil {
+		s.logger.Error("failed to close JSON-RPC IPC server", "err", err.Error())
+	}
+
+	if s.checkpoints != nil {
+		s.checkpoints.Close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
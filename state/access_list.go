@@ -0,0 +1,121 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AccessList is the set of addresses and storage slots accessed
+// during the execution of a single transaction (EIP-2929)
+type AccessList struct {
+	slots map[types.Address]map[types.Hash]struct{}
+
+	// journal keeps track of the additions, so they can be undone on revert
+	journal []accessListChange
+}
+
+type accessListChange struct {
+	address types.Address
+	slot    *types.Hash
+}
+
+// NewAccessList creates an empty access list
+func NewAccessList() *AccessList {
+	return &AccessList{
+		slots: map[types.Address]map[types.Hash]struct{}{},
+	}
+}
+
+// PrepareAccessList adds to the access list the addresses and slots warmed up
+// at the beginning of the transaction: sender, recipient, precompiles and the tx access list
+func (al *AccessList) PrepareAccessList(
+	from types.Address,
+	to *types.Address,
+	precompiles []types.Address,
+	txAccessList types.TxAccessList,
+) {
+	al.AddAddress(from)
+
+	if to != nil {
+		al.AddAddress(*to)
+	}
+
+	for _, addr := range precompiles {
+		al.AddAddress(addr)
+	}
+
+	for _, tuple := range txAccessList {
+		al.AddAddress(tuple.Address)
+
+		for _, slot := range tuple.StorageKeys {
+			al.AddSlot(tuple.Address, slot)
+		}
+	}
+}
+
+// ContainsAddress returns true if the address is in the access list
+func (al *AccessList) ContainsAddress(address types.Address) bool {
+	_, ok := al.slots[address]
+
+	return ok
+}
+
+// Contains checks if the address and the slot are in the access list
+func (al *AccessList) Contains(address types.Address, slot types.Hash) (bool, bool) {
+	slots, addressOk := al.slots[address]
+	if !addressOk {
+		return false, false
+	}
+
+	_, slotOk := slots[slot]
+
+	return true, slotOk
+}
+
+// AddAddress adds an address to the access list.
+// It returns true if the address was not present before
+func (al *AccessList) AddAddress(address types.Address) bool {
+	if _, ok := al.slots[address]; ok {
+		return false
+	}
+
+	al.slots[address] = map[types.Hash]struct{}{}
+	al.journal = append(al.journal, accessListChange{address: address})
+
+	return true
+}
+
+// AddSlot adds the address and the slot to the access list.
+// It returns whether the address and the slot were added
+func (al *AccessList) AddSlot(address types.Address, slot types.Hash) (bool, bool) {
+	addrAdded := al.AddAddress(address)
+
+	slots := al.slots[address]
+	if _, ok := slots[slot]; ok {
+		return addrAdded, false
+	}
+
+	slots[slot] = struct{}{}
+	al.journal = append(al.journal, accessListChange{address: address, slot: &slot})
+
+	return addrAdded, true
+}
+
+// Snapshot returns the identifier of the current access list revision
+func (al *AccessList) Snapshot() int {
+	return len(al.journal)
+}
+
+// RevertToSnapshot removes all the entries added after the given snapshot
+func (al *AccessList) RevertToSnapshot(id int) {
+	for i := len(al.journal) - 1; i >= id; i-- {
+		change := al.journal[i]
+
+		if change.slot != nil {
+			delete(al.slots[change.address], *change.slot)
+		} else {
+			delete(al.slots, change.address)
+		}
+	}
+
+	al.journal = al.journal[:id]
+}
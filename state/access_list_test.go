@@ -0,0 +1,76 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestAccessList_PrepareAccessList(t *testing.T) {
+	t.Parallel()
+
+	from := types.StringToAddress("1")
+	to := types.StringToAddress("2")
+	precompile := types.StringToAddress("3")
+	listed := types.StringToAddress("4")
+	slot := types.StringToHash("5")
+
+	al := NewAccessList()
+	al.PrepareAccessList(from, &to, []types.Address{precompile}, types.TxAccessList{
+		{Address: listed, StorageKeys: []types.Hash{slot}},
+	})
+
+	for _, addr := range []types.Address{from, to, precompile, listed} {
+		require.True(t, al.ContainsAddress(addr))
+	}
+
+	addrOk, slotOk := al.Contains(listed, slot)
+	require.True(t, addrOk)
+	require.True(t, slotOk)
+
+	addrOk, slotOk = al.Contains(to, slot)
+	require.True(t, addrOk)
+	require.False(t, slotOk)
+
+	require.False(t, al.ContainsAddress(types.StringToAddress("6")))
+}
+
+func TestAccessList_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	addr1 := types.StringToAddress("1")
+	addr2 := types.StringToAddress("2")
+	slot1 := types.StringToHash("1")
+	slot2 := types.StringToHash("2")
+
+	al := NewAccessList()
+	require.True(t, al.AddAddress(addr1))
+	require.False(t, al.AddAddress(addr1))
+
+	snapshot := al.Snapshot()
+
+	addrAdded, slotAdded := al.AddSlot(addr1, slot1)
+	require.False(t, addrAdded)
+	require.True(t, slotAdded)
+
+	addrAdded, slotAdded = al.AddSlot(addr2, slot2)
+	require.True(t, addrAdded)
+	require.True(t, slotAdded)
+
+	al.RevertToSnapshot(snapshot)
+
+	// entries added before the snapshot are kept
+	require.True(t, al.ContainsAddress(addr1))
+
+	_, slotOk := al.Contains(addr1, slot1)
+	require.False(t, slotOk)
+
+	require.False(t, al.ContainsAddress(addr2))
+
+	// the journal is consistent after the revert
+	addrAdded, slotAdded = al.AddSlot(addr2, slot2)
+	require.True(t, addrAdded)
+	require.True(t, slotAdded)
+}
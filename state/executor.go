@@ -25,6 +25,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP 2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -85,6 +88,10 @@ func (e *Executor) WriteGenesis(
 		gasPool:     uint64(env.GasLimit),
 		config:      config,
 		precompiles: precompiled.NewPrecompiled(),
+		accessList:  NewAccessList(),
+
+		transientStorage: NewTransientStorage(),
+		createdContracts: map[types.Address]struct{}{},
 	}
 
 	for addr, account := range alloc {
@@ -174,13 +181,51 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	forkConfig := e.config.Forks.At(header.Number)
-
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
 		return nil, err
 	}
 
+	return e.newTransition(auxSnap2, NewTxn(auxSnap2), header, coinbaseReceiver)
+}
+
+// BeginTxnOnState begins a transition on top of the root of the given state instead of the state of the executor,
+// e.g. the overlay state, so the transition can be committed without writing to the storage of the chain
+func (e *Executor) BeginTxnOnState(
+	st State,
+	parentRoot types.Hash,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	snap, err := st.NewSnapshotAt(parentRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.newTransition(snap, NewTxn(snap), header, coinbaseReceiver)
+}
+
+// ContinueTxn begins a transition of the given block on top of the uncommitted state
+// of the previous transition, so that the consecutive blocks can be simulated
+// without committing their state
+func (e *Executor) ContinueTxn(
+	prev *Transition,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	return e.newTransition(prev.snap, prev.state, header, coinbaseReceiver)
+}
+
+func (e *Executor) newTransition(
+	auxSnap2 Snapshot,
+	newTxn *Txn,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	var err error
+
+	forkConfig := e.config.Forks.At(header.Number)
+
 	burnContract := types.ZeroAddress
 	if forkConfig.London {
 		burnContract, err = e.config.CalculateBurnContract(header.Number)
@@ -189,8 +234,6 @@ func (e *Executor) BeginTxn(
 		}
 	}
 
-	newTxn := NewTxn(auxSnap2)
-
 	txCtx := runtime.TxContext{
 		Coinbase:     coinbaseReceiver,
 		Timestamp:    int64(header.Timestamp),
@@ -217,7 +260,11 @@ func (e *Executor) BeginTxn(
 
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+		accessList:  NewAccessList(),
 		PostHook:    e.PostHook,
+
+		transientStorage: NewTransientStorage(),
+		createdContracts: map[types.Address]struct{}{},
 	}
 
 	// enable contract deployment allow list (if any)
@@ -273,6 +320,15 @@ type Transition struct {
 	evm         *evm.EVM
 	precompiles *precompiled.Precompiled
 
+	// accessList is the set of addresses and slots accessed by the current transaction (EIP-2929)
+	accessList *AccessList
+
+	// transientStorage is the storage discarded at the end of the current transaction (EIP-1153)
+	transientStorage *TransientStorage
+
+	// createdContracts is the set of contracts created by the current transaction (EIP-6780)
+	createdContracts map[types.Address]struct{}
+
 	// allow list runtimes
 	deploymentAllowList *addresslist.AddressList
 	deploymentBlockList *addresslist.AddressList
@@ -289,6 +345,10 @@ func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transit
 		snap:        snap,
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+		accessList:  NewAccessList(),
+
+		transientStorage: NewTransientStorage(),
+		createdContracts: map[types.Address]struct{}{},
 	}
 }
 
@@ -337,7 +397,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type == types.AccessListTx || txn.Type == types.DynamicFeeTx) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -538,6 +598,8 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	t.captureTxStateStart(msg)
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -558,11 +620,16 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	}
 
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Berlin, t.config.Shanghai)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
 
+	// the initcode of the contract creation does not exceed the limit (EIP-3860)
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > evm.MaxInitCodeSize {
+		return nil, NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
 	// the purchased gas is enough to cover intrinsic usage
 	gasLeft := msg.Gas - intrinsicGasCost
 	// because we are working with unsigned integers for gas, the `>` operator is used instead of the more intuitive `<`
@@ -577,6 +644,20 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	// every transaction starts with a fresh access list and transient storage
+	t.accessList = NewAccessList()
+	if t.config.Berlin {
+		t.accessList.PrepareAccessList(msg.From, msg.To, t.precompiles.Addresses(&t.config), msg.AccessList)
+	}
+
+	// the coinbase is warm at the beginning of the transaction (EIP-3651)
+	if t.config.Shanghai {
+		t.accessList.AddAddress(t.ctx.Coinbase)
+	}
+
+	t.transientStorage = NewTransientStorage()
+	t.createdContracts = map[types.Address]struct{}{}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -620,6 +701,8 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	t.captureTxStateEnd()
+
 	return result, nil
 }
 
@@ -732,6 +815,9 @@ func (t *Transition) applyCall(
 	}
 
 	snapshot := t.state.Snapshot()
+	accessListSnapshot := t.accessList.Snapshot()
+	transientStorageSnapshot := t.transientStorage.Snapshot()
+
 	t.state.TouchAccount(c.Address)
 
 	if callType == runtime.Call {
@@ -756,6 +842,9 @@ func (t *Transition) applyCall(
 				Err:     err,
 			}
 		}
+
+		t.accessList.RevertToSnapshot(accessListSnapshot)
+		t.transientStorage.RevertToSnapshot(transientStorageSnapshot)
 	}
 
 	t.captureCallEnd(c, result)
@@ -788,6 +877,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		return &runtime.ExecutionResult{Err: err}
 	}
 
+	// The contract address is added to the access list even if the creation fails (EIP-2929)
+	if t.config.Berlin {
+		t.accessList.AddAddress(c.Address)
+	}
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -798,6 +892,10 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 
 	// Take snapshot of the current state
 	snapshot := t.state.Snapshot()
+	accessListSnapshot := t.accessList.Snapshot()
+	transientStorageSnapshot := t.transientStorage.Snapshot()
+
+	t.createdContracts[c.Address] = struct{}{}
 
 	if t.config.EIP158 {
 		// Force the creation of the account
@@ -866,6 +964,9 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 			}
 		}
 
+		t.accessList.RevertToSnapshot(accessListSnapshot)
+		t.transientStorage.RevertToSnapshot(transientStorageSnapshot)
+
 		return result
 	}
 
@@ -877,6 +978,9 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 			}
 		}
 
+		t.accessList.RevertToSnapshot(accessListSnapshot)
+		t.transientStorage.RevertToSnapshot(transientStorageSnapshot)
+
 		return &runtime.ExecutionResult{
 			GasLeft: 0,
 			Err:     runtime.ErrMaxCodeSizeExceeded,
@@ -897,6 +1001,9 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 				}
 			}
 
+			t.accessList.RevertToSnapshot(accessListSnapshot)
+			t.transientStorage.RevertToSnapshot(transientStorageSnapshot)
+
 			result.GasLeft = 0
 		}
 
@@ -1003,6 +1110,16 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
+	// only the contracts created in the same transaction are removed,
+	// the others just send their balance to the beneficiary (EIP-6780)
+	if _, created := t.createdContracts[addr]; t.config.Cancun && !created {
+		balance := t.state.GetBalance(addr)
+		t.state.SetBalance(addr, big.NewInt(0))
+		t.state.AddBalance(beneficiary, balance)
+
+		return
+	}
+
 	if !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
@@ -1011,6 +1128,36 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 	t.state.Suicide(addr)
 }
 
+// AddressInAccessList returns true if the address is in the transaction access list
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList checks if the address and the slot are in the transaction access list
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.accessList.Contains(addr, slot)
+}
+
+// AddAddressToAccessList adds the address to the transaction access list
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.accessList.AddAddress(addr)
+}
+
+// AddSlotToAccessList adds the address and the slot to the transaction access list
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.accessList.AddSlot(addr, slot)
+}
+
+// GetTransientState returns the value from the transient storage of the given account
+func (t *Transition) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return t.transientStorage.Get(addr, key)
+}
+
+// SetTransientState sets the value in the transient storage of the given account
+func (t *Transition) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	t.transientStorage.Set(addr, key, value)
+}
+
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
 	if c.Type == runtime.Create {
 		return t.applyCreate(c, h)
@@ -1069,7 +1216,7 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isBerlin, isShanghai bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -1107,6 +1254,33 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		cost += zeros * 4
+
+		// Initcode of the contract creation is metered by words (EIP-3860)
+		if msg.IsContractCreation() && isShanghai {
+			words := (uint64(len(payload)) + 31) / 32
+			if (math.MaxUint64-cost)/evm.InitCodeWordGas < words {
+				return 0, ErrIntrinsicGasOverflow
+			}
+
+			cost += words * evm.InitCodeWordGas
+		}
+	}
+
+	// the access list is charged only since the berlin fork (EIP-2930)
+	if isBerlin && len(msg.AccessList) > 0 {
+		addresses := uint64(len(msg.AccessList))
+		if (math.MaxUint64-cost)/TxAccessListAddressGas < addresses {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += addresses * TxAccessListAddressGas
+
+		storageKeys := uint64(msg.AccessList.StorageKeys())
+		if (math.MaxUint64-cost)/TxAccessListStorageKeyGas < storageKeys {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += storageKeys * TxAccessListStorageKeyGas
 	}
 
 	return cost, nil
@@ -1171,12 +1345,49 @@ func checkAndProcessStateTx(msg *types.Transaction) error {
 	return nil
 }
 
-// captureCallStart calls CallStart in Tracer if context has the tracer
+// captureTxStateStart calls TxStateStart with the accounts touched by the transaction
+// if context has the tracer which needs to access the state
+func (t *Transition) captureTxStateStart(msg *types.Transaction) {
+	stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer)
+	if !ok {
+		return
+	}
+
+	addresses := []types.Address{msg.From, t.ctx.Coinbase}
+
+	if msg.IsContractCreation() {
+		addresses = append(addresses, crypto.CreateAddress(msg.From, t.state.GetNonce(msg.From)))
+	} else {
+		addresses = append(addresses, *msg.To)
+	}
+
+	if t.config.London && msg.Type != types.StateTx {
+		addresses = append(addresses, t.ctx.BurnContract)
+	}
+
+	stateTracer.TxStateStart(t, addresses...)
+}
+
+// captureTxStateEnd calls TxStateEnd if context has the tracer which needs to access the state
+func (t *Transition) captureTxStateEnd() {
+	if stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer); ok {
+		stateTracer.TxStateEnd(t)
+	}
+}
+
+// captureCallStart calls CallStart in Tracer if context has the tracer,
+// or CallFrameStart if the tracer needs the call frames as they are executed
 func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.CallType) {
 	if t.ctx.Tracer == nil {
 		return
 	}
 
+	if frameTracer, ok := t.ctx.Tracer.(tracer.CallFrameTracer); ok {
+		t.captureCallFrameStart(frameTracer, c, callType)
+
+		return
+	}
+
 	t.ctx.Tracer.CallStart(
 		c.Depth,
 		c.Caller,
@@ -1188,6 +1399,35 @@ func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.Call
 	)
 }
 
+// captureCallFrameStart calls CallFrameStart with the frame of the contract being executed
+func (t *Transition) captureCallFrameStart(
+	frameTracer tracer.CallFrameTracer,
+	c *runtime.Contract,
+	callType runtime.CallType,
+) {
+	from, input := c.Caller, c.Input
+
+	switch callType {
+	case runtime.DelegateCall:
+		// the caller of a delegate call is the contract which executes it
+		from = c.Address
+	case evm.CREATE:
+		// the creations are captured with the CREATE opcode
+		callType = runtime.Create
+		input = c.Code
+	}
+
+	frameTracer.CallFrameStart(
+		c.Depth,
+		from,
+		c.CodeAddress,
+		int(callType),
+		c.Gas,
+		c.Value,
+		input,
+	)
+}
+
 // captureCallEnd calls CallEnd in Tracer if context has the tracer
 func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.ExecutionResult) {
 	if t.ctx.Tracer == nil {
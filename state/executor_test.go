@@ -5,11 +5,18 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -157,3 +164,439 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func Test_Transition_AccessList(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0x1000")
+	contract := types.StringToAddress("0x2000")
+
+	// PUSH1 0x00 SLOAD PUSH1 0x00 SLOAD STOP
+	code := []byte{0x60, 0x00, 0x54, 0x60, 0x00, 0x54, 0x00}
+
+	tests := []struct {
+		name       string
+		config     chain.ForksInTime
+		accessList types.TxAccessList
+		gasUsed    uint64
+	}{
+		{
+			name:    "cold slot is charged on first access",
+			config:  chain.AllForksEnabled.At(0),
+			gasUsed: TxGas + 3 + evm.ColdSloadCostEIP2929 + 3 + evm.WarmStorageReadCostEIP2929,
+		},
+		{
+			name:   "slots in the tx access list are warm",
+			config: chain.AllForksEnabled.At(0),
+			accessList: types.TxAccessList{
+				{Address: contract, StorageKeys: []types.Hash{types.ZeroHash}},
+			},
+			gasUsed: TxGas + TxAccessListAddressGas + TxAccessListStorageKeyGas +
+				3 + evm.WarmStorageReadCostEIP2929 + 3 + evm.WarmStorageReadCostEIP2929,
+		},
+		{
+			name: "berlin fork disabled",
+			config: chain.ForksInTime{
+				Homestead: true,
+				Byzantium: true,
+				EIP150:    true,
+				EIP158:    true,
+				Istanbul:  true,
+			},
+			gasUsed: TxGas + 3 + 800 + 3 + 800,
+		},
+		{
+			name: "access list is ignored before berlin fork",
+			config: chain.ForksInTime{
+				Homestead: true,
+				Byzantium: true,
+				EIP150:    true,
+				EIP158:    true,
+				Istanbul:  true,
+				London:    true,
+			},
+			accessList: types.TxAccessList{
+				{Address: contract, StorageKeys: []types.Hash{types.ZeroHash}},
+			},
+			gasUsed: TxGas + 3 + 800 + 3 + 800,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			snap := newStateWithPreState(map[types.Address]*PreState{
+				sender: {Balance: 1000000000},
+			})
+
+			transition := NewTransition(tt.config, snap, newTxn(snap))
+			transition.ctx.BaseFee = big.NewInt(0)
+			transition.gasPool = 1000000
+			transition.state.SetCode(contract, code)
+
+			result, err := transition.Apply(&types.Transaction{
+				Type:       types.AccessListTx,
+				From:       sender,
+				To:         &contract,
+				Value:      big.NewInt(0),
+				Gas:        100000,
+				GasPrice:   big.NewInt(1),
+				AccessList: tt.accessList,
+			})
+			require.NoError(t, err)
+			require.NoError(t, result.Err)
+			require.Equal(t, tt.gasUsed, result.GasUsed)
+		})
+	}
+}
+
+func Test_Transition_SelfdestructEIP6780(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0x1000")
+	contract := types.StringToAddress("0x2000")
+
+	// CALLER SELFDESTRUCT
+	code := []byte{0x33, 0xff}
+
+	cancunDisabled := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+	tests := []struct {
+		name      string
+		config    chain.ForksInTime
+		create    bool
+		destroyed bool
+	}{
+		{
+			name:      "existing contract is not removed",
+			config:    chain.AllForksEnabled.At(0),
+			destroyed: false,
+		},
+		{
+			name:      "contract created in the same transaction is removed",
+			config:    chain.AllForksEnabled.At(0),
+			create:    true,
+			destroyed: true,
+		},
+		{
+			name:      "existing contract is removed when cancun fork is disabled",
+			config:    cancunDisabled,
+			destroyed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			snap := newStateWithPreState(map[types.Address]*PreState{
+				sender:   {Balance: 1000000000},
+				contract: {Nonce: 1, Balance: 100},
+			})
+
+			transition := NewTransition(tt.config, snap, newTxn(snap))
+			transition.ctx.BaseFee = big.NewInt(0)
+			transition.gasPool = 1000000
+			transition.state.SetCode(contract, code)
+
+			msg := &types.Transaction{
+				From:     sender,
+				To:       &contract,
+				Value:    big.NewInt(0),
+				Gas:      100000,
+				GasPrice: big.NewInt(0),
+			}
+
+			target := contract
+			if tt.create {
+				msg.To = nil
+				msg.Input = code
+				target = crypto.CreateAddress(sender, 0)
+			}
+
+			result, err := transition.Apply(msg)
+			require.NoError(t, err)
+			require.NoError(t, result.Err)
+
+			require.Equal(t, tt.destroyed, transition.state.HasSuicided(target))
+			require.Zero(t, transition.state.GetBalance(target).Sign())
+
+			if !tt.create {
+				require.Equal(t, uint64(1000000100), transition.state.GetBalance(sender).Uint64())
+			}
+		})
+	}
+}
+
+func Test_Transition_InitCodeLimit(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0x1000")
+	input := make([]byte, evm.MaxInitCodeSize+1)
+
+	shanghaiDisabled := chain.AllForksEnabled.Copy().RemoveFork(chain.Shanghai).At(0)
+
+	for _, config := range []chain.ForksInTime{chain.AllForksEnabled.At(0), shanghaiDisabled} {
+		snap := newStateWithPreState(map[types.Address]*PreState{
+			sender: {Balance: 1000000000},
+		})
+
+		transition := NewTransition(config, snap, newTxn(snap))
+		transition.ctx.BaseFee = big.NewInt(0)
+		transition.gasPool = 10000000
+
+		_, err := transition.Apply(&types.Transaction{
+			From:     sender,
+			Value:    big.NewInt(0),
+			Gas:      10000000,
+			GasPrice: big.NewInt(0),
+			Input:    input,
+		})
+
+		if config.Shanghai {
+			require.ErrorContains(t, err, runtime.ErrMaxInitCodeSizeExceeded.Error())
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	// 33 zero bytes are metered as two words
+	msg := &types.Transaction{Input: make([]byte, 33)}
+
+	cost, err := TransactionGasCost(msg, true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*4, cost)
+
+	cost, err = TransactionGasCost(msg, true, true, true, true)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*4+2*evm.InitCodeWordGas, cost)
+}
+
+func TestTransactionGasCost_AccessList(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("0x2000")
+	msg := &types.Transaction{
+		To: &to,
+		AccessList: types.TxAccessList{
+			{Address: to, StorageKeys: []types.Hash{types.ZeroHash, types.StringToHash("0x1")}},
+		},
+	}
+
+	cost, err := TransactionGasCost(msg, true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGas+TxAccessListAddressGas+2*TxAccessListStorageKeyGas, cost)
+
+	// the access list isn't charged before the berlin fork
+	cost, err = TransactionGasCost(msg, true, true, false, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGas, cost)
+}
+
+func Test_Transition_PrestateTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x1000")
+		receiver = types.StringToAddress("0x2000")
+		slot     = types.ZeroHash
+		// PUSH1 0x01 PUSH1 0x00 SSTORE STOP
+		code = []byte{byte(evm.PUSH1), 0x01, byte(evm.PUSH1), 0x00, byte(evm.SSTORE), byte(evm.STOP)}
+	)
+
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		sender: {Balance: 1000000000},
+		receiver: {
+			Nonce: 1,
+			State: map[types.Hash]types.Hash{slot: types.StringToHash("0x5")},
+		},
+	})
+
+	txn := newTxn(snap)
+	txn.SetCode(receiver, code)
+
+	transition := NewTransition(chain.AllForksEnabled.At(0), snap, txn)
+	transition.ctx.BaseFee = big.NewInt(0)
+	transition.gasPool = 10000000
+
+	tracer := prestatetracer.NewPrestateTracer(prestatetracer.Config{DiffMode: true})
+	transition.SetTracer(tracer)
+
+	_, err := transition.Apply(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Value:    big.NewInt(10),
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+	})
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	require.Equal(t, &prestatetracer.DiffResult{
+		Pre: prestatetracer.State{
+			sender: {Balance: "0x3b9aca00"},
+			receiver: {
+				Balance: "0x0",
+				Nonce:   1,
+				Code:    "0x600160005500",
+				Storage: map[types.Hash]types.Hash{slot: types.StringToHash("0x5")},
+			},
+		},
+		Post: prestatetracer.State{
+			sender: {Balance: "0x3b9ac9f6", Nonce: 1},
+			receiver: {
+				Balance: "0xa",
+				Storage: map[types.Hash]types.Hash{slot: types.StringToHash("0x1")},
+			},
+		},
+	}, res)
+}
+
+func Test_Transition_CallFrameTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x1000")
+		receiver = types.StringToAddress("0x2000")
+		library  = types.StringToAddress("0x3000")
+	)
+
+	// DELEGATECALL(gas, library, 0, 0, 0, 0) STOP
+	code := []byte{
+		byte(evm.PUSH1), 0x0, byte(evm.PUSH1), 0x0, byte(evm.PUSH1), 0x0, byte(evm.PUSH1), 0x0,
+		byte(evm.PUSH1) + 19, // PUSH20
+	}
+	code = append(code, library.Bytes()...)
+	code = append(code, byte(evm.GAS), byte(evm.DELEGATECALL), byte(evm.STOP))
+
+	apply := func(t *testing.T, tracer tracer.Tracer) {
+		t.Helper()
+
+		snap := newStateWithPreState(map[types.Address]*PreState{
+			sender: {Balance: 1000000000},
+		})
+
+		txn := newTxn(snap)
+		txn.SetCode(receiver, code)
+		txn.SetCode(library, []byte{byte(evm.STOP)})
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), snap, txn)
+		transition.ctx.BaseFee = big.NewInt(0)
+		transition.gasPool = 10000000
+		transition.SetTracer(tracer)
+
+		_, err := transition.Apply(&types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Value:    big.NewInt(0),
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("call tracer gets the caller and the address of the contract", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := &calltracer.CallTracer{}
+		apply(t, tracer)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+
+		call, ok := res.(*calltracer.Call)
+		require.True(t, ok)
+		require.Len(t, call.Calls, 1)
+
+		assert.Equal(t, "DELEGATECALL", call.Calls[0].Type)
+		assert.Equal(t, sender.String(), call.Calls[0].From)
+		assert.Equal(t, receiver.String(), call.Calls[0].To)
+	})
+
+	t.Run("call frame tracer gets the executing contract and the code address", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := &flattracer.FlatTracer{}
+		apply(t, tracer)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+
+		traces, ok := res.([]*flattracer.Trace)
+		require.True(t, ok)
+		require.Len(t, traces, 2)
+
+		action, ok := traces[1].Action.(*flattracer.CallAction)
+		require.True(t, ok)
+
+		assert.Equal(t, "delegatecall", action.CallType)
+		assert.Equal(t, receiver, action.From)
+		assert.Equal(t, library, action.To)
+	})
+}
+
+func TestExecutor_ContinueTxn(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x1000")
+		receiver = types.StringToAddress("0x2000")
+		coinbase = types.StringToAddress("0x3000")
+		burn     = types.StringToAddress("0x4000")
+	)
+
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		sender: {Balance: 100},
+	})
+
+	executor := NewExecutor(&chain.Params{
+		Forks:        chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{0: burn},
+	}, nil, hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	prev := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	prev.ctx.BaseFee = big.NewInt(0)
+	prev.gasPool = 100000
+
+	_, err := prev.Apply(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Value:    big.NewInt(10),
+		Gas:      21000,
+		GasPrice: big.NewInt(0),
+	})
+	require.NoError(t, err)
+
+	next, err := executor.ContinueTxn(prev, &types.Header{
+		Number:    5,
+		Timestamp: 10,
+		GasLimit:  50000,
+	}, coinbase)
+	require.NoError(t, err)
+
+	// the next transition sees the uncommitted state of the previous one
+	require.Equal(t, big.NewInt(90), next.GetBalance(sender))
+	require.Equal(t, big.NewInt(10), next.GetBalance(receiver))
+	require.Equal(t, uint64(1), next.GetNonce(sender))
+
+	ctx := next.GetTxContext()
+	require.Equal(t, int64(5), ctx.Number)
+	require.Equal(t, int64(10), ctx.Timestamp)
+	require.Equal(t, coinbase, ctx.Coinbase)
+	require.Equal(t, burn, ctx.BurnContract)
+	require.Equal(t, uint64(50000), next.gasPool)
+}
@@ -0,0 +1,184 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// IterateFn is called with the key and the value of every leaf visited by the iteration,
+// the iteration stops once it returns false
+type IterateFn func(key, value []byte) bool
+
+// Iterate visits the leaves of the trie in the order of their keys, starting
+// from the first key which is not less than start
+func (t *Trie) Iterate(storage Storage, start []byte, fn IterateFn) error {
+	it := &trieIterator{
+		storage: storage,
+		fn:      fn,
+	}
+
+	// the start is compared as nibbles, without the terminator
+	if len(start) > 0 {
+		it.start = bytesToHexNibbles(start)
+		it.start = it.start[:len(it.start)-1]
+	}
+
+	_, err := it.walk(t.root, nil)
+
+	return err
+}
+
+// Iterate visits the leaves of the trie at the root, which is either
+// the state root or the storage root of an account
+func (s *State) Iterate(root types.Hash, start []byte, fn IterateFn) error {
+	trie, err := s.newTrieAt(root)
+	if err != nil {
+		return err
+	}
+
+	return trie.Iterate(s.storage, start, fn)
+}
+
+// IterateAccounts visits the accounts of the state at the root in the order of their keys, which are
+// the hashes of their addresses, starting from the given key, until fn returns false
+func (s *State) IterateAccounts(
+	root types.Hash,
+	start types.Hash,
+	fn func(key types.Hash, account *state.Account) bool,
+) error {
+	var decodeErr error
+
+	err := s.Iterate(root, start.Bytes(), func(key, value []byte) bool {
+		var account state.Account
+		if decodeErr = account.UnmarshalRlp(value); decodeErr != nil {
+			return false
+		}
+
+		return fn(types.BytesToHash(key), &account)
+	})
+	if err != nil {
+		return err
+	}
+
+	return decodeErr
+}
+
+// IterateStorage visits the storage slots of the account with the storage root in the order of their keys,
+// which are the hashes of the slots, starting from the given key, until fn returns false
+func (s *State) IterateStorage(
+	storageRoot types.Hash,
+	start types.Hash,
+	fn func(key types.Hash, value types.Hash) bool,
+) error {
+	var decodeErr error
+
+	err := s.Iterate(storageRoot, start.Bytes(), func(key, data []byte) bool {
+		var value types.Hash
+		if value, decodeErr = decodeStorageValue(data); decodeErr != nil {
+			return false
+		}
+
+		return fn(types.BytesToHash(key), value)
+	})
+	if err != nil {
+		return err
+	}
+
+	return decodeErr
+}
+
+type trieIterator struct {
+	storage Storage
+	start   []byte
+	fn      IterateFn
+}
+
+// walk visits the leaves under the node whose path is given in nibbles,
+// it returns false once the iteration is stopped
+func (it *trieIterator) walk(node Node, path []byte) (bool, error) {
+	if it.before(path) {
+		return true, nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return true, nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, it.storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("trie node %x not found", n.buf)
+			}
+
+			return it.walk(nc, path)
+		}
+
+		// the leaf keys are full bytes, so their paths have an even number of nibbles
+		if len(path)%2 != 0 || (len(it.start) > 0 && bytes.Compare(path, it.start) < 0) {
+			return true, nil
+		}
+
+		return it.fn(nibblesToBytes(path), n.buf), nil
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		return it.walk(n.child, concat(path, key))
+
+	case *FullNode:
+		if n.value != nil {
+			if cont, err := it.walk(n.value, path); !cont || err != nil {
+				return cont, err
+			}
+		}
+
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if cont, err := it.walk(child, concat(path, []byte{byte(i)})); !cont || err != nil {
+				return cont, err
+			}
+		}
+
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// before returns true if all the keys under the path are less than the start
+func (it *trieIterator) before(path []byte) bool {
+	if len(it.start) == 0 {
+		return false
+	}
+
+	prefix := it.start
+	if len(prefix) > len(path) {
+		prefix = prefix[:len(path)]
+	}
+
+	return bytes.Compare(path, prefix) < 0
+}
+
+func nibblesToBytes(nibbles []byte) []byte {
+	buf := make([]byte, len(nibbles)/2)
+	for i := range buf {
+		buf[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return buf
+}
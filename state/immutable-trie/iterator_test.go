@@ -0,0 +1,160 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestIterate_Accounts(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	objs := make([]*state.Object, 0, 100)
+	keys := make([][]byte, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		objs = append(objs, obj)
+		keys = append(keys, hashit(obj.Address.Bytes()))
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	_, rootBytes, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+
+	collect := func(s *State, start []byte, limit int) [][]byte {
+		visited := make([][]byte, 0, limit)
+
+		require.NoError(t, s.Iterate(root, start, func(key, value []byte) bool {
+			var account state.Account
+			require.NoError(t, account.UnmarshalRlp(value))
+
+			visited = append(visited, key)
+
+			return len(visited) < limit
+		}))
+
+		return visited
+	}
+
+	// a fresh state has no cached tries, so the nodes are resolved from the storage
+	for _, s := range []*State{st, NewState(storage)} {
+		require.Equal(t, keys, collect(s, nil, 1000))
+		require.Equal(t, keys[:10], collect(s, nil, 10))
+
+		// the start is inclusive
+		require.Equal(t, keys[42:52], collect(s, keys[42], 10))
+
+		// the start between the keys begins with the next key
+		next := new(big.Int).Add(new(big.Int).SetBytes(keys[42]), big.NewInt(1))
+		require.Equal(t, keys[43:], collect(s, next.FillBytes(make([]byte, 32)), 1000))
+
+		require.Empty(t, collect(s, bytes.Repeat([]byte{0xff}, 32), 1000))
+	}
+
+	// the empty trie has no leaves
+	require.NoError(t, st.Iterate(types.EmptyRootHash, nil, func(key, value []byte) bool {
+		t.Fatal("unexpected leaf")
+
+		return false
+	}))
+}
+
+func TestIterate_StorageOnOverlay(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	addr := types.StringToAddress("1")
+	obj := &state.Object{
+		Address:  addr,
+		Balance:  big.NewInt(1),
+		CodeHash: types.EmptyCodeHash,
+		Root:     types.EmptyRootHash,
+	}
+
+	for i := 0; i < 10; i++ {
+		obj.Storage = append(obj.Storage, &state.StorageObject{
+			Key: types.BytesToHash([]byte{byte(i)}).Bytes(),
+			Val: types.BytesToHash([]byte{byte(i + 1)}).Bytes(),
+		})
+	}
+
+	snap, rootBytes, err := st.NewSnapshot().Commit([]*state.Object{obj})
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+
+	// the commit on top of the overlay isn't written to the storage
+	overlay := NewOverlayState(st)
+
+	overlaySnap, err := overlay.NewSnapshotAt(types.BytesToHash(rootBytes))
+	require.NoError(t, err)
+
+	obj.Root = account.Root
+	obj.Storage = []*state.StorageObject{{
+		Key: types.BytesToHash([]byte{100}).Bytes(),
+		Val: types.BytesToHash([]byte{101}).Bytes(),
+	}}
+
+	_, overlayRoot, err := overlaySnap.Commit([]*state.Object{obj})
+	require.NoError(t, err)
+
+	_, err = NewState(storage).newTrieAt(types.BytesToHash(overlayRoot))
+	require.Error(t, err)
+
+	for _, c := range []struct {
+		state *State
+		root  types.Hash
+		slots int
+	}{
+		{st, account.Root, 10},
+		{overlay, types.ZeroHash, 11},
+	} {
+		root := c.root
+
+		if root == types.ZeroHash {
+			require.NoError(t, overlay.IterateAccounts(types.BytesToHash(overlayRoot), types.ZeroHash,
+				func(key types.Hash, account *state.Account) bool {
+					require.Equal(t, types.BytesToHash(hashit(addr.Bytes())), key)
+					root = account.Root
+
+					return true
+				}))
+		}
+
+		values := make(map[types.Hash]types.Hash)
+
+		require.NoError(t, c.state.IterateStorage(root, types.ZeroHash, func(key, value types.Hash) bool {
+			values[key] = value
+
+			return true
+		}))
+
+		require.Len(t, values, c.slots)
+		require.Equal(t, types.BytesToHash([]byte{5}), values[types.BytesToHash(hashit(types.BytesToHash([]byte{4}).Bytes()))])
+	}
+}
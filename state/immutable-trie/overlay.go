@@ -0,0 +1,38 @@
+package itrie
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// overlayStorage reads the nodes and the code from the base storage,
+// while the written ones are kept only in the memory
+type overlayStorage struct {
+	Storage
+
+	base Storage
+}
+
+// NewOverlayState returns the state on top of the storage of the given state, whose commits
+// are kept in the memory, so the intermediate states can be built without writing to the storage
+func NewOverlayState(s *State) *State {
+	return NewState(&overlayStorage{
+		Storage: NewMemoryStorage(),
+		base:    s.storage,
+	})
+}
+
+func (o *overlayStorage) Get(k []byte) ([]byte, bool, error) {
+	if v, ok, err := o.Storage.Get(k); ok || err != nil {
+		return v, ok, err
+	}
+
+	return o.base.Get(k)
+}
+
+func (o *overlayStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	if code, ok := o.Storage.GetCode(hash); ok {
+		return code, true
+	}
+
+	return o.base.GetCode(hash)
+}
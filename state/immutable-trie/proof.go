@@ -0,0 +1,346 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrProofNodeMissing is returned when a node referenced on the proof path is not part of the proof
+	ErrProofNodeMissing = errors.New("proof node missing")
+
+	// ErrProofInvalid is returned when a proof node can not be decoded
+	ErrProofInvalid = errors.New("invalid proof node")
+)
+
+// StorageProof is the merkle proof of a single storage slot
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
+// AccountProof is the merkle proof of an account
+// and a subset of its storage slots (EIP-1186)
+type AccountProof struct {
+	// Account is nil if the account does not exist in the state
+	Account       *state.Account
+	Proof         [][]byte
+	StorageProofs []*StorageProof
+}
+
+// GetProof returns the merkle proof of the account and the given storage slots at the given state root
+func (s *State) GetProof(root types.Hash, addr types.Address, storageKeys []types.Hash) (*AccountProof, error) {
+	trie, err := s.newTrieAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	key := hashit(addr.Bytes())
+
+	accountProof, err := trie.Txn(s.storage).Prove(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove account %s: %w", addr, err)
+	}
+
+	res := &AccountProof{
+		Proof:         accountProof,
+		StorageProofs: make([]*StorageProof, 0, len(storageKeys)),
+	}
+
+	storageRoot := types.EmptyRootHash
+
+	if data, ok := trie.Get(key, s.storage); ok {
+		var account state.Account
+		if err := account.UnmarshalRlp(data); err != nil {
+			return nil, err
+		}
+
+		res.Account = &account
+		storageRoot = account.Root
+	}
+
+	storageTrie, err := s.newTrieAt(storageRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storageKey := range storageKeys {
+		slot := hashit(storageKey.Bytes())
+
+		proof, err := storageTrie.Txn(s.storage).Prove(slot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove storage slot %s: %w", storageKey, err)
+		}
+
+		storageProof := &StorageProof{
+			Key:   storageKey,
+			Proof: proof,
+		}
+
+		if data, ok := storageTrie.Get(slot, s.storage); ok {
+			if storageProof.Value, err = decodeStorageValue(data); err != nil {
+				return nil, err
+			}
+		}
+
+		res.StorageProofs = append(res.StorageProofs, storageProof)
+	}
+
+	return res, nil
+}
+
+// Prove returns the RLP encoded nodes on the path from the root to the given key.
+// If the key is not present in the trie, the returned nodes prove its absence.
+func (t *Txn) Prove(key []byte) ([][]byte, error) {
+	h, ok := hasherPool.Get().(*hasher)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	defer func() {
+		h.ReleaseArenas(0)
+		hasherPool.Put(h)
+	}()
+
+	var (
+		proof  [][]byte
+		node   = t.root
+		search = bytesToHexNibbles(key)
+	)
+
+	for node != nil {
+		if v, ok := node.(*ValueNode); ok {
+			if !v.hash {
+				// reached the leaf value
+				break
+			}
+
+			nc, ok, err := GetNode(v.buf, t.storage)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("trie node %s not found", hex.EncodeToHex(v.buf))
+			}
+
+			node = nc
+
+			continue
+		}
+
+		// nodes smaller than 32 bytes are embedded into their parent,
+		// so only the root and the referenced nodes are part of the proof
+		if enc := t.encodeNode(node, h); len(proof) == 0 || len(enc) >= 32 {
+			proof = append(proof, enc)
+		}
+
+		switch n := node.(type) {
+		case *ShortNode:
+			plen := len(n.key)
+			if plen > len(search) || !bytes.Equal(search[:plen], n.key) {
+				return proof, nil
+			}
+
+			node = n.child
+			search = search[plen:]
+
+		case *FullNode:
+			if len(search) == 0 {
+				node = n.value
+			} else {
+				node = n.getEdge(search[0])
+				search = search[1:]
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown node type %T", n)
+		}
+	}
+
+	return proof, nil
+}
+
+// encodeNode returns the full RLP encoding of a short or a full node
+func (t *Txn) encodeNode(node Node, h *hasher) []byte {
+	a, _ := h.AcquireArena()
+	val := a.NewArray()
+
+	switch n := node.(type) {
+	case *ShortNode:
+		val.Set(a.NewBytes(encodeCompact(n.key)))
+		val.Set(t.hash(n.child, h, a, 0))
+
+	case *FullNode:
+		for _, i := range n.children {
+			if i == nil {
+				val.Set(a.NewNull())
+			} else {
+				val.Set(t.hash(i, h, a, 0))
+			}
+		}
+
+		if n.value == nil {
+			val.Set(a.NewNull())
+		} else {
+			val.Set(t.hash(n.value, h, a, 0))
+		}
+	}
+
+	return val.MarshalTo(nil)
+}
+
+// VerifyProof checks the merkle proof of the key against the given trie root.
+// It returns the value stored under the key, or nil if the proof shows that the key is absent.
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, n := range proof {
+		nodes[types.BytesToHash(hashit(n))] = n
+	}
+
+	var (
+		p      fastrlp.Parser
+		want   = root
+		search = bytesToHexNibbles(key)
+	)
+
+	for i := 0; ; i++ {
+		data, ok := nodes[want]
+		if !ok {
+			return nil, fmt.Errorf("%w: node %d (hash %s)", ErrProofNodeMissing, i, want)
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: node %d: %w", ErrProofInvalid, i, err)
+		}
+
+		if v.Type() != fastrlp.TypeArray {
+			return nil, fmt.Errorf("%w: node %d is not an array", ErrProofInvalid, i)
+		}
+
+		node, err := decodeNode(v, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: node %d: %w", ErrProofInvalid, i, err)
+		}
+
+		var (
+			ref   []byte
+			value []byte
+		)
+
+		search, ref, value = walkProofNode(node, search)
+		if ref == nil {
+			return value, nil
+		}
+
+		want = types.BytesToHash(ref)
+	}
+}
+
+// walkProofNode follows the search key through a decoded proof node and its embedded children.
+// It returns either the reference to the next proof node or the found value (nil if the key is absent)
+func walkProofNode(node Node, search []byte) ([]byte, []byte, []byte) {
+	for {
+		switch n := node.(type) {
+		case nil:
+			return nil, nil, nil
+
+		case *ValueNode:
+			if n.hash {
+				return search, n.buf, nil
+			}
+
+			if len(search) != 0 {
+				return nil, nil, nil
+			}
+
+			return nil, nil, n.buf
+
+		case *ShortNode:
+			plen := len(n.key)
+			if plen > len(search) || !bytes.Equal(search[:plen], n.key) {
+				return nil, nil, nil
+			}
+
+			node = n.child
+			search = search[plen:]
+
+		case *FullNode:
+			if len(search) == 0 {
+				node = n.value
+			} else {
+				node = n.getEdge(search[0])
+				search = search[1:]
+			}
+
+		default:
+			return nil, nil, nil
+		}
+	}
+}
+
+// VerifyAccountProof checks the account proof against the given state root.
+// It returns nil account if the proof shows that the account does not exist.
+func VerifyAccountProof(stateRoot types.Hash, addr types.Address, proof [][]byte) (*state.Account, error) {
+	data, err := VerifyProof(stateRoot, hashit(addr.Bytes()), proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// VerifyStorageProof checks the storage slot proof against the given account storage root
+// and returns the value stored in the slot
+func VerifyStorageProof(storageRoot types.Hash, key types.Hash, proof [][]byte) (types.Hash, error) {
+	data, err := VerifyProof(storageRoot, hashit(key.Bytes()), proof)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if data == nil {
+		return types.ZeroHash, nil
+	}
+
+	return decodeStorageValue(data)
+}
+
+// decodeStorageValue decodes the RLP encoded storage trie value
+func decodeStorageValue(data []byte) (types.Hash, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	res, err := v.GetBytes(nil)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(res), nil
+}
@@ -0,0 +1,108 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestProof_AccountAndStorage(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	objs := make([]*state.Object, 0, 64)
+
+	for i := 0; i < 64; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i * 1000)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%8 == 0 {
+			for j := 0; j < 16; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(j + 1), 0x1}).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, rootBytes, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+
+	// a fresh state has no cached tries, so the nodes are resolved from the storage
+	for _, s := range []*State{st, NewState(storage)} {
+		proof, err := s.GetProof(root, objs[8].Address, []types.Hash{
+			types.BytesToHash([]byte{3}),
+			types.BytesToHash([]byte{100}),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, proof.Account)
+		require.Len(t, proof.StorageProofs, 2)
+
+		account, err := VerifyAccountProof(root, objs[8].Address, proof.Proof)
+		require.NoError(t, err)
+		require.Equal(t, uint64(8), account.Nonce)
+		require.Equal(t, big.NewInt(8000), account.Balance)
+		require.Equal(t, proof.Account.Root, account.Root)
+
+		value, err := VerifyStorageProof(account.Root, types.BytesToHash([]byte{3}), proof.StorageProofs[0].Proof)
+		require.NoError(t, err)
+		require.Equal(t, types.BytesToHash([]byte{4, 0x1}), value)
+		require.Equal(t, value, proof.StorageProofs[0].Value)
+
+		value, err = VerifyStorageProof(account.Root, types.BytesToHash([]byte{100}), proof.StorageProofs[1].Proof)
+		require.NoError(t, err)
+		require.Equal(t, types.ZeroHash, value)
+	}
+
+	t.Run("missing account", func(t *testing.T) {
+		t.Parallel()
+
+		missing := types.StringToAddress("0xdeadbeef")
+
+		proof, err := st.GetProof(root, missing, []types.Hash{types.BytesToHash([]byte{1})})
+		require.NoError(t, err)
+		require.Nil(t, proof.Account)
+		require.NotEmpty(t, proof.Proof)
+		require.Empty(t, proof.StorageProofs[0].Proof)
+
+		account, err := VerifyAccountProof(root, missing, proof.Proof)
+		require.NoError(t, err)
+		require.Nil(t, account)
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := st.GetProof(root, objs[1].Address, nil)
+		require.NoError(t, err)
+
+		_, err = VerifyAccountProof(root, objs[1].Address, proof.Proof[:len(proof.Proof)-1])
+		require.ErrorIs(t, err, ErrProofNodeMissing)
+
+		tampered := make([][]byte, len(proof.Proof))
+		copy(tampered, proof.Proof)
+
+		last := append([]byte{}, tampered[len(tampered)-1]...)
+		last[len(last)-1] ^= 0xff
+		tampered[len(tampered)-1] = last
+
+		_, err = VerifyAccountProof(root, objs[1].Address, tampered)
+		require.ErrorIs(t, err, ErrProofNodeMissing)
+	})
+}
@@ -114,6 +114,30 @@ func (m *mockHost) GetRefund() uint64 {
 	panic("Not implemented in tests") //nolint:gocritic
 }
 
+func (m *mockHost) AddressInAccessList(addr types.Address) bool {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) AddAddressToAccessList(addr types.Address) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
 func TestRun(t *testing.T) {
 	t.Parallel()
 
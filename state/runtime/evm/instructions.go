@@ -459,13 +459,50 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dst := c.pop()
+	src := c.pop()
+	length := c.pop()
+
+	// eip-5656: memory is expanded to cover both the source and the destination
+	if !c.allocateMemory(src, length) || !c.allocateMemory(dst, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		d, s := dst.Uint64(), src.Uint64()
+		copy(c.memory[d:d+size], c.memory[s:s+size])
+	}
+}
+
 // --- storage ---
 
+// Gas costs introduced by eip-2929
+const (
+	ColdAccountAccessCostEIP2929 uint64 = 2600
+	ColdSloadCostEIP2929         uint64 = 2100
+	WarmStorageReadCostEIP2929   uint64 = 100
+)
+
 func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.slotAccessCost(bigToHash(loc))
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -500,12 +537,24 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
 
+	// eip-2929: accessing a cold slot is charged on top of the eip-2200 cost
+	if c.config.Berlin {
+		if _, slotWarm := c.host.SlotInAccessList(c.msg.Address, key); !slotWarm {
+			c.host.AddSlotToAccessList(c.msg.Address, key)
+
+			cost = ColdSloadCostEIP2929
+		}
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
+
 	switch status {
 	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			cost += WarmStorageReadCostEIP2929
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -515,10 +564,16 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageModified:
-		cost = 5000
+		if c.config.Berlin {
+			cost += 5000 - ColdSloadCostEIP2929
+		} else {
+			cost = 5000
+		}
 
 	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			cost += WarmStorageReadCostEIP2929
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -528,10 +583,14 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
+		cost += 20000
 
 	case runtime.StorageDeleted:
-		cost = 5000
+		if c.config.Berlin {
+			cost += 5000 - ColdSloadCostEIP2929
+		} else {
+			cost = 5000
+		}
 	}
 
 	if !c.consumeGas(cost) {
@@ -539,6 +598,62 @@ func opSStore(c *state) {
 	}
 }
 
+func opTLoad(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientState(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTStore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientState(c.msg.Address, key, val)
+}
+
+// addressAccessCost returns the eip-2929 cost of accessing the given account
+// and adds the account to the access list if it was cold
+func (c *state) addressAccessCost(addr types.Address) uint64 {
+	if c.host.AddressInAccessList(addr) {
+		return WarmStorageReadCostEIP2929
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return ColdAccountAccessCostEIP2929
+}
+
+// slotAccessCost returns the eip-2929 cost of reading the given storage slot
+// of the current contract and adds the slot to the access list if it was cold
+func (c *state) slotAccessCost(slot types.Hash) uint64 {
+	if _, slotWarm := c.host.SlotInAccessList(c.msg.Address, slot); slotWarm {
+		return WarmStorageReadCostEIP2929
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return ColdSloadCostEIP2929
+}
+
 const sha3WordGas uint64 = 6
 
 func opSha3(c *state) {
@@ -575,7 +690,10 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -658,7 +776,10 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +814,10 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +891,10 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -943,6 +1070,13 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// eip-2929: charge for the cold beneficiary account
+	if c.config.Berlin && !c.host.AddressInAccessList(address) {
+		c.host.AddAddressToAccessList(address)
+
+		gas += ColdAccountAccessCostEIP2929
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
@@ -975,6 +1109,16 @@ func opJumpi(c *state) {
 func opJumpDest(c *state) {
 }
 
+func opPush0(c *state) {
+	if !c.config.Shanghai {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().Set(zero)
+}
+
 func opPush(n int) instruction {
 	return func(c *state) {
 		ins := c.code
@@ -1226,7 +1370,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gasCost = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -1322,6 +1469,12 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	return contract, retOffset.Uint64(), retSize.Uint64(), nil
 }
 
+// Initcode limits introduced by eip-3860
+const (
+	MaxInitCodeSize        = 2 * 24576
+	InitCodeWordGas uint64 = 2
+)
+
 func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 	// Pop input arguments
 	value := c.pop()
@@ -1346,6 +1499,19 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 
 	var ok bool
 
+	// eip-3860: limit and meter the initcode
+	if c.config.Shanghai {
+		if !length.IsUint64() || length.Uint64() > MaxInitCodeSize {
+			c.exit(runtime.ErrMaxInitCodeSizeExceeded)
+
+			return nil, nil
+		}
+
+		if !c.consumeGas(((length.Uint64() + 31) / 32) * InitCodeWordGas) {
+			return nil, nil
+		}
+	}
+
 	input, ok = c.get2(input[:0], offset, length) // Does the memory check
 	if !ok {
 		return nil, nil
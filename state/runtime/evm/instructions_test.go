@@ -107,6 +107,64 @@ type mockHostForInstructions struct {
 	nonce       uint64
 	code        []byte
 	callxResult *runtime.ExecutionResult
+	accessList  map[types.Address]map[types.Hash]struct{}
+	transient   map[types.Address]map[types.Hash]types.Hash
+}
+
+func (m *mockHostForInstructions) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForInstructions) GetBalance(types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForInstructions) AddressInAccessList(addr types.Address) bool {
+	_, ok := m.accessList[addr]
+
+	return ok
+}
+
+func (m *mockHostForInstructions) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	slots, ok := m.accessList[addr]
+	if !ok {
+		return false, false
+	}
+
+	_, ok = slots[slot]
+
+	return true, ok
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(addr types.Address) {
+	if m.accessList == nil {
+		m.accessList = map[types.Address]map[types.Hash]struct{}{}
+	}
+
+	if _, ok := m.accessList[addr]; !ok {
+		m.accessList[addr] = map[types.Hash]struct{}{}
+	}
+}
+
+func (m *mockHostForInstructions) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.AddAddressToAccessList(addr)
+	m.accessList[addr][slot] = struct{}{}
+}
+
+func (m *mockHostForInstructions) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return m.transient[addr][key]
+}
+
+func (m *mockHostForInstructions) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	if m.transient == nil {
+		m.transient = map[types.Address]map[types.Hash]types.Hash{}
+	}
+
+	if _, ok := m.transient[addr]; !ok {
+		m.transient[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.transient[addr][key] = value
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -693,6 +751,79 @@ func Test_opCall(t *testing.T) {
 				},
 				memory: []byte{0x01},
 			},
+			resultState: &state{
+				memory: []byte{0x01},
+				stop:   false,
+				err:    nil,
+				gas:    900,
+			},
+			mockHost: &mockHostForInstructions{
+				callxResult: &runtime.ExecutionResult{
+					ReturnValue: []byte{0x03},
+				},
+				accessList: map[types.Address]map[types.Hash]struct{}{
+					types.ZeroAddress: {},
+				},
+			},
+		},
+		{
+			name: "should charge cold account access cost (EIP2929)",
+			op:   STATICCALL,
+			contract: &runtime.Contract{
+				Static: true,
+			},
+			config: allEnabledForks,
+			initState: &state{
+				gas: 3000,
+				sp:  6,
+				stack: []*big.Int{
+					big.NewInt(0x00), // outSize
+					big.NewInt(0x00), // outOffset
+					big.NewInt(0x00), // inSize
+					big.NewInt(0x00), // inOffset
+					big.NewInt(0x01), // address
+					big.NewInt(0x00), // initialGas
+				},
+				memory: []byte{0x01},
+			},
+			resultState: &state{
+				memory: []byte{0x01},
+				stop:   false,
+				err:    nil,
+				gas:    400,
+			},
+			mockHost: &mockHostForInstructions{
+				callxResult: &runtime.ExecutionResult{
+					ReturnValue: []byte{0x03},
+				},
+			},
+		},
+		{
+			name: "should charge legacy call cost (Berlin fork disabled)",
+			op:   STATICCALL,
+			contract: &runtime.Contract{
+				Static: true,
+			},
+			config: chain.ForksInTime{
+				Homestead: true,
+				Byzantium: true,
+				EIP150:    true,
+				EIP158:    true,
+				Istanbul:  true,
+			},
+			initState: &state{
+				gas: 1000,
+				sp:  6,
+				stack: []*big.Int{
+					big.NewInt(0x00), // outSize
+					big.NewInt(0x00), // outOffset
+					big.NewInt(0x00), // inSize
+					big.NewInt(0x00), // inOffset
+					big.NewInt(0x01), // address
+					big.NewInt(0x00), // initialGas
+				},
+				memory: []byte{0x01},
+			},
 			resultState: &state{
 				memory: []byte{0x01},
 				stop:   false,
@@ -797,3 +928,315 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func Test_AccessListGas(t *testing.T) {
+	t.Parallel()
+
+	berlinDisabled := chain.ForksInTime{
+		Homestead: true,
+		Byzantium: true,
+		EIP150:    true,
+		EIP158:    true,
+		Istanbul:  true,
+	}
+
+	tests := []struct {
+		name        string
+		instruction instruction
+		config      chain.ForksInTime
+		accessList  map[types.Address]map[types.Hash]struct{}
+		gasUsed     uint64
+	}{
+		{
+			name:        "SLOAD cold slot",
+			instruction: opSload,
+			config:      allEnabledForks,
+			gasUsed:     ColdSloadCostEIP2929,
+		},
+		{
+			name:        "SLOAD warm slot",
+			instruction: opSload,
+			config:      allEnabledForks,
+			accessList: map[types.Address]map[types.Hash]struct{}{
+				addr1: {types.ZeroHash: {}},
+			},
+			gasUsed: WarmStorageReadCostEIP2929,
+		},
+		{
+			name:        "SLOAD Berlin fork disabled",
+			instruction: opSload,
+			config:      berlinDisabled,
+			gasUsed:     800,
+		},
+		{
+			name:        "BALANCE cold address",
+			instruction: opBalance,
+			config:      allEnabledForks,
+			gasUsed:     ColdAccountAccessCostEIP2929,
+		},
+		{
+			name:        "BALANCE warm address",
+			instruction: opBalance,
+			config:      allEnabledForks,
+			accessList: map[types.Address]map[types.Hash]struct{}{
+				types.ZeroAddress: {},
+			},
+			gasUsed: WarmStorageReadCostEIP2929,
+		},
+		{
+			name:        "BALANCE Berlin fork disabled",
+			instruction: opBalance,
+			config:      berlinDisabled,
+			gasUsed:     700,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			host := &mockHostForInstructions{accessList: test.accessList}
+
+			s.gas = 10000
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &test.config
+			s.host = host
+			s.push(big.NewInt(0))
+
+			test.instruction(s)
+
+			assert.NoError(t, s.err)
+			assert.Equal(t, 10000-test.gasUsed, s.gas)
+
+			// the accessed item is warm afterwards
+			if test.config.Berlin {
+				s.push(big.NewInt(0))
+				test.instruction(s)
+
+				assert.Equal(t, 10000-test.gasUsed-WarmStorageReadCostEIP2929, s.gas)
+			}
+		})
+	}
+}
+
+func Test_opPush0(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.config = &allEnabledForks
+
+	opPush0(s)
+	assert.NoError(t, s.err)
+	assert.Equal(t, 1, s.sp)
+	assert.Equal(t, zero, s.pop())
+
+	shanghaiDisabled := chain.AllForksEnabled.Copy().RemoveFork(chain.Shanghai).At(0)
+	s.config = &shanghaiDisabled
+
+	opPush0(s)
+	assert.ErrorIs(t, s.err, errOpCodeNotFound)
+}
+
+func Test_TransientStorage(t *testing.T) {
+	t.Parallel()
+
+	key := big.NewInt(1)
+	value := big.NewInt(2)
+
+	t.Run("TSTORE and TLOAD", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		host := &mockHostForInstructions{}
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &allEnabledForks
+		s.host = host
+
+		s.push(value)
+		s.push(key)
+		opTStore(s)
+		assert.NoError(t, s.err)
+		assert.Equal(t, bigToHash(value), host.transient[addr1][bigToHash(key)])
+
+		s.push(key)
+		opTLoad(s)
+		assert.NoError(t, s.err)
+		assert.Equal(t, value, s.pop())
+	})
+
+	t.Run("TSTORE in static call", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.config = &allEnabledForks
+		s.host = &mockHostForInstructions{}
+
+		s.push(value)
+		s.push(key)
+		opTStore(s)
+		assert.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("Cancun fork disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		cancunDisabled := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &cancunDisabled
+		s.host = &mockHostForInstructions{}
+
+		s.push(key)
+		opTLoad(s)
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+func Test_opMCopy(t *testing.T) {
+	t.Parallel()
+
+	// word pads the given bytes to a 32 bytes memory word
+	word := func(b ...byte) []byte {
+		return append(b, make([]byte, 32-len(b))...)
+	}
+
+	tests := []struct {
+		name     string
+		dst      int64
+		src      int64
+		length   int64
+		memory   []byte
+		expected []byte
+		gasUsed  uint64
+	}{
+		{
+			name:     "copy to the unused memory",
+			dst:      32,
+			src:      0,
+			length:   2,
+			memory:   word(0x1, 0x2),
+			expected: append(word(0x1, 0x2), word(0x1, 0x2)...),
+			// memory expansion by one word and a copy of one word
+			gasUsed: 3 + 3,
+		},
+		{
+			name:     "overlapping areas",
+			dst:      1,
+			src:      0,
+			length:   3,
+			memory:   word(0x1, 0x2, 0x3, 0x4),
+			expected: word(0x1, 0x1, 0x2, 0x3),
+			gasUsed:  3,
+		},
+		{
+			name:     "zero length",
+			dst:      0,
+			src:      1,
+			length:   0,
+			memory:   word(0x1, 0x2),
+			expected: word(0x1, 0x2),
+			gasUsed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.config = &allEnabledForks
+			s.gas = 1000
+			s.memory = append(s.memory[:0], tt.memory...)
+			s.lastGasCost = 3 * uint64(len(tt.memory)/32)
+
+			s.push(big.NewInt(tt.length))
+			s.push(big.NewInt(tt.src))
+			s.push(big.NewInt(tt.dst))
+
+			opMCopy(s)
+
+			assert.NoError(t, s.err)
+			assert.Equal(t, tt.expected, s.memory)
+			assert.Equal(t, 1000-tt.gasUsed, s.gas)
+		})
+	}
+}
+
+func Test_InitCodeLimit(t *testing.T) {
+	t.Parallel()
+
+	shanghaiDisabled := chain.AllForksEnabled.Copy().RemoveFork(chain.Shanghai).At(0)
+
+	tests := []struct {
+		name   string
+		config chain.ForksInTime
+		length uint64
+		err    error
+	}{
+		{
+			name:   "initcode exceeds the limit",
+			config: allEnabledForks,
+			length: MaxInitCodeSize + 1,
+			err:    runtime.ErrMaxInitCodeSizeExceeded,
+		},
+		{
+			name:   "initcode is within the limit",
+			config: allEnabledForks,
+			length: MaxInitCodeSize,
+		},
+		{
+			name:   "Shanghai fork disabled",
+			config: shanghaiDisabled,
+			length: MaxInitCodeSize + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &tt.config
+			s.gas = 10000000
+			s.host = &mockHostForInstructions{}
+
+			s.push(new(big.Int).SetUint64(tt.length)) // length
+			s.push(big.NewInt(0))                     // offset
+			s.push(big.NewInt(0))                     // value
+
+			contract, _ := s.buildCreateContract(CREATE)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, s.err, tt.err)
+				assert.Nil(t, contract)
+			} else {
+				assert.NoError(t, s.err)
+				assert.NotNil(t, contract)
+			}
+		})
+	}
+}
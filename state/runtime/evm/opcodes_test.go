@@ -11,6 +11,7 @@ func TestOpcodesString(t *testing.T) {
 		assert.Equal(t, op.String(), str)
 	}
 
+	assert(PUSH0, "PUSH0")
 	assert(PUSH1, "PUSH1")
 	assert(PUSH32, "PUSH32")
 
@@ -197,3 +197,27 @@ func (d dummyHost) GetTracer() runtime.VMTracer {
 func (d dummyHost) GetRefund() uint64 {
 	return 0
 }
+
+func (d dummyHost) AddressInAccessList(addr types.Address) bool {
+	return false
+}
+
+func (d dummyHost) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return false, false
+}
+
+func (d dummyHost) AddAddressToAccessList(addr types.Address) {
+}
+
+func (d dummyHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+}
+
+func (d dummyHost) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	d.t.Fatalf("GetTransientState is not implemented")
+
+	return types.ZeroHash
+}
+
+func (d dummyHost) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	d.t.Fatalf("SetTransientState is not implemented")
+}
@@ -102,8 +102,26 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 		return false
 	}
 
+	return isActive(c.CodeAddress, config)
+}
+
+// Addresses returns the addresses of the precompiled contracts active under the given forks
+func (p *Precompiled) Addresses(config *chain.ForksInTime) []types.Address {
+	addrs := make([]types.Address, 0, len(p.contracts))
+
+	for addr := range p.contracts {
+		if isActive(addr, config) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+// isActive checks if the precompiled contract on the given address is enabled by the forks
+func isActive(addr types.Address, config *chain.ForksInTime) bool {
 	// byzantium precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case five:
 		fallthrough
 	case six:
@@ -115,7 +133,7 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	}
 
 	// istanbul precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case nine:
 		return config.Istanbul
 	}
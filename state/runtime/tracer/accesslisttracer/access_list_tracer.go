@@ -0,0 +1,210 @@
+package accesslisttracer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// accessList is an ordered set of addresses and their accessed storage slots
+type accessList struct {
+	addresses []types.Address
+	slots     map[types.Address][]types.Hash
+	seen      map[types.Address]map[types.Hash]struct{}
+}
+
+func newAccessList() *accessList {
+	return &accessList{
+		slots: map[types.Address][]types.Hash{},
+		seen:  map[types.Address]map[types.Hash]struct{}{},
+	}
+}
+
+func (al *accessList) addAddress(addr types.Address) {
+	if _, ok := al.seen[addr]; ok {
+		return
+	}
+
+	al.addresses = append(al.addresses, addr)
+	al.seen[addr] = map[types.Hash]struct{}{}
+}
+
+func (al *accessList) addSlot(addr types.Address, slot types.Hash) {
+	al.addAddress(addr)
+
+	if _, ok := al.seen[addr][slot]; ok {
+		return
+	}
+
+	al.seen[addr][slot] = struct{}{}
+	al.slots[addr] = append(al.slots[addr], slot)
+}
+
+// equal checks if both access lists contain the same addresses and slots, regardless of the order
+func (al *accessList) equal(other *accessList) bool {
+	if len(al.seen) != len(other.seen) {
+		return false
+	}
+
+	for addr, slots := range al.seen {
+		otherSlots, ok := other.seen[addr]
+		if !ok || len(slots) != len(otherSlots) {
+			return false
+		}
+
+		for slot := range slots {
+			if _, ok := otherSlots[slot]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (al *accessList) toTxAccessList() types.TxAccessList {
+	res := make(types.TxAccessList, 0, len(al.addresses))
+
+	for _, addr := range al.addresses {
+		storageKeys := make([]types.Hash, len(al.slots[addr]))
+		copy(storageKeys, al.slots[addr])
+
+		res = append(res, types.AccessTuple{
+			Address:     addr,
+			StorageKeys: storageKeys,
+		})
+	}
+
+	return res
+}
+
+// AccessListTracer records every address and storage slot touched during the execution,
+// except the excluded ones (sender, recipient and precompiles), which are always warm
+type AccessListTracer struct {
+	list     *accessList
+	excluded map[types.Address]struct{}
+}
+
+// NewAccessListTracer creates a new tracer initialized with the given access list
+func NewAccessListTracer(initial types.TxAccessList, excluded []types.Address) *AccessListTracer {
+	t := &AccessListTracer{
+		list:     newAccessList(),
+		excluded: make(map[types.Address]struct{}, len(excluded)),
+	}
+
+	for _, addr := range excluded {
+		t.excluded[addr] = struct{}{}
+	}
+
+	for _, tuple := range initial {
+		if !t.isExcluded(tuple.Address) {
+			t.list.addAddress(tuple.Address)
+		}
+
+		for _, slot := range tuple.StorageKeys {
+			t.list.addSlot(tuple.Address, slot)
+		}
+	}
+
+	return t
+}
+
+func (t *AccessListTracer) isExcluded(addr types.Address) bool {
+	_, ok := t.excluded[addr]
+
+	return ok
+}
+
+// AccessList returns the recorded access list
+func (t *AccessListTracer) AccessList() types.TxAccessList {
+	return t.list.toTxAccessList()
+}
+
+// Equal returns true if both tracers recorded the same access list
+func (t *AccessListTracer) Equal(other *AccessListTracer) bool {
+	return t.list.equal(other.list)
+}
+
+func (t *AccessListTracer) Cancel(err error) {
+}
+
+func (t *AccessListTracer) Clear() {
+	t.list = newAccessList()
+}
+
+func (t *AccessListTracer) GetResult() (interface{}, error) {
+	return t.AccessList(), nil
+}
+
+func (t *AccessListTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *AccessListTracer) TxEnd(gasLeft uint64) {
+}
+
+func (t *AccessListTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *AccessListTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (t *AccessListTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			t.list.addSlot(contractAddress, bigToHash(stack[sp-1]))
+		}
+
+	case evm.EXTCODECOPY, evm.EXTCODEHASH, evm.EXTCODESIZE, evm.BALANCE, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			if addr := bigToAddress(stack[sp-1]); !t.isExcluded(addr) {
+				t.list.addAddress(addr)
+			}
+		}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 5 {
+			if addr := bigToAddress(stack[sp-2]); !t.isExcluded(addr) {
+				t.list.addAddress(addr)
+			}
+		}
+	}
+}
+
+func (t *AccessListTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opcode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func bigToHash(b *big.Int) types.Hash {
+	return types.BytesToHash(b.Bytes())
+}
+
+func bigToAddress(b *big.Int) types.Address {
+	return types.BytesToAddress(b.Bytes())
+}
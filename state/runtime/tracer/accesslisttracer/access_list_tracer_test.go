@@ -0,0 +1,60 @@
+package accesslisttracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestAccessListTracer_CaptureState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from     = types.StringToAddress("1")
+		to       = types.StringToAddress("2")
+		callee   = types.StringToAddress("3")
+		balance  = types.StringToAddress("4")
+		listed   = types.StringToAddress("5")
+		slot     = types.StringToHash("6")
+		listSlot = types.StringToHash("7")
+	)
+
+	tracer := NewAccessListTracer(types.TxAccessList{
+		{Address: listed, StorageKeys: []types.Hash{listSlot}},
+	}, []types.Address{from, to})
+
+	// storage of the excluded recipient is still recorded
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot.Bytes())}, evm.SLOAD, to, 1, nil, nil)
+
+	// excluded addresses are not recorded
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(from.Bytes())}, evm.BALANCE, to, 1, nil, nil)
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(balance.Bytes())}, evm.BALANCE, to, 1, nil, nil)
+
+	// gas, address, value, inOffset, inSize, outOffset, outSize
+	callStack := []*big.Int{
+		big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0),
+		new(big.Int).SetBytes(callee.Bytes()), big.NewInt(1000),
+	}
+	tracer.CaptureState(nil, callStack, evm.CALL, to, len(callStack), nil, nil)
+
+	expected := types.TxAccessList{
+		{Address: listed, StorageKeys: []types.Hash{listSlot}},
+		{Address: to, StorageKeys: []types.Hash{slot}},
+		{Address: balance, StorageKeys: []types.Hash{}},
+		{Address: callee, StorageKeys: []types.Hash{}},
+	}
+
+	require.Equal(t, expected, tracer.AccessList())
+
+	// the order of the entries doesn't matter for equality
+	reordered := NewAccessListTracer(types.TxAccessList{
+		expected[3], expected[2], expected[1], expected[0],
+	}, []types.Address{from, to})
+	require.True(t, tracer.Equal(reordered))
+
+	require.False(t, tracer.Equal(NewAccessListTracer(expected[:3], nil)))
+}
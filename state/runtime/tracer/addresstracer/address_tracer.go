@@ -0,0 +1,82 @@
+package addresstracer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AddressTracer collects the addresses touched by the transaction: the callers and the callees
+// of all the calls, the created contracts and the beneficiaries of the self-destructs.
+// It doesn't record anything per opcode, so it's cheap enough to trace every block
+type AddressTracer struct {
+	addresses []types.Address
+	seen      map[types.Address]struct{}
+}
+
+func NewAddressTracer() *AddressTracer {
+	return &AddressTracer{
+		seen: map[types.Address]struct{}{},
+	}
+}
+
+// Addresses returns the touched addresses in the order they are first touched
+func (t *AddressTracer) Addresses() []types.Address {
+	return t.addresses
+}
+
+func (t *AddressTracer) Cancel(err error) {
+}
+
+func (t *AddressTracer) Clear() {
+	t.addresses = nil
+	t.seen = map[types.Address]struct{}{}
+}
+
+func (t *AddressTracer) GetResult() (interface{}, error) {
+	return t.addresses, nil
+}
+
+func (t *AddressTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *AddressTracer) TxEnd(gasLeft uint64) {
+}
+
+// CallStart is a no-op, the calls are captured by CallFrameStart
+func (t *AddressTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+}
+
+func (t *AddressTracer) CallFrameStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	t.add(from)
+	t.add(to)
+}
+
+func (t *AddressTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (t *AddressTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if opCode != evm.SELFDESTRUCT || sp < 1 {
+		return
+	}
+
+	t.add(types.BytesToAddress(stack[sp-1].Bytes()))
+}
+
+func (t *AddressTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+func (t *AddressTracer) add(addr types.Address) {
+	if _, ok := t.seen[addr]; ok {
+		return
+	}
+
+	t.addresses = append(t.addresses, addr)
+	t.seen[addr] = struct{}{}
+}
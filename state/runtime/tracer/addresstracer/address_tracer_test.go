@@ -0,0 +1,48 @@
+package addresstracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+	addr4 = types.StringToAddress("4")
+)
+
+func TestAddressTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewAddressTracer()
+
+	tracer.CallFrameStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(1), nil)
+	tracer.CallFrameStart(2, addr2, addr3, int(runtime.StaticCall), 500, nil, nil)
+	tracer.CallEnd(2, nil, nil)
+	tracer.CallFrameStart(2, addr2, addr1, int(runtime.Call), 500, nil, nil)
+	tracer.CallEnd(2, nil, runtime.ErrExecutionReverted)
+
+	// the beneficiary of the self-destruct is touched as well
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(addr4.Bytes())}, evm.SELFDESTRUCT, addr2, 1, nil, nil)
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(5)}, evm.ADD, addr2, 1, nil, nil)
+	tracer.CallEnd(1, nil, nil)
+
+	require.Equal(t, []types.Address{addr1, addr2, addr3, addr4}, tracer.Addresses())
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, tracer.Addresses(), res)
+
+	tracer.Clear()
+	require.Empty(t, tracer.Addresses())
+
+	tracer.CallFrameStart(1, addr3, addr1, int(runtime.Create), 1000, nil, nil)
+	require.Equal(t, []types.Address{addr3, addr1}, tracer.Addresses())
+}
@@ -0,0 +1,320 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	TraceTypeCall    = "call"
+	TraceTypeCreate  = "create"
+	TraceTypeSuicide = "suicide"
+
+	errReverted = "Reverted"
+
+	creationMethodCreate  = "create"
+	creationMethodCreate2 = "create2"
+)
+
+var (
+	callTypes = map[int]string{
+		int(runtime.Call):         "call",
+		int(runtime.CallCode):     "callcode",
+		int(runtime.DelegateCall): "delegatecall",
+		int(runtime.StaticCall):   "staticcall",
+	}
+)
+
+// Trace is a single flat (parity-style) trace entry
+type Trace struct {
+	Action       interface{} `json:"action"`
+	Result       interface{} `json:"result"`
+	Error        string      `json:"error,omitempty"`
+	Subtraces    int         `json:"subtraces"`
+	TraceAddress []int       `json:"traceAddress"`
+	Type         string      `json:"type"`
+}
+
+// CallAction is the action of a call trace
+type CallAction struct {
+	CallType string        `json:"callType"`
+	From     types.Address `json:"from"`
+	To       types.Address `json:"to"`
+	Gas      string        `json:"gas"`
+	Input    string        `json:"input"`
+	Value    string        `json:"value"`
+}
+
+// CreateAction is the action of a create trace
+type CreateAction struct {
+	From           types.Address `json:"from"`
+	Gas            string        `json:"gas"`
+	Init           string        `json:"init"`
+	Value          string        `json:"value"`
+	CreationMethod string        `json:"creationMethod"`
+}
+
+// IsCreate2 returns true if the contract is created by the CREATE2 opcode
+func (a *CreateAction) IsCreate2() bool {
+	return a.CreationMethod == creationMethodCreate2
+}
+
+// SuicideAction is the action of a suicide trace
+type SuicideAction struct {
+	Address       types.Address `json:"address"`
+	RefundAddress types.Address `json:"refundAddress"`
+	Balance       string        `json:"balance"`
+}
+
+// CallResult is the result of a successful call trace
+type CallResult struct {
+	GasUsed string `json:"gasUsed"`
+	Output  string `json:"output"`
+}
+
+// CreateResult is the result of a successful create trace
+type CreateResult struct {
+	Address types.Address `json:"address"`
+	Code    string        `json:"code"`
+	GasUsed string        `json:"gasUsed"`
+}
+
+// frame is a call which is currently being executed
+type frame struct {
+	trace    *Trace
+	depth    int
+	to       types.Address
+	startGas uint64
+	gasLeft  uint64
+}
+
+// FlatTracer records all the calls of a transaction as a flat list of traces
+type FlatTracer struct {
+	traces []*Trace
+	frames []*frame
+
+	// output is the output of the top-level call, the revert data if it's reverted
+	output []byte
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func (t *FlatTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.stop = true
+}
+
+func (t *FlatTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.stop
+}
+
+func (t *FlatTracer) Clear() {
+	t.traces = nil
+	t.frames = nil
+	t.output = nil
+}
+
+// GetResult returns the recorded traces in the order the calls were started
+func (t *FlatTracer) GetResult() (interface{}, error) {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	traces := t.traces
+	if traces == nil {
+		traces = []*Trace{}
+	}
+
+	return traces, nil
+}
+
+// Output returns the output of the top-level call, which is the revert data if the call is reverted
+func (t *FlatTracer) Output() []byte {
+	return t.output
+}
+
+func (t *FlatTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *FlatTracer) TxEnd(gasLeft uint64) {
+}
+
+// CallStart is a no-op, the calls are captured by CallFrameStart
+func (t *FlatTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+}
+
+func (t *FlatTracer) CallFrameStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if t.cancelled() {
+		return
+	}
+
+	val := "0x0"
+	if value != nil {
+		val = hex.EncodeBig(value)
+	}
+
+	trace := &Trace{}
+
+	switch callType {
+	case int(runtime.Create), int(runtime.Create2):
+		creationMethod := creationMethodCreate
+		if callType == int(runtime.Create2) {
+			creationMethod = creationMethodCreate2
+		}
+
+		trace.Type = TraceTypeCreate
+		trace.Action = &CreateAction{
+			From:           from,
+			Gas:            hex.EncodeUint64(gas),
+			Init:           hex.EncodeToHex(input),
+			Value:          val,
+			CreationMethod: creationMethod,
+		}
+	default:
+		trace.Type = TraceTypeCall
+		trace.Action = &CallAction{
+			CallType: callTypes[callType],
+			From:     from,
+			To:       to,
+			Gas:      hex.EncodeUint64(gas),
+			Input:    hex.EncodeToHex(input),
+			Value:    val,
+		}
+	}
+
+	t.addTrace(trace)
+
+	t.frames = append(t.frames, &frame{
+		trace:    trace,
+		depth:    depth,
+		to:       to,
+		startGas: gas,
+		gasLeft:  gas,
+	})
+}
+
+func (t *FlatTracer) CallEnd(depth int, output []byte, err error) {
+	if t.cancelled() || len(t.frames) == 0 {
+		return
+	}
+
+	active := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	gasUsed := active.startGas - active.gasLeft
+
+	if len(t.frames) == 0 {
+		t.output = output
+	}
+
+	if err != nil {
+		active.trace.Error = errorString(err)
+
+		return
+	}
+
+	if active.trace.Type == TraceTypeCreate {
+		active.trace.Result = &CreateResult{
+			Address: active.to,
+			Code:    hex.EncodeToHex(output),
+			GasUsed: hex.EncodeUint64(gasUsed),
+		}
+
+		return
+	}
+
+	active.trace.Result = &CallResult{
+		GasUsed: hex.EncodeUint64(gasUsed),
+		Output:  hex.EncodeToHex(output),
+	}
+}
+
+func (t *FlatTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if opCode != evm.SELFDESTRUCT || sp < 1 || len(t.frames) == 0 {
+		return
+	}
+
+	balance := "0x0"
+	if b := host.GetBalance(contractAddress); b != nil {
+		balance = hex.EncodeBig(b)
+	}
+
+	t.addTrace(&Trace{
+		Type: TraceTypeSuicide,
+		Action: &SuicideAction{
+			Address:       contractAddress,
+			RefundAddress: types.BytesToAddress(stack[sp-1].Bytes()),
+			Balance:       balance,
+		},
+	})
+}
+
+func (t *FlatTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+	if len(t.frames) == 0 {
+		return
+	}
+
+	active := t.frames[len(t.frames)-1]
+	if active.depth != depth {
+		return
+	}
+
+	if availableGas > cost {
+		active.gasLeft = availableGas - cost
+	} else {
+		active.gasLeft = 0
+	}
+}
+
+// addTrace appends the trace as the next subtrace of the active call
+func (t *FlatTracer) addTrace(trace *Trace) {
+	trace.TraceAddress = []int{}
+
+	if len(t.frames) > 0 {
+		parent := t.frames[len(t.frames)-1].trace
+
+		trace.TraceAddress = make([]int, len(parent.TraceAddress), len(parent.TraceAddress)+1)
+		copy(trace.TraceAddress, parent.TraceAddress)
+		trace.TraceAddress = append(trace.TraceAddress, parent.Subtraces)
+
+		parent.Subtraces++
+	}
+
+	t.traces = append(t.traces, trace)
+}
+
+func errorString(err error) string {
+	if errors.Is(err, runtime.ErrExecutionReverted) {
+		return errReverted
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,227 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockHost struct {
+	balance *big.Int
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	return m.balance
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	return 0
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	return nil
+}
+
+func getTraces(t *testing.T, tracer *FlatTracer) []*Trace {
+	t.Helper()
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	traces, ok := res.([]*Trace)
+	require.True(t, ok)
+
+	return traces
+}
+
+func TestFlatTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := &FlatTracer{}
+
+	require.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	require.True(t, tracer.cancelled())
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, addr1, 0, &mockHost{}, state)
+	require.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
+
+func TestFlatTracer_Empty(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []*Trace{}, getTraces(t, &FlatTracer{}))
+}
+
+func TestFlatTracer_NestedCalls(t *testing.T) {
+	t.Parallel()
+
+	tracer := &FlatTracer{}
+
+	tracer.CallFrameStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(10), []byte{0x1})
+	tracer.ExecuteState(addr2, 0, "PUSH1", 1000, 3, nil, 1, nil, nil)
+
+	// first sub call succeeds
+	tracer.CallFrameStart(2, addr2, addr3, int(runtime.StaticCall), 500, nil, nil)
+	tracer.ExecuteState(addr3, 0, "STOP", 500, 100, nil, 2, nil, nil)
+	tracer.CallEnd(2, []byte{0x2}, nil)
+
+	// second sub call reverts
+	tracer.CallFrameStart(2, addr2, addr3, int(runtime.DelegateCall), 300, nil, nil)
+	tracer.CallEnd(2, nil, runtime.ErrExecutionReverted)
+
+	tracer.ExecuteState(addr2, 10, "RETURN", 400, 0, nil, 1, nil, nil)
+	tracer.CallEnd(1, []byte{0x3}, nil)
+
+	expected := []*Trace{
+		{
+			Action: &CallAction{
+				CallType: "call",
+				From:     addr1,
+				To:       addr2,
+				Gas:      "0x3e8",
+				Input:    "0x01",
+				Value:    "0xa",
+			},
+			Result:       &CallResult{GasUsed: "0x258", Output: "0x03"},
+			Subtraces:    2,
+			TraceAddress: []int{},
+			Type:         TraceTypeCall,
+		},
+		{
+			Action: &CallAction{
+				CallType: "staticcall",
+				From:     addr2,
+				To:       addr3,
+				Gas:      "0x1f4",
+				Input:    "0x",
+				Value:    "0x0",
+			},
+			Result:       &CallResult{GasUsed: "0x64", Output: "0x02"},
+			TraceAddress: []int{0},
+			Type:         TraceTypeCall,
+		},
+		{
+			Action: &CallAction{
+				CallType: "delegatecall",
+				From:     addr2,
+				To:       addr3,
+				Gas:      "0x12c",
+				Input:    "0x",
+				Value:    "0x0",
+			},
+			Error:        "Reverted",
+			TraceAddress: []int{1},
+			Type:         TraceTypeCall,
+		},
+	}
+
+	require.Equal(t, expected, getTraces(t, tracer))
+	require.Equal(t, []byte{0x3}, tracer.Output())
+
+	tracer.Clear()
+	require.Equal(t, []*Trace{}, getTraces(t, tracer))
+	require.Nil(t, tracer.Output())
+}
+
+func TestFlatTracer_CreateAndSuicide(t *testing.T) {
+	t.Parallel()
+
+	tracer := &FlatTracer{}
+	host := &mockHost{balance: big.NewInt(5)}
+
+	tracer.CallFrameStart(1, addr1, addr2, int(runtime.Create), 1000, nil, []byte{0x60})
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(addr3.Bytes())},
+		evm.SELFDESTRUCT, addr2, 1, host, &mockState{})
+	tracer.ExecuteState(addr2, 0, "SELFDESTRUCT", 1000, 200, nil, 1, nil, nil)
+	tracer.CallEnd(1, []byte{0x1}, nil)
+
+	expected := []*Trace{
+		{
+			Action: &CreateAction{
+				From:           addr1,
+				Gas:            "0x3e8",
+				Init:           "0x60",
+				Value:          "0x0",
+				CreationMethod: creationMethodCreate,
+			},
+			Result: &CreateResult{
+				Address: addr2,
+				Code:    "0x01",
+				GasUsed: "0xc8",
+			},
+			Subtraces:    1,
+			TraceAddress: []int{},
+			Type:         TraceTypeCreate,
+		},
+		{
+			Action: &SuicideAction{
+				Address:       addr2,
+				RefundAddress: addr3,
+				Balance:       "0x5",
+			},
+			TraceAddress: []int{0},
+			Type:         TraceTypeSuicide,
+		},
+	}
+
+	require.Equal(t, expected, getTraces(t, tracer))
+}
+
+func TestFlatTracer_RevertedCreate2(t *testing.T) {
+	t.Parallel()
+
+	tracer := &FlatTracer{}
+	revertData := []byte{0x8, 0xc3, 0x79, 0xa0}
+
+	tracer.CallFrameStart(1, addr1, addr2, int(runtime.Create2), 1000, big.NewInt(1), []byte{0x60})
+	tracer.CallEnd(1, revertData, runtime.ErrExecutionReverted)
+
+	traces := getTraces(t, tracer)
+	require.Len(t, traces, 1)
+	require.Equal(t, "Reverted", traces[0].Error)
+	require.Nil(t, traces[0].Result)
+
+	action, ok := traces[0].Action.(*CreateAction)
+	require.True(t, ok)
+	require.True(t, action.IsCreate2())
+
+	// the revert data is kept as the output of the top-level call
+	require.Equal(t, revertData, tracer.Output())
+}
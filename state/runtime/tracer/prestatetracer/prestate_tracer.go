@@ -0,0 +1,356 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Config is the configuration of the prestate tracer
+type Config struct {
+	// DiffMode makes the tracer return the state before and after the transaction
+	// of the modified accounts only
+	DiffMode bool `json:"diffMode"`
+}
+
+// Account is the state of an account in the tracer result
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// State is the state of the accounts in the tracer result
+type State map[types.Address]*Account
+
+// DiffResult is the result of the tracer in diff mode
+type DiffResult struct {
+	Pre  State `json:"pre"`
+	Post State `json:"post"`
+}
+
+type accountState struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+func (a *accountState) empty() bool {
+	return a.nonce == 0 && a.balance.Sign() == 0 && len(a.code) == 0
+}
+
+func (a *accountState) toAccount() *Account {
+	acc := &Account{
+		Balance: hex.EncodeBig(a.balance),
+		Nonce:   a.nonce,
+	}
+
+	if len(a.code) > 0 {
+		acc.Code = hex.EncodeToHex(a.code)
+	}
+
+	if len(a.storage) > 0 {
+		acc.Storage = make(map[types.Hash]types.Hash, len(a.storage))
+
+		for key, value := range a.storage {
+			acc.Storage[key] = value
+		}
+	}
+
+	return acc
+}
+
+// PrestateTracer records the state of all the accounts touched by a transaction
+// as it was before the transaction, and the changed state after it in diff mode
+type PrestateTracer struct {
+	config Config
+
+	pre     map[types.Address]*accountState
+	post    map[types.Address]*Account
+	created map[types.Address]struct{}
+	deleted map[types.Address]struct{}
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	t := &PrestateTracer{
+		config: config,
+	}
+
+	t.Clear()
+
+	return t
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.stop = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.stop
+}
+
+func (t *PrestateTracer) Clear() {
+	t.pre = map[types.Address]*accountState{}
+	t.post = map[types.Address]*Account{}
+	t.created = map[types.Address]struct{}{}
+	t.deleted = map[types.Address]struct{}{}
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	pre := make(State, len(t.pre))
+
+	for addr, acc := range t.pre {
+		pre[addr] = acc.toAccount()
+	}
+
+	if !t.config.DiffMode {
+		return pre, nil
+	}
+
+	post := make(State, len(t.post))
+
+	for addr, acc := range t.post {
+		post[addr] = acc
+	}
+
+	return &DiffResult{
+		Pre:  pre,
+		Post: post,
+	}, nil
+}
+
+func (t *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+// TxStateStart records the state of the accounts touched by the transaction itself
+func (t *PrestateTracer) TxStateStart(host tracer.RuntimeHost, addresses ...types.Address) {
+	for _, addr := range addresses {
+		t.lookupAccount(host, addr)
+	}
+}
+
+// TxStateEnd computes the changed state of the accounts in diff mode
+// and drops the accounts created by the transaction from the pre state
+func (t *PrestateTracer) TxStateEnd(host tracer.RuntimeHost) {
+	if t.cancelled() {
+		return
+	}
+
+	if t.config.DiffMode {
+		t.processDiffState(host)
+	}
+
+	// the state of the created accounts was empty before the transaction
+	for addr := range t.created {
+		if acc, ok := t.pre[addr]; ok && acc.empty() {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+func (t *PrestateTracer) processDiffState(host tracer.RuntimeHost) {
+	for addr, acc := range t.pre {
+		// the deleted accounts are present in the pre state only
+		if _, ok := t.deleted[addr]; ok {
+			continue
+		}
+
+		var (
+			modified = false
+			post     = &Account{}
+		)
+
+		if balance := host.GetBalance(addr); balance.Cmp(acc.balance) != 0 {
+			modified = true
+			post.Balance = hex.EncodeBig(balance)
+		}
+
+		if nonce := host.GetNonce(addr); nonce != acc.nonce {
+			modified = true
+			post.Nonce = nonce
+		}
+
+		if code := host.GetCode(addr); !bytes.Equal(code, acc.code) {
+			modified = true
+
+			if len(code) > 0 {
+				post.Code = hex.EncodeToHex(code)
+			}
+		}
+
+		for key, value := range acc.storage {
+			newValue := host.GetStorage(addr, key)
+
+			if value == newValue {
+				// only the modified slots are kept
+				delete(acc.storage, key)
+
+				continue
+			}
+
+			modified = true
+
+			// the empty slots are omitted
+			if value == types.ZeroHash {
+				delete(acc.storage, key)
+			}
+
+			if newValue != types.ZeroHash {
+				if post.Storage == nil {
+					post.Storage = map[types.Hash]types.Hash{}
+				}
+
+				post.Storage[key] = newValue
+			}
+		}
+
+		if modified {
+			t.post[addr] = post
+		} else {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+// CallStart is a no-op, the calls are captured by CallFrameStart
+func (t *PrestateTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+}
+
+func (t *PrestateTracer) CallFrameStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if callType == int(runtime.Create) || callType == int(runtime.Create2) {
+		t.created[to] = struct{}{}
+	}
+}
+
+func (t *PrestateTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (t *PrestateTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			t.lookupStorage(host, contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+
+	case evm.EXTCODECOPY, evm.EXTCODEHASH, evm.EXTCODESIZE, evm.BALANCE:
+		if sp >= 1 {
+			t.lookupAccount(host, types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+	case evm.SELFDESTRUCT:
+		if sp >= 1 {
+			t.lookupAccount(host, types.BytesToAddress(stack[sp-1].Bytes()))
+			t.deleted[contractAddress] = struct{}{}
+		}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			t.lookupAccount(host, types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+
+	case evm.CREATE:
+		t.lookupAccount(host, crypto.CreateAddress(contractAddress, host.GetNonce(contractAddress)))
+
+	case evm.CREATE2:
+		if sp >= 4 {
+			initCode, ok := memorySlice(memory, stack[sp-2], stack[sp-3])
+			if !ok {
+				return
+			}
+
+			salt := types.BytesToHash(stack[sp-4].Bytes())
+
+			t.lookupAccount(host, crypto.CreateAddress2(contractAddress, salt, initCode))
+		}
+	}
+}
+
+func (t *PrestateTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+// lookupAccount records the state of the account if it hasn't been touched before
+func (t *PrestateTracer) lookupAccount(host tracer.RuntimeHost, addr types.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+
+	balance := host.GetBalance(addr)
+	if balance == nil {
+		balance = big.NewInt(0)
+	}
+
+	t.pre[addr] = &accountState{
+		balance: new(big.Int).Set(balance),
+		nonce:   host.GetNonce(addr),
+		code:    host.GetCode(addr),
+		storage: map[types.Hash]types.Hash{},
+	}
+}
+
+// lookupStorage records the value of the slot if it hasn't been touched before
+func (t *PrestateTracer) lookupStorage(host tracer.RuntimeHost, addr types.Address, key types.Hash) {
+	t.lookupAccount(host, addr)
+
+	if _, ok := t.pre[addr].storage[key]; ok {
+		return
+	}
+
+	t.pre[addr].storage[key] = host.GetStorage(addr, key)
+}
+
+// memorySlice returns a copy of the memory in the given range, padded with zeros
+// if the memory hasn't been expanded yet, or false if the range is too large
+func memorySlice(memory []byte, offset, size *big.Int) ([]byte, bool) {
+	if !offset.IsUint64() || !size.IsUint64() || size.Uint64() > evm.MaxInitCodeSize {
+		return nil, false
+	}
+
+	start, length := offset.Uint64(), size.Uint64()
+	res := make([]byte, length)
+
+	if start < uint64(len(memory)) {
+		copy(res, memory[start:])
+	}
+
+	return res, true
+}
@@ -0,0 +1,206 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+
+	slot1 = types.StringToHash("1")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockAccount struct {
+	balance int64
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func (m *mockHost) account(addr types.Address) *mockAccount {
+	acc, ok := m.accounts[addr]
+	if !ok {
+		acc = &mockAccount{}
+		m.accounts[addr] = acc
+	}
+
+	return acc
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.account(addr).storage[key]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(m.account(addr).balance)
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.account(addr).nonce
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.account(addr).code
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewPrestateTracer(Config{})
+
+	tracer.Cancel(err)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, addr1, 0, &mockHost{}, state)
+	require.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
+
+func TestPrestateTracer_PreState(t *testing.T) {
+	t.Parallel()
+
+	host := &mockHost{accounts: map[types.Address]*mockAccount{
+		addr1: {balance: 100, nonce: 2},
+		addr2: {code: []byte{0x1}, storage: map[types.Hash]types.Hash{slot1: {0x2}}},
+	}}
+
+	created := crypto.CreateAddress(addr2, 0)
+
+	tracer := NewPrestateTracer(Config{})
+	tracer.TxStateStart(host, addr1, addr2)
+
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot1.Bytes())},
+		evm.SLOAD, addr2, 1, host, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(addr3.Bytes())},
+		evm.BALANCE, addr2, 1, host, &mockState{})
+	tracer.CaptureState(nil, nil, evm.CREATE, addr2, 3, host, &mockState{})
+
+	// the state changes after the first access are not recorded
+	host.accounts[addr2].storage[slot1] = types.Hash{0x3}
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot1.Bytes())},
+		evm.SSTORE, addr2, 1, host, &mockState{})
+
+	tracer.CallFrameStart(2, addr2, created, int(runtime.Create), 100, nil, nil)
+	tracer.CallEnd(2, nil, nil)
+	tracer.TxStateEnd(host)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	// the created account is dropped since it was empty
+	require.Equal(t, State{
+		addr1: {Balance: "0x64", Nonce: 2},
+		addr2: {
+			Balance: "0x0",
+			Code:    "0x01",
+			Storage: map[types.Hash]types.Hash{slot1: {0x2}},
+		},
+		addr3: {Balance: "0x0"},
+	}, res)
+}
+
+func TestPrestateTracer_DiffMode(t *testing.T) {
+	t.Parallel()
+
+	host := &mockHost{accounts: map[types.Address]*mockAccount{
+		addr1: {balance: 100},
+		addr2: {balance: 5, code: []byte{0x1}, storage: map[types.Hash]types.Hash{slot1: {0x2}}},
+		addr3: {balance: 7},
+	}}
+
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+	tracer.TxStateStart(host, addr1, addr2, addr3)
+
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot1.Bytes())},
+		evm.SLOAD, addr2, 1, host, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(addr1.Bytes())},
+		evm.SELFDESTRUCT, addr3, 1, host, &mockState{})
+
+	host.accounts[addr1].balance = 107
+	host.accounts[addr1].nonce = 1
+	host.accounts[addr2].storage[slot1] = types.Hash{0x4}
+	host.accounts[addr3].balance = 0
+
+	tracer.TxStateEnd(host)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	require.Equal(t, &DiffResult{
+		Pre: State{
+			addr1: {Balance: "0x64"},
+			addr2: {
+				Balance: "0x5",
+				Code:    "0x01",
+				Storage: map[types.Hash]types.Hash{slot1: {0x2}},
+			},
+			addr3: {Balance: "0x7"},
+		},
+		Post: State{
+			addr1: {Balance: "0x6b", Nonce: 1},
+			addr2: {Storage: map[types.Hash]types.Hash{slot1: {0x4}}},
+		},
+	}, res)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, &DiffResult{Pre: State{}, Post: State{}}, res)
+}
+
+func TestPrestateTracer_Create2Address(t *testing.T) {
+	t.Parallel()
+
+	host := &mockHost{accounts: map[types.Address]*mockAccount{}}
+	initCode := []byte{0x60, 0x00}
+	salt := types.StringToHash("5")
+
+	// the init code is not in the memory yet, it's padded with zeros
+	memory := []byte{0x60}
+
+	tracer := NewPrestateTracer(Config{})
+	tracer.CaptureState(memory, []*big.Int{
+		new(big.Int).SetBytes(salt.Bytes()),
+		big.NewInt(int64(len(initCode))),
+		big.NewInt(0),
+		big.NewInt(0),
+	}, evm.CREATE2, addr1, 4, host, &mockState{})
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, State{
+		crypto.CreateAddress2(addr1, salt, initCode): {Balance: "0x0"},
+	}, res)
+}
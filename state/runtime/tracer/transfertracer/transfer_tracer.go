@@ -0,0 +1,97 @@
+package transfertracer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// TransferLogAddress is the address of the logs of the native value transfers (ERC-7528)
+	TransferLogAddress = types.StringToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+	// TransferEventTopic is the topic of the Transfer(address,address,uint256) event
+	TransferEventTopic = types.StringToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+// LogEmitter is the interface of the state which receives the transfer logs
+type LogEmitter interface {
+	EmitLog(addr types.Address, topics []types.Hash, data []byte)
+}
+
+// TransferTracer emits an ERC-20 like Transfer log for every native value transfer,
+// the logs are emitted in the frame of the transfer so they are reverted together with it
+type TransferTracer struct {
+	emitter LogEmitter
+}
+
+func NewTransferTracer(emitter LogEmitter) *TransferTracer {
+	return &TransferTracer{
+		emitter: emitter,
+	}
+}
+
+func (t *TransferTracer) Cancel(err error) {
+}
+
+func (t *TransferTracer) Clear() {
+}
+
+func (t *TransferTracer) GetResult() (interface{}, error) {
+	return nil, nil
+}
+
+func (t *TransferTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *TransferTracer) TxEnd(gasLeft uint64) {
+}
+
+// CallStart is a no-op, the calls are captured by CallFrameStart
+func (t *TransferTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+}
+
+func (t *TransferTracer) CallFrameStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	// the value is transferred by the calls and the creations only
+	switch runtime.CallType(callType) {
+	case runtime.Call, runtime.Create, runtime.Create2:
+		t.emitTransfer(from, to, value)
+	}
+}
+
+func (t *TransferTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (t *TransferTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if opCode != evm.SELFDESTRUCT || sp < 1 {
+		return
+	}
+
+	t.emitTransfer(contractAddress, types.BytesToAddress(stack[sp-1].Bytes()), host.GetBalance(contractAddress))
+}
+
+func (t *TransferTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+func (t *TransferTracer) emitTransfer(from, to types.Address, value *big.Int) {
+	if value == nil || value.Sign() <= 0 {
+		return
+	}
+
+	t.emitter.EmitLog(
+		TransferLogAddress,
+		[]types.Hash{
+			TransferEventTopic,
+			types.BytesToHash(from.Bytes()),
+			types.BytesToHash(to.Bytes()),
+		},
+		types.BytesToHash(value.Bytes()).Bytes(),
+	)
+}
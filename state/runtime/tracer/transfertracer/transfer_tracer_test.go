@@ -0,0 +1,95 @@
+package transfertracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+)
+
+type mockEmitter struct {
+	logs []*types.Log
+}
+
+func (m *mockEmitter) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	m.logs = append(m.logs, &types.Log{Address: addr, Topics: topics, Data: data})
+}
+
+type mockHost struct {
+	balance *big.Int
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	return m.balance
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	return 0
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	return nil
+}
+
+func newTransferLog(from, to types.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: TransferLogAddress,
+		Topics: []types.Hash{
+			TransferEventTopic,
+			types.BytesToHash(from.Bytes()),
+			types.BytesToHash(to.Bytes()),
+		},
+		Data: types.BytesToHash(big.NewInt(value).Bytes()).Bytes(),
+	}
+}
+
+func TestTransferTracer_CallFrameStart(t *testing.T) {
+	t.Parallel()
+
+	emitter := &mockEmitter{}
+	tracer := NewTransferTracer(emitter)
+
+	tracer.CallFrameStart(1, addr1, addr2, int(runtime.Call), 100, big.NewInt(10), nil)
+	// the calls which don't transfer the value are ignored
+	tracer.CallFrameStart(2, addr2, addr1, int(runtime.Call), 100, big.NewInt(0), nil)
+	tracer.CallFrameStart(2, addr2, addr1, int(runtime.DelegateCall), 100, big.NewInt(10), nil)
+	tracer.CallFrameStart(2, addr2, addr1, int(runtime.StaticCall), 100, nil, nil)
+	tracer.CallFrameStart(2, addr2, addr1, int(runtime.Create2), 100, big.NewInt(3), nil)
+
+	require.Equal(t, []*types.Log{
+		newTransferLog(addr1, addr2, 10),
+		newTransferLog(addr2, addr1, 3),
+	}, emitter.logs)
+}
+
+func TestTransferTracer_Selfdestruct(t *testing.T) {
+	t.Parallel()
+
+	emitter := &mockEmitter{}
+	tracer := NewTransferTracer(emitter)
+
+	stack := []*big.Int{new(big.Int).SetBytes(addr2.Bytes())}
+
+	tracer.CaptureState(nil, stack, evm.SELFDESTRUCT, addr1, 1, &mockHost{balance: big.NewInt(0)}, nil)
+	require.Empty(t, emitter.logs)
+
+	tracer.CaptureState(nil, stack, evm.SELFDESTRUCT, addr1, 1, &mockHost{balance: big.NewInt(5)}, nil)
+	require.Equal(t, []*types.Log{newTransferLog(addr1, addr2, 5)}, emitter.logs)
+}
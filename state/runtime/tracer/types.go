@@ -12,6 +12,12 @@ type RuntimeHost interface {
 	GetRefund() uint64
 	// GetStorage access the storage slot at the given address and slot hash
 	GetStorage(types.Address, types.Hash) types.Hash
+	// GetBalance returns the balance of the given address
+	GetBalance(types.Address) *big.Int
+	// GetNonce returns the nonce of the given address
+	GetNonce(types.Address) uint64
+	// GetCode returns the code of the given address
+	GetCode(types.Address) []byte
 }
 
 type VMState interface {
@@ -68,3 +74,27 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// StateTracer is implemented by the tracers which need to access the state
+// of the accounts before and after the whole transaction is applied
+type StateTracer interface {
+	// TxStateStart is called before the transaction changes the state of the given accounts
+	TxStateStart(host RuntimeHost, addresses ...types.Address)
+	// TxStateEnd is called once all the changes of the transaction are applied
+	TxStateEnd(host RuntimeHost)
+}
+
+// CallFrameTracer is implemented by the tracers which need the frames of the calls as they are executed,
+// CallFrameStart is called instead of CallStart with the sender of a delegate call being the contract
+// which executes it, the callee being the address of the executed code
+// and the input of a creation being its init code
+type CallFrameTracer interface {
+	CallFrameStart(
+		depth int, // begins from 1
+		from, to types.Address,
+		callType int,
+		gas uint64,
+		value *big.Int,
+		input []byte,
+	)
+}
@@ -0,0 +1,78 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// TransientStorage is the storage which lives only for the duration
+// of a single transaction and is never written to the state (EIP-1153)
+type TransientStorage struct {
+	storage map[types.Address]map[types.Hash]types.Hash
+
+	// journal keeps track of the previous values, so they can be restored on revert
+	journal []transientStorageChange
+}
+
+type transientStorageChange struct {
+	address types.Address
+	key     types.Hash
+	prev    types.Hash
+}
+
+// NewTransientStorage creates an empty transient storage
+func NewTransientStorage() *TransientStorage {
+	return &TransientStorage{
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+// Get returns the value stored under the key for the given address
+func (ts *TransientStorage) Get(address types.Address, key types.Hash) types.Hash {
+	return ts.storage[address][key]
+}
+
+// Set stores the value under the key for the given address
+func (ts *TransientStorage) Set(address types.Address, key types.Hash, value types.Hash) {
+	prev := ts.Get(address, key)
+	if prev == value {
+		return
+	}
+
+	ts.journal = append(ts.journal, transientStorageChange{address: address, key: key, prev: prev})
+	ts.set(address, key, value)
+}
+
+func (ts *TransientStorage) set(address types.Address, key types.Hash, value types.Hash) {
+	if value == types.ZeroHash {
+		delete(ts.storage[address], key)
+
+		if len(ts.storage[address]) == 0 {
+			delete(ts.storage, address)
+		}
+
+		return
+	}
+
+	slots, ok := ts.storage[address]
+	if !ok {
+		slots = map[types.Hash]types.Hash{}
+		ts.storage[address] = slots
+	}
+
+	slots[key] = value
+}
+
+// Snapshot returns the identifier of the current transient storage revision
+func (ts *TransientStorage) Snapshot() int {
+	return len(ts.journal)
+}
+
+// RevertToSnapshot restores all the values modified after the given snapshot
+func (ts *TransientStorage) RevertToSnapshot(id int) {
+	for i := len(ts.journal) - 1; i >= id; i-- {
+		change := ts.journal[i]
+		ts.set(change.address, change.key, change.prev)
+	}
+
+	ts.journal = ts.journal[:id]
+}
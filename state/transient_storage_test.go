@@ -0,0 +1,50 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestTransientStorage_SetGet(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	key := types.StringToHash("2")
+	value := types.StringToHash("3")
+
+	ts := NewTransientStorage()
+	require.Equal(t, types.ZeroHash, ts.Get(addr, key))
+
+	ts.Set(addr, key, value)
+	require.Equal(t, value, ts.Get(addr, key))
+	require.Equal(t, types.ZeroHash, ts.Get(types.StringToAddress("4"), key))
+
+	ts.Set(addr, key, types.ZeroHash)
+	require.Equal(t, types.ZeroHash, ts.Get(addr, key))
+	require.Empty(t, ts.storage)
+}
+
+func TestTransientStorage_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	key1 := types.StringToHash("2")
+	key2 := types.StringToHash("3")
+
+	ts := NewTransientStorage()
+	ts.Set(addr, key1, types.StringToHash("4"))
+
+	snapshot := ts.Snapshot()
+
+	ts.Set(addr, key1, types.StringToHash("5"))
+	ts.Set(addr, key2, types.StringToHash("6"))
+
+	ts.RevertToSnapshot(snapshot)
+
+	require.Equal(t, types.StringToHash("4"), ts.Get(addr, key1))
+	require.Equal(t, types.ZeroHash, ts.Get(addr, key2))
+	require.Len(t, ts.journal, snapshot)
+}
@@ -249,7 +249,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 				continue
 			}
 
-			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block, peerID.String())
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
 
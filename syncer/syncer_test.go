@@ -59,7 +59,7 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
-func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock, error) {
+func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block, s string) (*types.FullBlock, error) {
 	return m.verifyFinalizedBlockHandler(b)
 }
 
@@ -28,7 +28,7 @@ type Blockchain interface {
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	// VerifyFinalizedBlock verifies finalized block
-	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
+	VerifyFinalizedBlock(block *types.Block, source string) (*types.FullBlock, error)
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
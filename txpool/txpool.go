@@ -65,6 +65,7 @@ var (
 	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
 	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrAccessListNotAllowed    = errors.New("access list not allowed before berlin hardfork")
 )
 
 // indicates origin of a transaction
@@ -488,6 +489,34 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 	})
 }
 
+// Reset drops the transactions of all the known accounts and rolls their nonces back
+// to the state of the current head. It's called once the head of the chain is rewound,
+// as the pool can't tell which of its transactions are still valid
+func (p *TxPool) Reset() {
+	header := p.store.Header()
+
+	p.accounts.Range(
+		func(key, value interface{}) bool {
+			address, _ := key.(types.Address)
+			account, _ := value.(*account)
+
+			nextNonce := p.store.GetNonce(header.StateRoot, address)
+
+			if firstTx := account.getLowestTx(); firstTx != nil {
+				p.dropAccount(account, nextNonce, firstTx)
+			} else {
+				account.setNonce(nextNonce)
+			}
+
+			account.resetSkips()
+
+			return true
+		},
+	)
+
+	p.SetBaseFee(header)
+}
+
 // processEvent collects the latest nonces for each account contained
 // in the received event. Resets all known accounts with the new nonce.
 func (p *TxPool) processEvent(event *blockchain.Event) {
@@ -618,6 +647,21 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	latestBlockGasLimit := currentHeader.GasLimit
 	baseFee := p.GetBaseFee() // base fee is calculated for the next block
 
+	// Reject access list tx if berlin hardfork is not enabled
+	if tx.Type == types.AccessListTx && !forks.Berlin {
+		metrics.IncrCounter([]string{txPoolMetrics, "tx_type"}, 1)
+
+		return fmt.Errorf("%w: type %d rejected, berlin hardfork is not enabled", ErrTxTypeNotSupported, tx.Type)
+	}
+
+	// Reject the access list of any tx type if berlin hardfork is not enabled,
+	// it would be neither charged nor applied by the executor
+	if len(tx.AccessList) > 0 && !forks.Berlin {
+		metrics.IncrCounter([]string{txPoolMetrics, "access_list_not_allowed_tx"}, 1)
+
+		return ErrAccessListNotAllowed
+	}
+
 	if tx.Type == types.DynamicFeeTx {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !forks.London {
@@ -703,7 +747,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, forks.Homestead, forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, forks.Homestead, forks.Istanbul, forks.Berlin, forks.Shanghai)
 	if err != nil {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_intrinsic_gas_tx"}, 1)
 
@@ -778,8 +822,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		return err
 	}
 
-	// add chainID to the tx - only dynamic fee tx
-	if tx.Type == types.DynamicFeeTx {
+	// add chainID to the tx - only dynamic fee and access list txs
+	if tx.Type == types.DynamicFeeTx || tx.Type == types.AccessListTx {
 		tx.ChainID = p.chainID
 	}
 
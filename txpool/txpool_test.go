@@ -175,6 +175,25 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("ErrAccessListNotAllowed Berlin hardfork not enabled", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.forks.RemoveFork(chain.Berlin)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasFeeCap = big.NewInt(1)
+		tx.GasTipCap = big.NewInt(1)
+		tx.AccessList = types.TxAccessList{
+			{Address: defaultAddr, StorageKeys: []types.Hash{types.ZeroHash}},
+		}
+
+		assert.ErrorIs(t,
+			pool.addTx(local, signTx(tx)),
+			ErrAccessListNotAllowed,
+		)
+	})
+
 	t.Run("ErrNegativeValue", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
@@ -1873,6 +1892,45 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, (*types.Transaction)(nil), acc.nonceToTx.get(tx1.Nonce))
 }
 
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// one promoted and one enqueued transaction
+	tx1 := newTx(addr1, 0, 1)
+	assert.NoError(t, pool.addTx(local, tx1))
+
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	tx2 := newTx(addr1, 2, 1)
+	assert.NoError(t, pool.addTx(local, tx2))
+
+	// the account without transactions, whose nonce is ahead of the state
+	pool.accounts.initOnce(addr2, 5)
+
+	assert.Equal(t, uint64(2), pool.gauge.read())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+
+	pool.Reset()
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr2).getNonce())
+
+	for _, tx := range []*types.Transaction{tx1, tx2} {
+		_, exists := pool.index.get(tx.Hash)
+		assert.False(t, exists)
+	}
+
+	// the dropped transaction can be added again
+	assert.NoError(t, pool.addTx(local, tx1.Copy()))
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+)
+
+// AccessTuple is the element type of an access list (EIP-2930)
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the list of addresses and storage keys
+// that the transaction plans to access (EIP-2930)
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+
+	return sum
+}
+
+// Copy creates a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	newAccessList := make(TxAccessList, len(al))
+
+	for i, item := range al {
+		var copiedAddress Address
+
+		copy(copiedAddress[:], item.Address[:])
+		newAccessList[i] = AccessTuple{
+			Address:     copiedAddress,
+			StorageKeys: append([]Hash{}, item.StorageKeys...),
+		}
+	}
+
+	return newAccessList
+}
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(al) == 0 {
+		return arena.NewNullArray()
+	}
+
+	accessListVV := arena.NewArray()
+
+	for _, accessTuple := range al {
+		accessTupleVV := arena.NewArray()
+		accessTupleVV.Set(arena.NewCopyBytes(accessTuple.Address.Bytes()))
+
+		storageKeysVV := arena.NewArray()
+		for _, storageKey := range accessTuple.StorageKeys {
+			storageKeysVV.Set(arena.NewCopyBytes(storageKey.Bytes()))
+		}
+
+		accessTupleVV.Set(storageKeysVV)
+		accessListVV.Set(accessTupleVV)
+	}
+
+	return accessListVV
+}
+
+// unmarshalRLPFrom unmarshals the access list from RLP
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	accessListVV, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(accessListVV) == 0 {
+		*al = nil
+
+		return nil
+	}
+
+	*al = make(TxAccessList, len(accessListVV))
+
+	for i, accessTupleVV := range accessListVV {
+		accessTupleElems, err := accessTupleVV.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if numElems := len(accessTupleElems); numElems != 2 {
+			return fmt.Errorf("incorrect number of access tuple elements, expected 2 but found %d", numElems)
+		}
+
+		// Read the address
+		addressVVBytes, err := accessTupleElems[0].Bytes()
+		if err != nil {
+			return err
+		}
+
+		if len(addressVVBytes) != AddressLength {
+			return fmt.Errorf("incorrect access tuple address length %d", len(addressVVBytes))
+		}
+
+		(*al)[i].Address = BytesToAddress(addressVVBytes)
+
+		// Read the storage keys
+		storageKeysArrayVV, err := accessTupleElems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		(*al)[i].StorageKeys = make([]Hash, len(storageKeysArrayVV))
+
+		for j, storageKeyVV := range storageKeysArrayVV {
+			storageKey, err := storageKeyVV.Bytes()
+			if err != nil {
+				return err
+			}
+
+			if len(storageKey) != HashLength {
+				return fmt.Errorf("incorrect access tuple storage key length %d", len(storageKey))
+			}
+
+			(*al)[i].StorageKeys[j] = BytesToHash(storageKey)
+		}
+	}
+
+	return nil
+}
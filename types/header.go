@@ -98,6 +98,10 @@ type Body struct {
 type FullBlock struct {
 	Block    *Block
 	Receipts []*Receipt
+
+	// TxAddresses are the addresses touched by the transactions, collected by the execution
+	// of the block if the address index is enabled
+	TxAddresses [][]Address
 }
 
 type Block struct {
@@ -150,11 +150,23 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 		V:         big.NewInt(25),
 		S:         big.NewInt(26),
 		R:         big.NewInt(27),
+		ChainID:   big.NewInt(100),
+		AccessList: TxAccessList{
+			{
+				Address:     StringToAddress("33"),
+				StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+			},
+			{
+				Address:     StringToAddress("44"),
+				StorageKeys: []Hash{},
+			},
+		},
 	}
 
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
@@ -171,6 +183,13 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 			unmarshalledTx.ComputeHash(1)
 			assert.Equal(t, originalTx.Type, unmarshalledTx.Type)
 			assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+
+			if v == AccessListTx || v == DynamicFeeTx {
+				assert.Equal(t, originalTx.ChainID, unmarshalledTx.ChainID)
+				assert.Equal(t, originalTx.AccessList, unmarshalledTx.AccessList)
+			} else {
+				assert.Nil(t, unmarshalledTx.AccessList)
+			}
 		})
 	}
 }
@@ -181,11 +200,13 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
 	for _, txType := range txTypes {
 		txType := txType
+		hasChainID := txType == DynamicFeeTx || txType == AccessListTx
 		testTable := []struct {
 			name          string
 			expectedErr   bool
@@ -204,11 +225,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Missing From", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    !hasChainID,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": !hasChainID,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -217,11 +238,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Address set for state tx only", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    !hasChainID,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": !hasChainID,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -270,6 +291,10 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "LegacyTx",
 			txType: LegacyTx,
 		},
+		{
+			name:   "AccessListTx",
+			txType: AccessListTx,
+		},
 		{
 			name:   "DynamicFeeTx",
 			txType: DynamicFeeTx,
@@ -407,6 +407,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 9
 	case StateTx:
 		num = 10
+	case AccessListTx:
+		num = 11
 	case DynamicFeeTx:
 		num = 12
 	default:
@@ -417,8 +419,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return fmt.Errorf("incorrect number of transaction elements, expected %d but found %d", num, numElems)
 	}
 
-	// Load Chain ID for dynamic transactions
-	if t.Type == DynamicFeeTx {
+	// Load Chain ID for dynamic fee and access list transactions
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
 		t.ChainID = new(big.Int)
 		if err = getElem().GetBigInt(t.ChainID); err != nil {
 			return err
@@ -476,11 +478,11 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	// Skipping Access List field since we don't support it.
-	// This is needed to be compatible with other EVM chains and have the same format.
-	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
-		_ = getElem()
+	// access list
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
+		if err = t.AccessList.unmarshalRLPFrom(p, getElem()); err != nil {
+			return err
+		}
 	}
 
 	// V
@@ -20,6 +20,7 @@ type TxType byte
 const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
+	AccessListTx TxType = 0x01
 	DynamicFeeTx TxType = 0x02
 )
 
@@ -27,7 +28,7 @@ func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, AccessListTx, DynamicFeeTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -41,6 +42,8 @@ func (t TxType) String() (s string) {
 		return "LegacyTx"
 	case StateTx:
 		return "StateTx"
+	case AccessListTx:
+		return "AccessListTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
 	}
@@ -65,6 +68,8 @@ type Transaction struct {
 
 	ChainID *big.Int
 
+	AccessList TxAccessList
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -141,6 +146,8 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
@@ -209,3 +209,45 @@ type OverrideAccount struct {
 }
 
 type StateOverride map[Address]OverrideAccount
+
+// BlockOverride is the set of block context fields overridden while executing a call
+type BlockOverride struct {
+	Number     *uint64
+	Difficulty *uint64
+	Time       *uint64
+	GasLimit   *uint64
+	Coinbase   *Address
+	BaseFee    *uint64
+}
+
+// Apply returns a copy of the header with the overridden fields applied.
+// The coinbase is not part of the header, so it has to be applied by the caller
+func (o *BlockOverride) Apply(header *Header) *Header {
+	if o == nil {
+		return header
+	}
+
+	res := header.Copy()
+
+	if o.Number != nil {
+		res.Number = *o.Number
+	}
+
+	if o.Difficulty != nil {
+		res.Difficulty = *o.Difficulty
+	}
+
+	if o.Time != nil {
+		res.Timestamp = *o.Time
+	}
+
+	if o.GasLimit != nil {
+		res.GasLimit = *o.GasLimit
+	}
+
+	if o.BaseFee != nil {
+		res.BaseFee = *o.BaseFee
+	}
+
+	return res
+}
@@ -112,3 +112,35 @@ func TestIsValidAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockOverride_Apply(t *testing.T) {
+	t.Parallel()
+
+	header := &Header{
+		Number:     1,
+		Difficulty: 2,
+		Timestamp:  3,
+		GasLimit:   4,
+		BaseFee:    5,
+	}
+
+	var nilOverride *BlockOverride
+	require.Equal(t, header, nilOverride.Apply(header))
+
+	number, timestamp, baseFee := uint64(10), uint64(30), uint64(50)
+
+	res := (&BlockOverride{
+		Number:  &number,
+		Time:    &timestamp,
+		BaseFee: &baseFee,
+	}).Apply(header)
+
+	require.Equal(t, uint64(10), res.Number)
+	require.Equal(t, uint64(2), res.Difficulty)
+	require.Equal(t, uint64(30), res.Timestamp)
+	require.Equal(t, uint64(4), res.GasLimit)
+	require.Equal(t, uint64(50), res.BaseFee)
+
+	// the original header is not modified
+	require.Equal(t, uint64(1), header.Number)
+}